@@ -0,0 +1,52 @@
+// Command genparsers generates the element parsers of the package obj/parser that do not use reflection.
+//
+// Usage:
+//
+// 	genparsers [-o file]
+//
+// The source generated by parser.GenerateParsers is written to the file or to the standard output.
+// The command is run by go generate in the obj/parser directory with the reflectparsers tag,
+// so that the stale generated parsers do not need to compile to regenerate them:
+//
+// 	go generate ./obj/parser
+package main
+
+import (
+	"bytes"
+	"computer_graphics/obj/parser"
+	"flag"
+	"fmt"
+	"os"
+)
+
+// The file to which the generated source is written.
+var output = flag.String("o", "", "the file to which the generated source is written, the standard output by default")
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [-o file]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err := run(); err != nil {
+		fmt.Fprintf(os.Stderr, "genparsers: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+// Generates the source and writes it to the output.
+func run() error {
+	var source bytes.Buffer
+	if err := parser.GenerateParsers(&source); err != nil {
+		return err
+	}
+	if *output == "" {
+		_, err := os.Stdout.Write(source.Bytes())
+		return err
+	}
+	return os.WriteFile(*output, source.Bytes(), 0666)
+}
@@ -0,0 +1,230 @@
+// Command objbench measures the time and the memory spent by each stage of the rendering of a model
+// specified by the .obj file, so that the performance problems can be reported in the same way.
+//
+// Usage:
+//
+// 	objbench [flags] model.obj
+//
+// The file is read into memory once, then each stage is run several times:
+// the scanning of the tokens, the parsing of the elements, the import of the model,
+// the generation of the normals and the rendering of the model to an image.
+// The table with the best and the mean time and the allocations of each run is written to the standard output.
+// If the -profile flag is set, a CPU profile of the runs of each stage is written to the directory,
+// for example, cpu_parse.pprof, together with the allocations profile of the whole command allocs.pprof,
+// which can be viewed by 'go tool pprof'.
+package main
+
+import (
+	"bytes"
+	"computer_graphics/model"
+	"computer_graphics/obj/importer"
+	"computer_graphics/obj/parser"
+	"computer_graphics/obj/scanner"
+	"computer_graphics/pngimage"
+	"computer_graphics/render"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"text/tabwriter"
+	"time"
+)
+
+// A stage of the rendering measured by the command.
+type stage struct {
+	name    string // The name of the stage in the table and in the names of the profiles.
+	reads   bool   // Whether the stage reads the file, the throughput is shown only for such stages.
+	prepare func() // Prepares the input of the stage once before the runs without measuring it, nil if it is not needed.
+	run     func() // Performs the stage once.
+}
+
+// The measurements of the runs of a stage.
+type result struct {
+	best, total time.Duration // The time of the fastest run and of all runs.
+	allocs      uint64        // The number of the allocations of all runs.
+	bytes       uint64        // The number of the allocated bytes of all runs.
+}
+
+// The command line flags.
+var (
+	runs       = flag.Int("runs", 5, "the number of runs of each stage")
+	size       = flag.Uint("size", 1000, "the width and the height of the rendered image in pixels")
+	material   = flag.String("material", "gouraud", "the material of the model: gouraud or normal")
+	profileDir = flag.String("profile", "", "write the CPU profiles of the stages and the allocations profile to this directory")
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] model.obj\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err := run(flag.Arg(0)); err != nil {
+		fmt.Fprintf(os.Stderr, "objbench: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+// Measures the stages of the rendering of the model from the file and writes the table according to the flags.
+func run(path string) error {
+	if *runs <= 0 {
+		return fmt.Errorf("the number of runs must be positive")
+	}
+	if *size == 0 {
+		return fmt.Errorf("the size of the image must be positive")
+	}
+	if *material != "gouraud" && *material != "normal" {
+		return fmt.Errorf("unknown material: %q", *material)
+	}
+	var data, err = os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if *profileDir != "" {
+		if err = os.MkdirAll(*profileDir, os.ModePerm); err != nil {
+			return err
+		}
+	}
+	var (
+		m      *model.Model
+		stages = []stage{
+			{name: "scan", reads: true, run: func() { scan(data) }},
+			{name: "parse", reads: true, run: func() { parse(data) }},
+			{name: "import", reads: true, run: func() { m = importModel(data) }},
+			// The model is fitted to the image before the normals are generated, since they must be generated after transforming it.
+			{name: "normals", prepare: func() { render.FitToImage(m, *size) }, run: func() { m.GenerateNormals() }},
+			{name: "render", run: func() { renderModel(m, *size) }},
+		}
+		results = make([]result, len(stages))
+	)
+	for i, s := range stages {
+		if results[i], err = measure(s); err != nil {
+			return err
+		}
+	}
+	if *profileDir != "" {
+		if err = writeProfile("allocs", filepath.Join(*profileDir, "allocs.pprof")); err != nil {
+			return err
+		}
+	}
+	fmt.Printf("%s: %d bytes, %d vertices, %d faces, %d runs\n", path, len(data), m.VerticesCount(), m.FacesCount(), *runs)
+	return writeTable(stages, results, len(data))
+}
+
+// Runs the stage the number of times specified by the flags and returns the measurements,
+// the CPU profile of the runs is written if the profiles are requested.
+func measure(s stage) (result, error) {
+	if s.prepare != nil {
+		s.prepare()
+	}
+	if *profileDir != "" {
+		var file, err = os.Create(filepath.Join(*profileDir, "cpu_"+s.name+".pprof"))
+		if err != nil {
+			return result{}, err
+		}
+		defer file.Close()
+		if err = pprof.StartCPUProfile(file); err != nil {
+			return result{}, err
+		}
+		defer pprof.StopCPUProfile()
+	}
+	var (
+		r             = result{best: math.MaxInt64}
+		before, after runtime.MemStats
+	)
+	for i := 0; i < *runs; i++ {
+		// The garbage of the previous runs is collected, so that it does not slow down the measured run.
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+		var start = time.Now()
+		s.run()
+		var elapsed = time.Since(start)
+		runtime.ReadMemStats(&after)
+		r.total += elapsed
+		if elapsed < r.best {
+			r.best = elapsed
+		}
+		r.allocs += after.Mallocs - before.Mallocs
+		r.bytes += after.TotalAlloc - before.TotalAlloc
+	}
+	return r, nil
+}
+
+// Writes the table with the measurements of the stages, the throughput of the best run is calculated for the size of the file.
+func writeTable(stages []stage, results []result, fileSize int) error {
+	var w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "stage\tbest\tmean\tMB/s\tallocs/run\tKB/run\t")
+	for i, s := range stages {
+		var (
+			r    = results[i]
+			n    = uint64(*runs)
+			rate = "-"
+		)
+		if s.reads {
+			rate = fmt.Sprintf("%.1f", float64(fileSize)/r.best.Seconds()/1e6)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%d\t\n",
+			s.name,
+			r.best.Round(time.Microsecond),
+			(r.total / time.Duration(*runs)).Round(time.Microsecond),
+			rate,
+			r.allocs/n,
+			r.bytes/n/1024,
+		)
+	}
+	return w.Flush()
+}
+
+// Writes the named runtime profile to the file.
+func writeProfile(name, path string) error {
+	var file, err = os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err = pprof.Lookup(name).WriteTo(file, 0); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// Reads all tokens of the file.
+func scan(data []byte) {
+	var s = scanner.NewScanner(bytes.NewReader(data))
+	for tokenType, _ := s.Next(); tokenType != scanner.EOF; tokenType, _ = s.Next() {
+	}
+}
+
+// Reads all elements of the file without importing them.
+func parse(data []byte) {
+	var p = parser.NewParser(bytes.NewReader(data))
+	p.Output(nil)
+	for elementType, _ := p.Next(); elementType != parser.EndOfFile; elementType, _ = p.Next() {
+	}
+}
+
+// Imports the model from the file without printing the problems.
+func importModel(data []byte) *model.Model {
+	var i = importer.Importer{}
+	return i.Import(bytes.NewReader(data))
+}
+
+// Renders the model to a new image of the specified size with the material specified by the flags.
+func renderModel(m *model.Model, size uint) {
+	var (
+		img      = pngimage.BlackImage(size, size)
+		renderer = render.NewRenderer(img)
+	)
+	if *material == "normal" {
+		renderer.Render(m, render.NewNormalMaterial())
+		return
+	}
+	renderer.Render(m, render.NewGouraudMaterial(m, pngimage.WhiteColor()))
+}
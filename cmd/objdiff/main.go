@@ -0,0 +1,220 @@
+// Command objdiff compares two models specified by the .obj files.
+//
+// Usage:
+//
+// 	objdiff [flags] source.obj target.obj
+//
+// The source model is optionally aligned with the target one using the ICP algorithm,
+// then the distances from the vertices of each model to the nearest vertices of the other one are calculated.
+// The report with the statistics of the distances and the Hausdorff distance is written in the JSON format,
+// the source model colored by the distances to the target one can be rendered to a PNG image.
+package main
+
+import (
+	"computer_graphics/mathutils"
+	"computer_graphics/model"
+	"computer_graphics/obj/importer"
+	"computer_graphics/pngimage"
+	"computer_graphics/render"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+)
+
+// The statistics of the distances from the vertices of one model to the other one.
+type distanceReport struct {
+	Mean   float64 `json:"mean"`   // The mean distance.
+	RMS    float64 `json:"rms"`    // The root mean square distance.
+	Median float64 `json:"median"` // The median distance.
+	P95    float64 `json:"p95"`    // The 95th percentile of the distances.
+	Max    float64 `json:"max"`    // The maximum distance.
+}
+
+// Creates a new distanceReport from the distances.
+func newDistanceReport(distances []float64) distanceReport {
+	var (
+		percentiles = mathutils.Percentiles(distances, 50, 95)
+		squares     = make([]float64, len(distances))
+	)
+	for i, d := range distances {
+		squares[i] = d * d
+	}
+	return distanceReport{
+		Mean:   mathutils.Mean(distances),
+		RMS:    math.Sqrt(mathutils.Mean(squares)),
+		Median: percentiles[0],
+		P95:    percentiles[1],
+		Max:    mathutils.Percentile(distances, 100),
+	}
+}
+
+// The result of the alignment of the source model with the target one.
+type alignmentReport struct {
+	Iterations  int        `json:"iterations"`  // The number of iterations performed.
+	Converged   bool       `json:"converged"`   // Whether the alignment converged before the iterations ran out.
+	Error       float64    `json:"error"`       // The root mean square distance between the matched points.
+	Angle       float64    `json:"angle"`       // The angle of the rotation applied to the source model in radians.
+	Translation [3]float64 `json:"translation"` // The translation applied to the source model after the rotation.
+}
+
+// The report written by the command.
+type report struct {
+	Source         string           `json:"source"`              // The path to the source model.
+	Target         string           `json:"target"`              // The path to the target model.
+	SourceVertices int              `json:"source_vertices"`     // The number of vertices of the source model.
+	TargetVertices int              `json:"target_vertices"`     // The number of vertices of the target model.
+	Alignment      *alignmentReport `json:"alignment,omitempty"` // The result of the alignment, if it was performed.
+	SourceToTarget distanceReport   `json:"source_to_target"`    // The distances from the source vertices to the target.
+	TargetToSource distanceReport   `json:"target_to_source"`    // The distances from the target vertices to the source.
+	MeanDistance   float64          `json:"mean_distance"`       // The mean of the distances in both directions.
+	Hausdorff      float64          `json:"hausdorff"`           // The Hausdorff distance between the vertices of the models.
+	Image          string           `json:"image,omitempty"`     // The path to the rendered heatmap, if it was rendered.
+}
+
+// The command line flags.
+var (
+	align         = flag.Bool("align", false, "align the source model with the target one using ICP before comparing")
+	pointToPlane  = flag.Bool("point-to-plane", false, "use the point-to-plane ICP instead of the point-to-point one")
+	maxIterations = flag.Int("iterations", 50, "the maximum number of ICP iterations")
+	maxDistance   = flag.Float64("max-distance", 0, "ignore the ICP pairs farther than this distance, 0 means no limit")
+	imagePath     = flag.String("image", "", "render the source model colored by the distances to this PNG file")
+	imageSize     = flag.Uint("size", 1000, "the width and the height of the rendered image in pixels")
+	reportPath    = flag.String("report", "", "write the JSON report to this file instead of the standard output")
+	quiet         = flag.Bool("quiet", false, "do not print the import warnings")
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] source.obj target.obj\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err := run(flag.Arg(0), flag.Arg(1)); err != nil {
+		fmt.Fprintf(os.Stderr, "objdiff: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+// Compares the models and writes the report according to the flags.
+func run(sourcePath, targetPath string) error {
+	var source, err = importModel(sourcePath)
+	if err != nil {
+		return err
+	}
+	target, err := importModel(targetPath)
+	if err != nil {
+		return err
+	}
+	if source.VerticesCount() == 0 || target.VerticesCount() == 0 {
+		return fmt.Errorf("the models must have vertices")
+	}
+	var r = &report{
+		Source:         sourcePath,
+		Target:         targetPath,
+		SourceVertices: source.VerticesCount(),
+		TargetVertices: target.VerticesCount(),
+	}
+	if *align {
+		var result = model.AlignICP(source, target, model.ICPOptions{
+			MaxIterations: *maxIterations,
+			MaxDistance:   *maxDistance,
+			PointToPlane:  *pointToPlane,
+		})
+		source.Transform(result.Transform.Apply)
+		var t = result.Transform.Translation
+		r.Alignment = &alignmentReport{
+			Iterations:  result.Iterations,
+			Converged:   result.Converged,
+			Error:       result.Error,
+			Angle:       result.Transform.Angle(),
+			Translation: [3]float64{t.X, t.Y, t.Z},
+		}
+	}
+	var (
+		forward  = source.DistancesTo(target)
+		backward = target.DistancesTo(source)
+	)
+	r.SourceToTarget = newDistanceReport(forward)
+	r.TargetToSource = newDistanceReport(backward)
+	r.MeanDistance = mathutils.Mean(append(append([]float64{}, forward...), backward...))
+	r.Hausdorff = math.Max(r.SourceToTarget.Max, r.TargetToSource.Max)
+	if *imagePath != "" {
+		if err = renderHeatmap(source, forward, *imagePath, *imageSize); err != nil {
+			return err
+		}
+		r.Image = *imagePath
+	}
+	return writeReport(r, *reportPath)
+}
+
+// Imports the model from the .obj file.
+func importModel(path string) (*model.Model, error) {
+	var file, err = os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	var i = importer.Importer{Output: os.Stderr, IgnoreInfos: true}
+	if *quiet {
+		i.Output = nil
+	}
+	return i.Import(file), nil
+}
+
+// Renders the model colored by the distances of its vertices to the PNG image of the specified size.
+// The model is scaled to fit the image and viewed along the Z axis.
+func renderHeatmap(m *model.Model, distances []float64, path string, size uint) error {
+	if err := m.SetVertexAttribute(model.DistanceAttribute, distances); err != nil {
+		return err
+	}
+	var (
+		min, max = m.Bounds()
+		extent   = math.Max(max.X-min.X, max.Y-min.Y)
+		scale    = 0.9 * float64(size) / extent
+		center   = model.Vertex{X: (min.X + max.X) / 2, Y: (min.Y + max.Y) / 2, Z: (min.Z + max.Z) / 2}
+		half     = float64(size) / 2
+	)
+	if extent == 0 {
+		scale = 1
+	}
+	m.Transform(func(x, y, z float64) (float64, float64, float64) {
+		// The Y axis of the image is directed downwards, and the points with the smaller Z are drawn on top.
+		return half + (x-center.X)*scale, half - (y-center.Y)*scale, (center.Z - z) * scale
+	})
+	var (
+		img      = pngimage.BlackImage(size, size)
+		renderer = render.NewRenderer(img)
+	)
+	renderer.Render(m, render.NewHeatmapMaterial(distances))
+	return img.Save(path)
+}
+
+// Writes the report in the JSON format to the file or to the standard output if the path is empty.
+func writeReport(r *report, path string) error {
+	if path == "" {
+		return encodeReport(r, os.Stdout)
+	}
+	var file, err = os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err = encodeReport(r, file); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// Writes the report in the JSON format to the writer.
+func encodeReport(r *report, out io.Writer) error {
+	var encoder = json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
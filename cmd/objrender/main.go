@@ -0,0 +1,297 @@
+// Command objrender renders the models specified by the .obj files to PNG images
+// and creates a gallery of the renders with an index.html contact sheet.
+//
+// Usage:
+//
+// 	objrender [flags] path ...
+//
+// Each path is a .obj file, a directory whose .obj files are rendered recursively
+// or a glob pattern like 'assets/*.obj'.
+// The settings of the renders are taken from the JSON config file specified by the -config flag,
+// the flags set on the command line override the config file. Example of the config file:
+//
+// 	{
+// 		"output": "gallery",
+// 		"size": 512,
+// 		"material": "unlit",
+// 		"color": [224, 90, 0],
+// 		"background": [32, 32, 32],
+// 		"rotate": [0, 3.14159, 0]
+// 	}
+//
+// Each model is rotated by the angles around the axes, scaled to fit the image and viewed along the Z axis.
+package main
+
+import (
+	"computer_graphics/obj/importer"
+	"computer_graphics/pngimage"
+	"computer_graphics/render"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// The settings of the renders read from the config file.
+type config struct {
+	Output     string     `json:"output"`     // The directory to which the renders and the index.html are written.
+	Size       uint       `json:"size"`       // The width and the height of the renders in pixels.
+	Material   string     `json:"material"`   // The material of the models: normal, unlit or checker.
+	Color      [3]uint8   `json:"color"`      // The color of the unlit material.
+	Background [3]uint8   `json:"background"` // The color of the background.
+	Rotate     [3]float64 `json:"rotate"`     // The angles of the rotation of the models around the axes in radians.
+}
+
+// The default settings of the renders.
+var defaultConfig = config{
+	Output:   "gallery",
+	Size:     512,
+	Material: "normal",
+	Color:    [3]uint8{255, 255, 255},
+}
+
+// A rendered model shown in the contact sheet.
+type entry struct {
+	Source   string // The path to the .obj file.
+	Image    string // The name of the render in the output directory.
+	Vertices int    // The number of vertices of the model.
+	Faces    int    // The number of faces of the model.
+}
+
+// The contact sheet of the gallery.
+var indexTemplate = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>objrender gallery</title>
+<style>
+body { font-family: sans-serif; background: #202020; color: #e0e0e0; }
+figure { display: inline-block; margin: 8px; }
+img { width: 256px; height: 256px; }
+figcaption { font-size: 12px; }
+</style>
+</head>
+<body>
+{{range .}}<figure>
+<a href="{{.Image}}"><img src="{{.Image}}" alt="{{.Source}}"></a>
+<figcaption>{{.Source}}<br>{{.Vertices}} vertices, {{.Faces}} faces</figcaption>
+</figure>
+{{end}}</body>
+</html>
+`))
+
+// The command line flags.
+var (
+	configPath = flag.String("config", "", "read the settings of the renders from this JSON file")
+	output     = flag.String("out", defaultConfig.Output, "write the renders and the index.html to this directory")
+	size       = flag.Uint("size", defaultConfig.Size, "the width and the height of the renders in pixels")
+	material   = flag.String("material", defaultConfig.Material, "the material of the models: normal, unlit or checker")
+	quiet      = flag.Bool("quiet", false, "do not print the import warnings")
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] path ...\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err := run(flag.Args()); err != nil {
+		fmt.Fprintf(os.Stderr, "objrender: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+// Renders the models specified by the paths and writes the gallery according to the config and the flags.
+func run(paths []string) error {
+	var c, err = loadConfig()
+	if err != nil {
+		return err
+	}
+	sources, err := expandPaths(paths)
+	if err != nil {
+		return err
+	}
+	if len(sources) == 0 {
+		return fmt.Errorf("no .obj files found")
+	}
+	if err = os.MkdirAll(c.Output, os.ModePerm); err != nil {
+		return err
+	}
+	var (
+		entries []entry
+		failed  int
+		names   = make(map[string]bool)
+	)
+	for _, source := range sources {
+		var e, err = renderModel(source, uniqueName(source, names), c)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "objrender: %s: %s\n", source, err)
+			failed++
+			continue
+		}
+		entries = append(entries, e)
+	}
+	if err = writeIndex(entries, filepath.Join(c.Output, "index.html")); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d models are not rendered", failed, len(sources))
+	}
+	return nil
+}
+
+// Reads the config file if it is specified and applies the flags set on the command line.
+func loadConfig() (config, error) {
+	var c = defaultConfig
+	if *configPath != "" {
+		var data, err = os.ReadFile(*configPath)
+		if err != nil {
+			return c, err
+		}
+		if err = json.Unmarshal(data, &c); err != nil {
+			return c, fmt.Errorf("invalid config %s: %w", *configPath, err)
+		}
+	}
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "out":
+			c.Output = *output
+		case "size":
+			c.Size = *size
+		case "material":
+			c.Material = *material
+		}
+	})
+	if c.Size == 0 {
+		return c, fmt.Errorf("the size of the renders must be positive")
+	}
+	if _, err := newMaterial(c); err != nil {
+		return c, err
+	}
+	return c, nil
+}
+
+// Returns the sorted paths of the .obj files specified by the files, the directories and the glob patterns.
+func expandPaths(paths []string) ([]string, error) {
+	var (
+		sources []string
+		seen    = make(map[string]bool)
+		add     = func(path string) {
+			if !seen[path] {
+				seen[path] = true
+				sources = append(sources, path)
+			}
+		}
+	)
+	for _, pattern := range paths {
+		var matches, err = filepath.Glob(pattern)
+		if err != nil {
+			return nil, err
+		}
+		if matches == nil {
+			return nil, fmt.Errorf("%s: no such file or directory", pattern)
+		}
+		for _, match := range matches {
+			var info, err = os.Stat(match)
+			if err != nil {
+				return nil, err
+			}
+			if !info.IsDir() {
+				add(match)
+				continue
+			}
+			err = filepath.WalkDir(match, func(path string, d fs.DirEntry, err error) error {
+				if err != nil {
+					return err
+				}
+				if !d.IsDir() && strings.EqualFold(filepath.Ext(path), ".obj") {
+					add(path)
+				}
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	sort.Strings(sources)
+	return sources, nil
+}
+
+// Returns the name of the render of the .obj file that differs from the names already used.
+func uniqueName(source string, used map[string]bool) string {
+	var (
+		base = strings.TrimSuffix(filepath.Base(source), filepath.Ext(source))
+		name = base + ".png"
+	)
+	for i := 2; used[name]; i++ {
+		name = fmt.Sprintf("%s_%d.png", base, i)
+	}
+	used[name] = true
+	return name
+}
+
+// Creates the material of the models specified by the config.
+func newMaterial(c config) (render.Material, error) {
+	switch c.Material {
+	case "normal":
+		return render.NewNormalMaterial(), nil
+	case "unlit":
+		return render.NewUnlitMaterial(pngimage.RGB{R: c.Color[0], G: c.Color[1], B: c.Color[2]}), nil
+	case "checker":
+		return render.NewCheckerMaterial(float64(c.Size) / 16), nil
+	default:
+		return nil, fmt.Errorf("unknown material: %q", c.Material)
+	}
+}
+
+// Imports the model from the .obj file and renders it to the image with the specified name in the output directory.
+func renderModel(source, name string, c config) (entry, error) {
+	var file, err = os.Open(source)
+	if err != nil {
+		return entry{}, err
+	}
+	defer file.Close()
+	var i = importer.Importer{Output: os.Stderr, IgnoreInfos: true}
+	if *quiet {
+		i.Output = nil
+	}
+	var m = i.Import(file)
+	if m.VerticesCount() == 0 {
+		return entry{}, fmt.Errorf("the model has no vertices")
+	}
+	m.Rotate(c.Rotate[0], c.Rotate[1], c.Rotate[2])
+	render.FitToImage(m, c.Size)
+	var (
+		img         = pngimage.FilledImage(c.Size, c.Size, pngimage.RGB{R: c.Background[0], G: c.Background[1], B: c.Background[2]})
+		renderer    = render.NewRenderer(img)
+		material, _ = newMaterial(c)
+	)
+	renderer.Render(m, material)
+	if err = img.Save(filepath.Join(c.Output, name)); err != nil {
+		return entry{}, err
+	}
+	return entry{Source: source, Image: name, Vertices: m.VerticesCount(), Faces: m.FacesCount()}, nil
+}
+
+// Writes the contact sheet with the renders.
+func writeIndex(entries []entry, path string) error {
+	var file, err = os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err = indexTemplate.Execute(file, entries); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -28,7 +28,7 @@ func DrawTriangle(v1, v2, v3 *model.Vertex, img *pngimage.Image, rgb pngimage.RG
 			l2 = ((v3.X-v1.X)*(y-v1.Y) - (v3.Y-v1.Y)*(x-v1.X)) / ((v3.X-v1.X)*(v2.Y-v1.Y) - (v3.Y-v1.Y)*(v2.X-v1.X))
 			l3 = ((v1.X-v2.X)*(y-v2.Y) - (v1.Y-v2.Y)*(x-v2.X)) / ((v1.X-v2.X)*(v3.Y-v2.Y) - (v1.Y-v2.Y)*(v3.X-v2.X))
 			if l1 > 0 && l2 > 0 && l3 > 0 {
-				img.Set(i, j, rgb)
+				img.SetRGB(i, j, rgb)
 			}
 		}
 	}
@@ -147,11 +147,7 @@ func ExampleDrawTriangle_rabbitBarycentricCoordinates() {
 			v1 = face.Vertex1()
 			v2 = face.Vertex2()
 			v3 = face.Vertex3()
-			DrawTriangle(&v1, &v2, &v3, img, pngimage.RGB{
-				R: uint8(-255 * cos),
-				G: uint8(-255 * cos),
-				B: uint8(-255 * cos),
-			})
+			DrawTriangle(&v1, &v2, &v3, img, pngimage.WhiteColor().Scale(-cos))
 		}
 	}
 	if err := img.Save("testdata/pictures/rabbit_barycentric_coordinates.png"); err != nil {
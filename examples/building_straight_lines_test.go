@@ -24,7 +24,7 @@ func SimplestMethod(x1, y1, x2, y2 int, img *pngimage.Image, rgb pngimage.RGB) {
 	for t := 0.0; t < 1.0; t += 0.01 {
 		x := int(float64(x1)*(1.0-t) + float64(x2)*t)
 		y := int(float64(y1)*(1.0-t) + float64(y2)*t)
-		img.Set(x, y, rgb)
+		img.SetRGB(x, y, rgb)
 	}
 }
 
@@ -33,7 +33,7 @@ func SecondMethod(x1, y1, x2, y2 int, img *pngimage.Image, rgb pngimage.RGB) {
 	for x := x1; x <= x2; x++ {
 		t := float64(x-x1) / float64(x2-x1)
 		y := int(float64(y1)*(1.0-t) + float64(y2)*t)
-		img.Set(x, y, rgb)
+		img.SetRGB(x, y, rgb)
 	}
 }
 
@@ -53,9 +53,9 @@ func ThirdMethod(x1, y1, x2, y2 int, img *pngimage.Image, rgb pngimage.RGB) {
 		t := float64(x-x1) / float64(x2-x1)
 		y := int(float64(y1)*(1.0-t) + float64(y2)*t)
 		if steep {
-			img.Set(y, x, rgb)
+			img.SetRGB(y, x, rgb)
 		} else {
-			img.Set(x, y, rgb)
+			img.SetRGB(x, y, rgb)
 		}
 	}
 }
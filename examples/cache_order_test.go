@@ -0,0 +1,59 @@
+package examples
+
+import (
+	"computer_graphics/model"
+	"computer_graphics/pngimage"
+	"computer_graphics/render"
+	"fmt"
+	"testing"
+)
+
+// Reorders the faces and the vertices of the rabbit and compares the cache miss ratios of the orders.
+func ExampleModel_ReorderForCache() {
+	var m = importModel("testdata/rabbit.obj")
+	if m == nil {
+		return
+	}
+	var faces, vertices = m.FacesCount(), m.VerticesCount()
+	fmt.Printf("before: %.2f\n", m.CacheMissRatio(16))
+	m.ReorderForCache(16)
+	fmt.Printf("after: %.2f\n", m.CacheMissRatio(16))
+	fmt.Println(m.FacesCount() == faces, m.VerticesCount() == vertices)
+	// Output:
+	//before: 1.77
+	//after: 0.67
+	//true true
+}
+
+// Measures the time of drawing the rabbit with the order of the faces and the vertices set by the reorder function.
+func benchmarkRenderRabbit(b *testing.B, reorder func(m *model.Model)) {
+	var m = importModel("testdata/rabbit.obj")
+	if m == nil {
+		b.Skip("the rabbit model is not available")
+	}
+	m.Transform(defaultRabbitTransformation)
+	reorder(m)
+	var (
+		renderer = render.NewRenderer(pngimage.BlackImage(2000, 2000))
+		material = render.NewNormalMaterial()
+	)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		renderer.Clear()
+		renderer.Render(m, material)
+	}
+}
+
+// Measures the time of drawing the rabbit in the order of the file.
+func BenchmarkRender_rabbit(b *testing.B) {
+	benchmarkRenderRabbit(b, func(*model.Model) {})
+}
+
+// Measures the time of drawing the rabbit reordered for the vertex cache.
+// The renderer has no vertex cache, so the reordered rabbit is not drawn faster, but a bit slower
+// because of the different overdraw, which is why the models are not reordered by default.
+func BenchmarkRender_rabbitReordered(b *testing.B) {
+	benchmarkRenderRabbit(b, func(m *model.Model) {
+		m.ReorderForCache(16)
+	})
+}
@@ -0,0 +1,70 @@
+package examples
+
+import (
+	"computer_graphics/mathutils"
+	"computer_graphics/model"
+	"computer_graphics/pngimage"
+	"computer_graphics/render"
+	"fmt"
+	"math"
+)
+
+// Creates a model of a sphere with the specified radius made of rings of faces with the normals directed outwards.
+func sphere(radius float64, rings, segments int) *model.Model {
+	var m = model.NewModel()
+	m.AppendVertex(0, 0, radius)
+	for i := 1; i < rings; i++ {
+		var sinTheta, cosTheta = math.Sincos(math.Pi * float64(i) / float64(rings))
+		for j := 0; j < segments; j++ {
+			var sinPhi, cosPhi = math.Sincos(2 * math.Pi * float64(j) / float64(segments))
+			m.AppendVertex(radius*sinTheta*cosPhi, radius*sinTheta*sinPhi, radius*cosTheta)
+		}
+	}
+	m.AppendVertex(0, 0, -radius)
+	// Returns the index of the vertex of the ring, the rings are numbered from 1.
+	var vertex = func(ring, segment int) int {
+		return 2 + (ring-1)*segments + segment%segments
+	}
+	var last = m.VerticesCount()
+	for j := 0; j < segments; j++ {
+		_ = m.AppendFace(1, vertex(1, j+1), vertex(1, j))
+		_ = m.AppendFace(last, vertex(rings-1, j), vertex(rings-1, j+1))
+		for i := 1; i < rings-1; i++ {
+			_ = m.AppendFace(vertex(i, j), vertex(i, j+1), vertex(i+1, j+1))
+			_ = m.AppendFace(vertex(i, j), vertex(i+1, j+1), vertex(i+1, j))
+		}
+	}
+	return m
+}
+
+// Estimates the curvature of a sphere with the radius 2: the mean curvature is 1/2 and the Gaussian curvature is 1/4.
+func ExampleModel_EstimateCurvature_sphere() {
+	var mean, gaussian = sphere(2, 32, 64).EstimateCurvature()
+	fmt.Printf("mean: %.2f\n", mathutils.Percentile(mean, 50))
+	fmt.Printf("gaussian: %.2f\n", mathutils.Percentile(gaussian, 50))
+	// Output:
+	//mean: 0.50
+	//gaussian: 0.25
+}
+
+// Draws the mean curvature of the rabbit as a heatmap.
+func ExampleModel_EstimateCurvature_rabbit() {
+	var m = importModel("testdata/rabbit.obj")
+	if m == nil {
+		return
+	}
+	m.EstimateCurvature()
+	var (
+		img      = pngimage.BlackImage(2000, 2000)
+		renderer = render.NewRenderer(img)
+		mean, _  = m.VertexAttribute(model.MeanCurvatureAttribute)
+	)
+	m.Transform(defaultRabbitTransformation)
+	renderer.Render(m, render.NewHeatmapMaterial(mean))
+	if err := img.Save("testdata/pictures/rabbit_mean_curvature.png"); err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println(m.VertexAttributeNames())
+	// Output:
+	//[gaussian_curvature mean_curvature]
+}
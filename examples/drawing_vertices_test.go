@@ -36,7 +36,7 @@ func DrawVertices(transform func(v *types.Vertex) image.Point, name string) erro
 	for elementType != parser.EndOfFile {
 		if elementType == parser.Vertex {
 			point = transform(element.(*types.Vertex))
-			img.Set(point.X, point.Y, rgb)
+			img.SetRGB(point.X, point.Y, rgb)
 		} else {
 			fmt.Fprintf(output, "[INFO] unnecessary element: %s\n", elementType)
 		}
@@ -0,0 +1,101 @@
+package examples
+
+import (
+	"computer_graphics/obj/importer"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Imports a file in which the vertices and the faces are interleaved, as written by many exporters,
+// and one face refers to a vertex defined after it.
+func ExampleImporter_Import_elementOrder() {
+	const obj = `o first
+v 0 0 0
+v 1 0 0
+v 0 1 0
+f -3 -2 -1
+o second
+s 1
+f 1 2 5
+v 0 0 1
+v 1 1 1
+f -2 -1 3
+`
+	var (
+		ipt = importer.Importer{Output: os.Stdout}
+		m   = ipt.Import(strings.NewReader(obj))
+	)
+	fmt.Println("vertices:", m.VerticesCount(), "faces:", m.FacesCount())
+	for i := 0; i < m.FacesCount(); i++ {
+		var (
+			face       = m.GetFace(i)
+			v1, v2, v3 = face.Indices()
+		)
+		fmt.Println(v1, v2, v3, "smoothing group:", face.SmoothingGroup())
+	}
+	for _, subMesh := range m.SubMeshes() {
+		fmt.Printf("%s: %d faces\n", subMesh.Object, subMesh.FacesCount)
+	}
+	// Output:
+	//[INFO] line: 8, message: the element refers to vertices defined after it, it is imported at the end of the file
+	//vertices: 5 faces: 3
+	//0 1 2 smoothing group: 0
+	//3 4 2 smoothing group: 1
+	//0 1 4 smoothing group: 1
+	//first: 1 faces
+	//second: 2 faces
+}
+
+// Imports a file in which the negative indices are followed by more vertices:
+// they refer to the vertices defined before the element, even if the element is postponed,
+// and the negative index referring to no vertex is reported.
+func ExampleImporter_Import_negativeIndices() {
+	const obj = `v 0 0 0
+v 1 0 0
+v 0 1 0
+f 1 -1 5
+v 0 0 1
+f -9 1 2
+v 1 1 1
+f -1 -2 -3
+`
+	var (
+		ipt = importer.Importer{OnIssue: func(issue importer.Issue) {
+			fmt.Printf("[%s] line %d: %s\n", issue.Severity, issue.Line, issue.Message)
+		}}
+		m = ipt.Import(strings.NewReader(obj))
+	)
+	for i := 0; i < m.FacesCount(); i++ {
+		fmt.Println(m.GetFace(i).Indices())
+	}
+	// Output:
+	//[ERROR] line 6: unresolved vertex index: -9, the number of the elements defined before it is 4
+	//[INFO] line 4: the element refers to vertices defined after it, it is imported at the end of the file
+	//4 3 2
+	//0 2 4
+}
+
+// Imports a small file with a face referring to a vertex defined after it and prints the progress of the stages.
+func ExampleImporter_OnProgress() {
+	const obj = `v 0 0 0
+v 1 0 0
+f 1 2 3
+v 0 1 0
+f -3 -2 -1
+`
+	var ipt = importer.Importer{
+		GenerateNormals: importer.FlatNormals,
+		OnProgress: func(stage string, processed, total int) {
+			fmt.Printf("%s: %d/%d\n", stage, processed, total)
+		},
+	}
+	ipt.Import(strings.NewReader(obj))
+	// Output:
+	//read: 0/43
+	//read: 43/43
+	//postponed: 0/1
+	//postponed: 1/1
+	//normals: 0/2
+	//normals: 2/2
+}
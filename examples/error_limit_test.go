@@ -0,0 +1,111 @@
+package examples
+
+import (
+	"computer_graphics/model"
+	"computer_graphics/obj/importer"
+	"computer_graphics/obj/parser"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Imports a corrupt file, stopping after the third error instead of reporting each corrupt line.
+func ExampleImporter_MaxErrors() {
+	var obj strings.Builder
+	obj.WriteString("v 0 0 0\nv 1 0 0\nv 0 1 0\nf 1 2 3\n")
+	for i := 0; i < 1000; i++ {
+		obj.WriteString("v 0 x 0\n")
+	}
+	obj.WriteString("v 0 0 1\n")
+	var (
+		ipt       = importer.Importer{MaxErrors: 3}
+		m, report = ipt.ImportWithReport(strings.NewReader(obj.String()))
+	)
+	fmt.Println("vertices:", m.VerticesCount(), "faces:", m.FacesCount())
+	fmt.Println(report.Aborted)
+	fmt.Println(errors.Is(report.Aborted, parser.ErrTooManyErrors))
+	// Output:
+	//vertices: 3 faces: 1
+	//too many errors: 3 errors found, the rest of the file is skipped
+	//true
+}
+
+// Imports a file with the context cancelled when the corrupt vertex is found, like a server
+// whose client has disconnected. The parser skips the corrupt line and reads the next vertex,
+// after which the import stops, so the last face is not imported.
+func ExampleImporter_ImportContext() {
+	const obj = `v 0 0 0
+v 1 0 0
+v 0 1 0
+f 1 2 3
+v 0 x 0
+v 0 0 1
+f 1 2 4
+`
+	var (
+		ctx, cancel = context.WithCancel(context.Background())
+		ipt         = importer.Importer{OnIssue: func(importer.Issue) { cancel() }}
+		m, err      = ipt.ImportContext(ctx, strings.NewReader(obj))
+	)
+	cancel()
+	fmt.Println("vertices:", m.VerticesCount(), "faces:", m.FacesCount())
+	fmt.Println(err, errors.Is(err, context.Canceled))
+	// Output:
+	//vertices: 4 faces: 1
+	//context canceled true
+}
+
+// Imports a file with a corrupt vertex, a corrupt face and a face referring to no vertex
+// and sorts the problems by their kinds instead of matching the messages.
+// The face referring to the vertex after it is imported and reported at the end of the file.
+func ExampleParseError() {
+	const obj = `v 0 0 0
+v 1 0 0
+v 0 1 0
+f 1 2 5
+v 0 x 0
+f 1 2 0/1
+`
+	var ipt = importer.Importer{OnIssue: func(issue importer.Issue) {
+		var (
+			parseErr   *importer.ParseError
+			indexErr   *model.IndexError
+			diagnostic parser.Diagnostic
+		)
+		errors.As(issue.Err, &parseErr)
+		switch {
+		case errors.Is(issue.Err, model.ErrIndexOutOfRange) && errors.As(issue.Err, &indexErr):
+			fmt.Printf("no %s %d at line %d\n", indexErr.Element, indexErr.Index, parseErr.Line)
+		case errors.As(issue.Err, &diagnostic):
+			fmt.Printf("%s of the parser at line %d, column %d\n", diagnostic.Severity, parseErr.Line, diagnostic.Column)
+		}
+	}}
+	ipt.Import(strings.NewReader(obj))
+	// Output:
+	//ERROR of the parser at line 5, column 5
+	//ERROR of the parser at line 6, column 7
+	//no vertex 5 at line 4
+}
+
+// Refers to the wrong vertices of a model of three vertices lying on a line and checks the kinds of the errors.
+func ExampleIndexError() {
+	var m = model.NewModel()
+	m.AppendVertex(0, 0, 0)
+	m.AppendVertex(1, 0, 0)
+	m.AppendVertex(2, 0, 0)
+	var _, err = m.GetVertex(0)
+	fmt.Println(err, errors.Is(err, model.ErrZeroIndex))
+	err = m.AppendFace(1, 2, 4)
+	fmt.Println(err, errors.Is(err, model.ErrIndexOutOfRange))
+	_ = m.AppendFace(1, 2, 3)
+	err = m.GetFace(0).Check()
+	fmt.Println(err, errors.Is(err, model.ErrDegenerateFace))
+	err = m.AppendLine(1)
+	fmt.Println(err, errors.Is(err, model.ErrDegenerateLine))
+	// Output:
+	//vertex index cannot be zero true
+	//unresolved vertex index: 4 true
+	//degenerate face: the vertices of the face lie on a line true
+	//degenerate line: line must contain at least two vertices true
+}
@@ -0,0 +1,45 @@
+package examples
+
+import (
+	"computer_graphics/model"
+	"computer_graphics/obj/importer"
+	"computer_graphics/obj/parser"
+	"fmt"
+	"os"
+)
+
+// Imports the geometry of testdata/fox.obj without parsing the texture vertices.
+func ExampleImporter_Skip() {
+	var input, err = os.Open("testdata/fox.obj")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer input.Close()
+	var (
+		ipt = (&importer.Importer{}).Skip(parser.VertexTexture)
+		m   = ipt.Import(input)
+	)
+	fmt.Println(m.VerticesCount(), m.TextureVerticesCount(), m.FacesCount())
+	// Output:
+	//290 0 576
+}
+
+// Extracts only the object and material structure of testdata/fox.obj without the geometry.
+func ExampleImporter_Only() {
+	var input, err = os.Open("testdata/fox.obj")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer input.Close()
+	var (
+		ipt = (&importer.Importer{}).Only(parser.Object, parser.MaterialLibrary)
+		m   = ipt.Import(input)
+	)
+	fmt.Println(m.VerticesCount(), m.FacesCount())
+	fmt.Println(m.Metadata()[model.NameKey], m.Metadata()[model.MaterialLibrariesKey])
+	// Output:
+	//0 0
+	//fox1 low-poly-fox-by-pixelmannen.mtl
+}
@@ -0,0 +1,96 @@
+package examples
+
+import (
+	"computer_graphics/model"
+	"computer_graphics/pngimage"
+	"computer_graphics/render"
+	"fmt"
+	"math"
+	"testing"
+)
+
+// Material lighting each pixel with the normal interpolated from the normals of the corners of the face,
+// the straightforward way of the smooth shading used to compare its speed with the GouraudMaterial.
+type pixelLightingMaterial struct {
+	model *model.Model
+	color pngimage.RGB
+	light render.Vec3
+}
+
+// Implementation of the Shade method in the render.Material interface.
+func (m *pixelLightingMaterial) Shade(fragment *render.Fragment) pngimage.RGB {
+	var (
+		normals, _ = m.model.FaceNormals(fragment.FaceIndex)
+		bary       = fragment.Barycentric
+		light      = m.light.Normalize()
+		n          = render.Vec3{
+			X: bary.X*normals[0].X + bary.Y*normals[1].X + bary.Z*normals[2].X,
+			Y: bary.X*normals[0].Y + bary.Y*normals[1].Y + bary.Z*normals[2].Y,
+			Z: bary.X*normals[0].Z + bary.Y*normals[1].Z + bary.Z*normals[2].Z,
+		}.Normalize()
+		lit = math.Abs(n.X*light.X + n.Y*light.Y + n.Z*light.Z)
+	)
+	return m.color.Scale(0.2 + 0.8*lit)
+}
+
+// Draws the rabbit with the smooth Gouraud shading and with the flat shading of the faces.
+func ExampleGouraudMaterial() {
+	var m = importModel("testdata/rabbit.obj")
+	if m == nil {
+		return
+	}
+	m.Transform(defaultRabbitTransformation)
+	var (
+		flat     = render.NewGouraudMaterial(m, pngimage.WhiteColor())
+		smooth   = render.NewGouraudMaterial(m, pngimage.WhiteColor())
+		img      = pngimage.BlackImage(2000, 2000)
+		renderer = render.NewRenderer(img)
+	)
+	renderer.Render(m, flat)
+	if err := img.Save("testdata/pictures/rabbit_flat.png"); err != nil {
+		fmt.Println(err)
+	}
+	var flatColor = img.Get(1000, 1000)
+	m.GenerateNormals()
+	renderer.Clear()
+	renderer.Render(m, smooth)
+	if err := img.Save("testdata/pictures/rabbit_gouraud.png"); err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println(flatColor != img.Get(1000, 1000))
+	// Output:
+	//true
+}
+
+// Measures the time of drawing the rabbit with the smooth shading on the 2000x2000 image with the material.
+func benchmarkSmoothShading(b *testing.B, material func(m *model.Model) render.Material) {
+	var m = importModel("testdata/rabbit.obj")
+	if m == nil {
+		b.Skip("the rabbit model is not available")
+	}
+	m.Transform(defaultRabbitTransformation)
+	m.GenerateNormals()
+	var (
+		renderer = render.NewRenderer(pngimage.BlackImage(2000, 2000))
+		shading  = material(m)
+	)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		renderer.Clear()
+		renderer.Render(m, shading)
+	}
+}
+
+// Measures the time of drawing the rabbit with the lighting calculated once per vertex.
+func BenchmarkGouraudMaterial(b *testing.B) {
+	benchmarkSmoothShading(b, func(m *model.Model) render.Material {
+		return render.NewGouraudMaterial(m, pngimage.WhiteColor())
+	})
+}
+
+// Measures the time of drawing the rabbit with the lighting calculated for each pixel.
+func BenchmarkPixelLighting(b *testing.B) {
+	benchmarkSmoothShading(b, func(m *model.Model) render.Material {
+		return &pixelLightingMaterial{model: m, color: pngimage.WhiteColor(), light: render.Vec3{X: -0.5, Y: -0.5, Z: -1}}
+	})
+}
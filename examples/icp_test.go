@@ -0,0 +1,57 @@
+package examples
+
+import (
+	"computer_graphics/model"
+	"computer_graphics/obj/importer"
+	"fmt"
+	"math"
+	"os"
+)
+
+// Imports the model from the file, returns nil and prints the error if the file cannot be opened.
+func importModel(filename string) *model.Model {
+	var input, err = os.Open(filename)
+	if err != nil {
+		fmt.Println(err)
+		return nil
+	}
+	defer input.Close()
+	var ipt = importer.Importer{}
+	return ipt.Import(input)
+}
+
+// Aligns the displaced copy of the fox with the original one using the point-to-point and point-to-plane ICP.
+func ExampleAlignICP_fox() {
+	for _, pointToPlane := range []bool{false, true} {
+		var (
+			original  = importModel("testdata/fox.obj")
+			displaced = importModel("testdata/fox.obj")
+		)
+		if original == nil || displaced == nil {
+			return
+		}
+		var displacement = model.AxisRotation(model.Vertex{X: 1, Y: 2, Z: -1}, 0.12)
+		displacement.Translation = model.Vertex{X: 1, Y: -2, Z: 0.5}
+		displaced.Transform(displacement.Apply)
+		var result = model.AlignICP(displaced, original, model.ICPOptions{Tolerance: 1e-12, PointToPlane: pointToPlane})
+		fmt.Printf("converged: %t, error < 1e-6: %t\n", result.Converged, result.Error < 1e-6)
+		fmt.Printf("rotation angle: %.3f\n", result.Transform.Angle())
+		displaced.Transform(result.Transform.Apply)
+		var maxDistance float64
+		for i := 1; i <= original.VerticesCount(); i++ {
+			var (
+				v1, _ = original.GetVertex(i)
+				v2, _ = displaced.GetVertex(i)
+			)
+			maxDistance = math.Max(maxDistance, math.Abs(v1.X-v2.X)+math.Abs(v1.Y-v2.Y)+math.Abs(v1.Z-v2.Z))
+		}
+		fmt.Printf("max distance < 1e-6: %t\n", maxDistance < 1e-6)
+	}
+	// Output:
+	//converged: true, error < 1e-6: true
+	//rotation angle: 0.120
+	//max distance < 1e-6: true
+	//converged: true, error < 1e-6: true
+	//rotation angle: 0.120
+	//max distance < 1e-6: true
+}
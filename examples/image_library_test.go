@@ -1,87 +1,36 @@
 package examples
 
 import (
+	"computer_graphics/pngimage"
 	"fmt"
-	"image"
-	"image/color"
-	"image/png"
-	"os"
 )
 
-// Creates a file with the specified name and places the specified image in it.
-func makeFile(img image.Image, filename string) error {
-	var file, err = os.Create(filename)
-	if err != nil {
-		return err
-	}
-	if err = png.Encode(file, img); err != nil {
-		_ = file.Close()
-		return err
-	}
-	if err = file.Close(); err != nil {
-		return err
-	}
-	return nil
-}
-
 // Creates an all-black png image.
 func BlackImage() error {
-	const (
-		w = 600 // Image width.
-		h = 400 // Image height.
-	)
-	var img = image.NewGray(image.Rect(0, 0, w, h))
-	for i := 0; i < w; i++ {
-		for j := 0; j < h; j++ {
-			img.SetGray(i, j, color.Gray{Y: 0})
-		}
-	}
-	return makeFile(img, "testdata/pictures/imagelibtest/black_image.png")
+	return pngimage.BlackImage(600, 400).Save("testdata/pictures/imagelibtest/black_image.png")
 }
 
 // Creates an all-white png image.
 func WhiteImage() error {
-	const (
-		w = 600 // Image width.
-		h = 400 // Image height.
-	)
-	var img = image.NewGray(image.Rect(0, 0, w, h))
-	for i := 0; i < w; i++ {
-		for j := 0; j < h; j++ {
-			img.SetGray(i, j, color.Gray{Y: 255})
-		}
-	}
-	return makeFile(img, "testdata/pictures/imagelibtest/white_image.png")
+	return pngimage.WhiteImage(600, 400).Save("testdata/pictures/imagelibtest/white_image.png")
 }
 
 // Creates an all-red png image.
 func RedImage() error {
-	const (
-		w = 600 // Image width.
-		h = 400 // Image height.
-	)
-	var img = image.NewRGBA(image.Rect(0, 0, w, h))
-	for i := 0; i < w; i++ {
-		for j := 0; j < h; j++ {
-			img.SetRGBA(i, j, color.RGBA{R: 255, A: 255})
-		}
-	}
-	return makeFile(img, "testdata/pictures/imagelibtest/red_image.png")
+	return pngimage.FilledImage(600, 400, pngimage.RedColor()).Save("testdata/pictures/imagelibtest/red_image.png")
 }
 
 // Creates a gradient png image.
 func GradientImage() error {
-	const (
-		w = 600 // Image width.
-		h = 400 // Image height.
-	)
-	var img = image.NewGray(image.Rect(0, 0, w, h))
-	for i := 0; i < w; i++ {
-		for j := 0; j < h; j++ {
-			img.SetGray(i, j, color.Gray{Y: uint8((i + j) % 256)})
-		}
-	}
-	return makeFile(img, "testdata/pictures/imagelibtest/gradient_image.png")
+	var img = pngimage.VerticalGradient(600, 400, pngimage.RGB{R: 40, G: 60, B: 90}, pngimage.RGB{R: 200, G: 210, B: 230})
+	return img.Save("testdata/pictures/imagelibtest/gradient_image.png")
+}
+
+// Creates a radial gradient png image with the vignette.
+func VignetteImage() error {
+	var img = pngimage.RadialGradient(600, 400, pngimage.WhiteColor(), pngimage.RGB{R: 120, G: 120, B: 120})
+	img.Vignette(0.5)
+	return img.Save("testdata/pictures/imagelibtest/vignette_image.png")
 }
 
 // Example of creating a black image.
@@ -123,3 +72,13 @@ func ExampleGradientImage() {
 	}
 	// Output: Ok
 }
+
+// Example of creating a radial gradient image with the vignette.
+func ExampleVignetteImage() {
+	if err := VignetteImage(); err != nil {
+		fmt.Println(err)
+	} else {
+		fmt.Println("Ok")
+	}
+	// Output: Ok
+}
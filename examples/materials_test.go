@@ -0,0 +1,119 @@
+package examples
+
+import (
+	"computer_graphics/obj/importer"
+	"computer_graphics/obj/mtl"
+	"computer_graphics/obj/parser"
+	"computer_graphics/pngimage"
+	"computer_graphics/render"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+)
+
+// FileResolver that opens the files stored in memory.
+type memoryResolver map[string]string
+
+// Implementation of the Open method in the FileResolver interface.
+func (resolver memoryResolver) Open(name string) (io.ReadCloser, error) {
+	var text, ok = resolver[name]
+	if !ok {
+		return nil, fmt.Errorf("file %s not found", name)
+	}
+	return io.NopCloser(strings.NewReader(text)), nil
+}
+
+// Reads the materials of a .mtl file with the texture maps, the options of the maps and an extension statement.
+func ExampleReader_Read() {
+	var reader = mtl.NewReader(strings.NewReader(
+		"newmtl brick\nKa 0.2\nKd 0.8 0.3 0.2\nNs 10\nTr 0.25\nillum 2\n" +
+			"map_Kd -s 2 2 1 textures/brick.png\nmap_Bump -bm 0.5 textures/brick_bump.png\nPr 0.7\n" +
+			"newmtl glass\nKd 0.9 0.9 1\nd 0.1\nKs spectral glass.rfl\n",
+	))
+	reader.Parser().Output(nil)
+	var materials, err = reader.Read()
+	for _, material := range materials {
+		fmt.Printf("%s: Ka %v, Kd %v, Ns %g, d %g, illum %d\n",
+			material.Name, material.Ambient, material.Diffuse, material.SpecularExponent, material.Dissolve, material.Illumination)
+		if material.DiffuseMap != nil {
+			fmt.Println("map_Kd:", material.DiffuseMap.File, material.DiffuseMap.Options)
+			fmt.Println("map_Bump:", material.BumpMap.File, material.BumpMap.Options)
+			fmt.Println("extensions:", material.Extensions)
+		}
+	}
+	fmt.Println(err, reader.Parser().Diagnostics())
+	// Output:
+	//brick: Ka {0.2 0.2 0.2}, Kd {0.8 0.3 0.2}, Ns 10, d 0.75, illum 2
+	//map_Kd: textures/brick.png map[s:[2 2 1]]
+	//map_Bump: textures/brick_bump.png map[bm:[0.5]]
+	//extensions: map[Pr:0.7]
+	//glass: Ka {0 0 0}, Kd {0.9 0.9 1}, Ns 0, d 0.1, illum 0
+	//<nil> [line 13, column 4: invalid color component, expected: FLOAT, received: WORD]
+}
+
+// Imports testdata/fox.obj with the materials of its material library.
+func ExampleImporter_LoadMaterials() {
+	var input, err = os.Open("testdata/fox.obj")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer input.Close()
+	var (
+		ipt       = importer.Importer{LoadMaterials: true}
+		_, report = ipt.ImportWithReport(input)
+	)
+	for _, material := range report.Materials {
+		fmt.Printf("%s: Kd %v, Ns %.2f, map_Kd %s\n", material.Name, material.Diffuse, material.SpecularExponent, material.DiffuseMap.File)
+	}
+	// The libraries that cannot be opened are reported.
+	ipt = importer.Importer{
+		Output:           os.Stdout,
+		LoadMaterials:    true,
+		MaterialResolver: parser.DirResolver("testdata/missing"),
+	}
+	ipt.Import(strings.NewReader("mtllib scene.mtl\nv 0 0 0\n"))
+	// Output:
+	//fox_material: Kd {0.64 0.64 0.64}, Ns 96.08, map_Kd texture.png
+	//[WARNING] line: 1, message: the material library is not loaded - open testdata/missing/scene.mtl: no such file or directory
+}
+
+// Imports a model with the materials of the faces and paints each face with the diffuse color of its material,
+// the face with the material that is not defined in the library is painted with the default color.
+func ExampleImportReport_FaceMaterials() {
+	var (
+		ipt = importer.Importer{
+			Output:           os.Stdout,
+			LoadMaterials:    true,
+			MaterialResolver: memoryResolver{"paint.mtl": "newmtl red\nKd 1 0 0\nnewmtl blue\nKd 0 0 1\n"},
+		}
+		m, report = ipt.ImportWithReport(strings.NewReader(
+			"mtllib paint.mtl\nv 0 0 0\nv 10 0 0\nv 10 10 0\nv 0 10 0\nv 20 0 0\nv 30 0 0\nv 30 10 0\n" +
+				"usemtl red\nf 1 2 3\nusemtl blue\nf 1 3 4\nusemtl chrome\nf 5 6 7\nusemtl red\nf 5 7 6\n",
+		))
+		materials = make([]render.Material, len(report.FaceMaterials))
+	)
+	var indices = make([]int, m.FacesCount())
+	for j := range indices {
+		indices[j] = m.GetFace(j).MaterialIndex()
+	}
+	fmt.Println(m.Materials(), indices)
+	for j, material := range report.FaceMaterials {
+		if material != nil {
+			materials[j] = render.NewUnlitMaterial(pngimage.RGB{
+				R: uint8(math.Round(255 * material.Diffuse.R)),
+				G: uint8(math.Round(255 * material.Diffuse.G)),
+				B: uint8(math.Round(255 * material.Diffuse.B)),
+			})
+		}
+	}
+	var img = pngimage.BlackImage(32, 12)
+	render.NewRenderer(img).Render(m, render.NewMaterialSet(materials, render.NewUnlitMaterial(pngimage.RGB{R: 128, G: 128, B: 128})))
+	fmt.Println(img.Get(8, 2), img.Get(2, 8), img.Get(28, 2))
+	// Output:
+	//[WARNING] line: 13, message: the material 'chrome' is not defined in the material libraries
+	//[red blue chrome] [0 1 2 0]
+	//{255 0 0} {0 0 255} {128 128 128}
+}
@@ -0,0 +1,83 @@
+package examples
+
+import (
+	"bytes"
+	"computer_graphics/model"
+	"computer_graphics/obj/importer"
+	"fmt"
+	"os"
+	"testing"
+)
+
+// Imports testdata/fox.obj with the Importer and returns the model with the ImportReport
+// or the error if the file cannot be opened.
+func importFox(ipt *importer.Importer) (*model.Model, *importer.ImportReport, error) {
+	var input, err = os.Open("testdata/fox.obj")
+	if err != nil {
+		return nil, nil, err
+	}
+	defer input.Close()
+	var m, report = ipt.ImportWithReport(input)
+	return m, report, nil
+}
+
+// Imports testdata/fox.obj again with the memory reserved by the statistics of the first import,
+// so that the arrays of the model are allocated once and have no unused capacity.
+func ExampleModel_Reserve() {
+	var (
+		ipt            = importer.Importer{}
+		m, report, err = importFox(&ipt)
+	)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	var grown = m.MemoryUsage()
+	ipt.Expected = &report.Stats
+	if m, _, err = importFox(&ipt); err != nil {
+		fmt.Println(err)
+		return
+	}
+	var reserved = m.MemoryUsage()
+	fmt.Println(m.VerticesCount(), m.FacesCount())
+	fmt.Println(grown.Vertices > reserved.Vertices, grown.Faces > reserved.Faces)
+	fmt.Println(reserved.Reserved)
+	// Output:
+	//290 576
+	//true true
+	//0
+}
+
+// Counts the statements of testdata/fox.obj before importing it, so that the memory of the model is allocated at once.
+func ExampleImporter_PreScan() {
+	var (
+		ipt       = importer.Importer{PreScan: true}
+		m, _, err = importFox(&ipt)
+	)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println(m.VerticesCount(), m.FacesCount(), m.MemoryUsage().Reserved)
+	// Output:
+	//290 576 0
+}
+
+// Compares the time and the allocations of importing testdata/rabbit.obj with and without counting its statements
+// before reading it. The file is read into the memory once, so that only the import is measured.
+// The counting saves only a few percent of the allocations and the second pass over the input takes the time it saves.
+func BenchmarkImporter_PreScan(b *testing.B) {
+	var data, err = os.ReadFile("testdata/rabbit.obj")
+	if err != nil {
+		b.Skip("the rabbit model is not available")
+	}
+	for _, preScan := range []bool{false, true} {
+		b.Run(fmt.Sprintf("prescan-%t", preScan), func(b *testing.B) {
+			var ipt = importer.Importer{PreScan: preScan}
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				ipt.Import(bytes.NewReader(data))
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package examples
+
+import (
+	"computer_graphics/model"
+	"computer_graphics/obj/importer"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Prints the metadata collected when importing testdata/fox.obj.
+func ExampleModel_Metadata_fox() {
+	var input, err = os.Open("testdata/fox.obj")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer input.Close()
+	var (
+		ipt      = importer.Importer{}
+		metadata = ipt.Import(input).Metadata()
+	)
+	fmt.Println(metadata[model.SourceKey])
+	fmt.Println(metadata[model.HeaderKey])
+	fmt.Println(metadata[model.NameKey])
+	// Output:
+	//testdata/fox.obj
+	//Blender v2.74 (sub 0) OBJ File: ''
+	//www.blender.org
+	//fox1
+}
+
+// Prints the material libraries and the materials of the faces of testdata/fox.obj.
+func ExampleImporter_ImportWithReport_materials() {
+	var input, err = os.Open("testdata/fox.obj")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer input.Close()
+	var (
+		ipt       = importer.Importer{}
+		m, report = ipt.ImportWithReport(input)
+	)
+	fmt.Println(m.Metadata()[model.MaterialLibrariesKey])
+	fmt.Println(report.MaterialLibraries)
+	fmt.Println(m.GetFace(0).Material())
+	// Output:
+	//low-poly-fox-by-pixelmannen.mtl
+	//[testdata/low-poly-fox-by-pixelmannen.mtl]
+	//fox_material
+}
+
+// Prints the display settings of the model that the importer stores in the metadata.
+func ExampleImporter_Import_displaySettings() {
+	var (
+		input = strings.NewReader("v 0 0 0\nv 1 0 0\nv 0 1 0\nbevel on\nc_interp off\nlod 50\nf 1 2 3\nd_interp on\nd_interp off\n")
+		ipt   = importer.Importer{}
+		m     = ipt.Import(input)
+	)
+	for _, key := range []string{
+		model.BevelInterpolationKey,
+		model.ColorInterpolationKey,
+		model.DissolveInterpolationKey,
+		model.LevelOfDetailKey,
+	} {
+		fmt.Printf("%s : %s\n", key, m.Metadata()[key])
+	}
+	// Output:
+	//bevel_interpolation : on
+	//color_interpolation : off
+	//dissolve_interpolation : off
+	//level_of_detail : 50
+}
+
+// Imports the model exported in millimeters in meters.
+func ExampleImporter_Import_units() {
+	var (
+		input = strings.NewReader("# Exported by CAD\n# units = millimeters\nv 1000 0 0\nv 0 2500 0\nv 0 0 10\nf 1 2 3\n")
+		ipt   = importer.Importer{Units: model.Meters}
+		m     = ipt.Import(input)
+	)
+	fmt.Println(m.Metadata()[model.UnitsKey])
+	for i := 1; i <= m.VerticesCount(); i++ {
+		var v, _ = m.GetVertex(i)
+		fmt.Println(v)
+	}
+	var scale, _ = model.Inches.ScaleTo(model.Centimeters)
+	fmt.Println(scale)
+	// Output:
+	//m
+	//{1 0 0}
+	//{0 2.5 0}
+	//{0 0 0.01}
+	//2.54
+}
+
+// Embeds the report of the cleanup made by the import into the re-exported model and reads it back.
+func ExampleImportReport_WriteComments() {
+	const obj = `v 0 0 0
+v 1 0 0
+v 0 1 0
+v 0 0 1
+f 1 2 3
+f 3 2 1
+f 1 2 x
+shadow_obj shadow.obj
+f 1 2 4
+f 1 2 9
+`
+	var (
+		ipt       = importer.Importer{RemoveDuplicateFaces: true}
+		m, report = ipt.ImportWithReport(strings.NewReader(obj))
+		exported  strings.Builder
+	)
+	if err := report.WriteComments(&exported); err != nil {
+		fmt.Println(err)
+		return
+	}
+	for i := 0; i < m.VerticesCount(); i++ {
+		var v, _ = m.GetVertex(i + 1)
+		fmt.Fprintf(&exported, "v %g %g %g\n", v.X, v.Y, v.Z)
+	}
+	for i := 0; i < m.FacesCount(); i++ {
+		var a, b, c = m.GetFace(i).Indices()
+		fmt.Fprintf(&exported, "f %d %d %d\n", a+1, b+1, c+1)
+	}
+	fmt.Print(exported.String())
+	var reimported = ipt.Import(strings.NewReader(exported.String()))
+	fmt.Println(strings.Join(importer.ReportComments(reimported.Metadata()), "\n"))
+	// Output:
+	//# import report: 1 duplicate faces removed
+	//# import report: 1 face elements not imported
+	//# import report: 1 lines with problems skipped
+	//# import report: 1 statements of unsupported formats skipped
+	//v 0 0 0
+	//v 1 0 0
+	//v 0 1 0
+	//v 0 0 1
+	//f 1 2 3
+	//f 1 2 4
+	//import report: 1 duplicate faces removed
+	//import report: 1 face elements not imported
+	//import report: 1 lines with problems skipped
+	//import report: 1 statements of unsupported formats skipped
+}
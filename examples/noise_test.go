@@ -0,0 +1,50 @@
+package examples
+
+import (
+	"computer_graphics/mathutils/noise"
+	"fmt"
+	"hash/fnv"
+)
+
+// Prints the values of the noises, which are the same for the same seed on all platforms.
+func ExampleNoise() {
+	var n = noise.New(42)
+	fmt.Printf("value: %.6f %.6f\n", n.Value2(0.5, 1.25), n.Value3(0.5, 1.25, -3.75))
+	fmt.Printf("perlin: %.6f %.6f\n", n.Perlin2(0.5, 1.25), n.Perlin3(0.5, 1.25, -3.75))
+	fmt.Printf("at the lattice: %g\n", n.Perlin3(2, -1, 7))
+	fmt.Printf("fractal: %.6f, turbulence: %.6f\n", n.Fractal3(1.3, 2.7, 0.9, 4, 2, 0.5), n.Turbulence3(1.3, 2.7, 0.9, 4, 2, 0.5))
+	fmt.Println("other seed:", noise.New(43).Perlin2(0.5, 1.25) != n.Perlin2(0.5, 1.25))
+	// Output:
+	//value: 0.233450 0.549186
+	//perlin: 0.349121 -0.489285
+	//at the lattice: 0
+	//fractal: -0.052814, turbulence: 0.221651
+	//other seed: true
+}
+
+// Shades a marble and a wood texture and a dithered gradient, and prints the hashes of their pixels,
+// which a golden-image test can compare with the stored ones.
+func ExampleNoise_Marble() {
+	var (
+		n                    = noise.New(7)
+		marble, wood, dither = fnv.New64a(), fnv.New64a(), fnv.New64a()
+	)
+	for y := 0; y < 64; y++ {
+		for x := 0; x < 64; x++ {
+			var u, v = float64(x) / 64, float64(y) / 64
+			marble.Write([]byte{uint8(255 * n.Marble(u, v, 0.5, 20))})
+			wood.Write([]byte{uint8(255 * n.Wood(u-0.5, v-0.5, 0, 12))})
+			// The shadow factor grows from left to right.
+			var shadow byte
+			if u > n.Threshold(x, y) {
+				shadow = 1
+			}
+			dither.Write([]byte{shadow})
+		}
+	}
+	fmt.Printf("marble: %016x\nwood: %016x\ndither: %016x\n", marble.Sum64(), wood.Sum64(), dither.Sum64())
+	// Output:
+	//marble: d948b34bb90c6401
+	//wood: aa0f1bb31ee1f33c
+	//dither: 03df69ca76fd8c37
+}
@@ -0,0 +1,141 @@
+package examples
+
+import (
+	"computer_graphics/model"
+	"fmt"
+	"math"
+	"testing"
+)
+
+// Chooses the point locators for the fox and the rabbit, picks the face of the rabbit under a ray
+// and checks that the spatial hash finds the same nearest vertices as the k-d tree.
+func ExampleModel_PointLocator() {
+	for _, filename := range []string{"testdata/fox.obj", "testdata/rabbit.obj"} {
+		var m = importModel(filename)
+		if m == nil {
+			return
+		}
+		fmt.Printf("%s: %T\n", filename, m.PointLocator())
+		var (
+			hash  = m.SpatialHash(0)
+			tree  = m.KDTree()
+			equal = true
+		)
+		for i := 0; i < hash.Len(); i += 7 {
+			var p = hash.Point(i)
+			p.X += 0.01
+			var _, d1 = hash.Nearest(p)
+			var _, d2 = tree.Nearest(p)
+			equal = equal && d1 == d2
+		}
+		fmt.Println("the same nearest distances:", equal)
+	}
+	var rabbit = importModel("testdata/rabbit.obj")
+	if rabbit == nil {
+		return
+	}
+	var (
+		hash       = rabbit.SpatialHash(0)
+		min, max   = rabbit.Bounds()
+		origin     = model.Vertex{X: (min.X + max.X) / 2, Y: (min.Y + max.Y) / 2, Z: max.Z + 1}
+		face, t    = hash.Pick(origin, model.Vertex{Z: -1})
+		missed, tm = hash.Pick(origin, model.Vertex{Z: 1})
+	)
+	fmt.Println("hit:", face >= 0, t > 1 && t < max.Z-min.Z+1)
+	fmt.Println("missed:", missed, math.IsInf(tm, +1))
+	// Output:
+	//testdata/fox.obj: *model.KDTree
+	//the same nearest distances: true
+	//testdata/rabbit.obj: *model.SpatialHash
+	//the same nearest distances: true
+	//hit: true true
+	//missed: -1 true
+}
+
+// Measures the time of finding the nearest vertices of the model in the specified file
+// to the vertices of the same model moved by a fraction of its size using the locator created by the function.
+func benchmarkNearest(b *testing.B, filename string, locator func(m *model.Model) model.PointLocator) {
+	var m = importModel(filename)
+	if m == nil {
+		b.Skip("the model is not available")
+	}
+	var (
+		l        = locator(m)
+		min, max = m.Bounds()
+		shift    = (max.X - min.X) / 100
+		points   = make([]model.Vertex, m.VerticesCount())
+	)
+	for i := range points {
+		var v, _ = m.GetVertex(i + 1)
+		points[i] = model.Vertex{X: v.X + shift, Y: v.Y - shift, Z: v.Z + shift}
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, p := range points {
+			l.Nearest(p)
+		}
+	}
+}
+
+// Measures the time of finding the nearest vertices of the rabbit with the spatial hash.
+func BenchmarkSpatialHash_Nearest_rabbit(b *testing.B) {
+	benchmarkNearest(b, "testdata/rabbit.obj", func(m *model.Model) model.PointLocator {
+		return m.SpatialHash(0)
+	})
+}
+
+// Measures the time of finding the nearest vertices of the rabbit with the k-d tree.
+func BenchmarkKDTree_Nearest_rabbit(b *testing.B) {
+	benchmarkNearest(b, "testdata/rabbit.obj", func(m *model.Model) model.PointLocator {
+		return m.KDTree()
+	})
+}
+
+// Measures the time of finding the nearest vertices of the fox with the spatial hash.
+func BenchmarkSpatialHash_Nearest_fox(b *testing.B) {
+	benchmarkNearest(b, "testdata/fox.obj", func(m *model.Model) model.PointLocator {
+		return m.SpatialHash(0)
+	})
+}
+
+// Measures the time of finding the nearest vertices of the fox with the k-d tree.
+func BenchmarkKDTree_Nearest_fox(b *testing.B) {
+	benchmarkNearest(b, "testdata/fox.obj", func(m *model.Model) model.PointLocator {
+		return m.KDTree()
+	})
+}
+
+// Measures the time of picking the faces of the model in the specified file with the rays going along the z axis
+// through the points of a grid over its bounding box.
+func benchmarkPick(b *testing.B, filename string) {
+	var m = importModel(filename)
+	if m == nil {
+		b.Skip("the model is not available")
+	}
+	var (
+		hash     = m.SpatialHash(0)
+		min, max = m.Bounds()
+	)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for x := 0; x < 32; x++ {
+			for y := 0; y < 32; y++ {
+				hash.Pick(model.Vertex{
+					X: min.X + (max.X-min.X)*(float64(x)+0.5)/32,
+					Y: min.Y + (max.Y-min.Y)*(float64(y)+0.5)/32,
+					Z: max.Z + 1,
+				}, model.Vertex{Z: -1})
+			}
+		}
+	}
+}
+
+// Measures the time of picking the faces of the rabbit with the spatial hash.
+func BenchmarkSpatialHash_Pick_rabbit(b *testing.B) {
+	benchmarkPick(b, "testdata/rabbit.obj")
+}
+
+// Measures the time of picking the faces of the fox with the spatial hash.
+func BenchmarkSpatialHash_Pick_fox(b *testing.B) {
+	benchmarkPick(b, "testdata/fox.obj")
+}
@@ -0,0 +1,30 @@
+//go:build go1.21
+
+package examples
+
+import (
+	"computer_graphics/obj/importer"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// Imports a file with problems, writing them and the summary of the import as structured log records.
+func ExampleImporter_LogTo() {
+	var logger = slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+		// The time is removed, so that the output does not change.
+		ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+			if attr.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return attr
+		},
+	}))
+	var ipt importer.Importer
+	ipt.LogTo(logger).Import(strings.NewReader("v 0 0 0\nv 1 0 0\nv 0 1\nv 0 1 0\nf 1 2 3 4\nf 1 2 9\n"))
+	// Output:
+	//level=ERROR msg="parameter Z coordinate is not specified" source=parser kind="invalid element" line=3 element=vertex
+	//level=WARN msg="only triangular faces are supported, the first three vertices will be used as a triangle" source=importer kind=polygon line=5 element=face
+	//level=ERROR msg="unresolved vertex index: 9" source=importer kind="invalid face" line=6 element=face
+	//level=INFO msg="model imported" vertices=3 faces=1 lines=0 points=0 unsupported=0 duplicate_faces=0 infos=0 warnings=1 errors=2
+}
@@ -0,0 +1,243 @@
+package examples
+
+import (
+	"computer_graphics/model"
+	"computer_graphics/obj/importer"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+)
+
+// Imports a file consisting of several objects and groups and prints its sub-meshes.
+func ExampleModel_SubMeshes() {
+	const obj = `v 0 0 0
+v 1 0 0
+v 0 1 0
+v 0 0 1
+f 1 2 3
+o tetrahedron
+g bottom
+f 1 3 2
+g sides
+f 1 2 4
+f 2 3 4
+f 3 1 4
+o empty
+o triangle
+f 1 2 3
+`
+	var (
+		ipt = importer.Importer{}
+		m   = ipt.Import(strings.NewReader(obj))
+	)
+	for _, subMesh := range m.SubMeshes() {
+		fmt.Printf("object: %q, groups: %v, faces: %d-%d\n",
+			subMesh.Object,
+			subMesh.Groups,
+			subMesh.FirstFace,
+			subMesh.FirstFace+subMesh.FacesCount-1,
+		)
+	}
+	// Output:
+	//object: "", groups: [], faces: 0-0
+	//object: "tetrahedron", groups: [bottom], faces: 1-1
+	//object: "tetrahedron", groups: [sides], faces: 2-4
+	//object: "triangle", groups: [], faces: 5-5
+}
+
+// Imports a file with several smoothing groups and prints the smoothing group of each face.
+func ExampleModel_SetSmoothingGroup() {
+	const obj = `v 0 0 0
+v 1 0 0
+v 0 1 0
+v 0 0 1
+f 1 3 2
+s 1
+f 1 2 4
+f 2 3 4
+s off
+f 3 1 4
+`
+	var (
+		ipt = importer.Importer{}
+		m   = ipt.Import(strings.NewReader(obj))
+	)
+	for i := 0; i < m.FacesCount(); i++ {
+		fmt.Print(m.GetFace(i).SmoothingGroup(), " ")
+	}
+	fmt.Println()
+	// Output:
+	//0 1 1 0
+}
+
+// Imports a roof of two slopes and generates the normals of the corners of its faces:
+// the slopes of the same smoothing group share the normals at the ridge,
+// the slope of another smoothing group and the face with the smoothing turned off have a crease.
+func ExampleModel_GenerateNormals() {
+	const obj = `v 0 0 0
+v 1 0 0
+v 0 1 1
+v 1 1 1
+v 0 0 2
+v 1 0 2
+s 1
+f 1 2 3
+f 2 4 3
+f 3 4 5
+s 2
+f 4 6 5
+s off
+f 1 6 2
+`
+	var (
+		ipt = importer.Importer{}
+		m   = ipt.Import(strings.NewReader(obj))
+	)
+	m.GenerateNormals()
+	for i := 0; i < m.FacesCount(); i++ {
+		var (
+			normals, _ = m.FaceNormals(i)
+			corners    []string
+		)
+		for _, n := range normals {
+			corners = append(corners, fmt.Sprintf("(%.2f %.2f %.2f)", n.X, n.Y, n.Z))
+		}
+		fmt.Println(strings.Join(corners, " "))
+	}
+	// Output:
+	//(0.00 0.71 -0.71) (0.00 0.71 -0.71) (0.00 0.95 -0.32)
+	//(0.00 0.71 -0.71) (0.00 1.00 0.00) (0.00 0.95 -0.32)
+	//(0.00 0.95 -0.32) (0.00 1.00 0.00) (0.00 0.71 0.71)
+	//(0.00 0.71 0.71) (0.00 0.71 0.71) (0.00 0.71 0.71)
+	//(0.00 -1.00 0.00) (0.00 -1.00 0.00) (0.00 -1.00 0.00)
+}
+
+// Imports a roof of two slopes without the smoothing groups with the normals generated in different ways
+// and prints the normals of the corners of the face of the first slope: the flat normals show the ridge sharp,
+// the smoothing by the angle keeps it sharp for the small threshold and smooths it for the large one.
+func ExampleImporter_GenerateNormals() {
+	const obj = `v 0 0 0
+v 1 0 0
+v 0 1 1
+v 1 1 1
+v 0 0 2
+v 1 0 2
+f 1 2 3
+f 2 4 3
+f 3 4 5
+f 4 6 5
+`
+	for _, mode := range []struct {
+		name string
+		mode importer.NormalMode
+	}{
+		{"flat", importer.FlatNormals},
+		{"60 degrees", importer.SmoothByAngle(math.Pi / 3)},
+		{"120 degrees", importer.SmoothByAngle(2 * math.Pi / 3)},
+	} {
+		var (
+			ipt        = importer.Importer{GenerateNormals: mode.mode}
+			m          = ipt.Import(strings.NewReader(obj))
+			normals, _ = m.FaceNormals(1)
+			corners    []string
+		)
+		for _, n := range normals {
+			corners = append(corners, fmt.Sprintf("(%.2f %.2f %.2f)", n.X, n.Y, n.Z))
+		}
+		fmt.Printf("%s: %s\n", mode.name, strings.Join(corners, " "))
+	}
+	// Output:
+	//flat: (0.00 0.71 -0.71) (0.00 0.71 -0.71) (0.00 0.71 -0.71)
+	//60 degrees: (0.00 0.71 -0.71) (0.00 0.71 -0.71) (0.00 0.71 -0.71)
+	//120 degrees: (0.00 0.71 -0.71) (0.00 0.95 0.32) (0.00 0.95 -0.32)
+}
+
+// Imports a file containing the same faces several times with the removal of the duplicates.
+func ExampleImporter_RemoveDuplicateFaces() {
+	const obj = `v 0 0 0
+v 1 0 0
+v 0 1 0
+v 0 0 1
+f 1 2 3
+f 1 2 4
+f 3 1 2
+f -4 -1 -3
+f 2 3 4
+`
+	var (
+		ipt       = importer.Importer{Output: os.Stdout, RemoveDuplicateFaces: true}
+		m, report = ipt.ImportWithReport(strings.NewReader(obj))
+	)
+	fmt.Println("faces:", m.FacesCount(), "duplicates:", report.DuplicateFaces)
+	// Output:
+	//[WARNING] line: 7, message: duplicate of the face at line 5, the face will be skipped
+	//[WARNING] line: 8, message: duplicate of the face at line 6, the face will be skipped
+	//faces: 3 duplicates: 2
+}
+
+// Imports triangulated polygons with the removal of the duplicates:
+// the rotated quad is skipped as a whole and the quad sharing only a triangle with it is imported as a whole.
+func ExampleImporter_RemoveDuplicateFaces_polygons() {
+	const obj = `v 0 0 0
+v 1 0 0
+v 1 1 0
+v 0 1 0
+v 2 2 0
+f 1 2 3 4
+f 2 3 4 1
+f 1 2 3 5
+`
+	var (
+		ipt       = importer.Importer{Output: os.Stdout, Triangulation: importer.FanTriangulation, RemoveDuplicateFaces: true}
+		m, report = ipt.ImportWithReport(strings.NewReader(obj))
+	)
+	fmt.Println("faces:", m.FacesCount(), "duplicates:", report.DuplicateFaces)
+	// Output:
+	//[WARNING] line: 7, message: duplicate of the face at line 6, the face will be skipped
+	//faces: 4 duplicates: 1
+}
+
+// Imports a file consisting of several objects and groups as a scene and prints the meshes of the scene.
+func ExampleImporter_ImportScene() {
+	const obj = `o model
+v 0 0 0
+v 1 0 0
+v 0 1 0
+v 0 0 1
+v 5 5 5
+o tetrahedron
+g bottom
+f 1 3 2
+g sides
+f 1 2 4
+f 2 3 4
+f 3 1 4
+o empty
+o triangle
+f 1 2 5
+`
+	var (
+		ipt   = importer.Importer{}
+		scene = ipt.ImportScene(strings.NewReader(obj))
+	)
+	fmt.Println("scene:", scene.Metadata[model.NameKey])
+	for _, mesh := range scene.Meshes {
+		var last, _ = mesh.Model.GetVertex(-1)
+		fmt.Printf("%q %v: %d vertices, %d faces, name: %s, last vertex: %v\n",
+			mesh.Object,
+			mesh.Groups,
+			mesh.Model.VerticesCount(),
+			mesh.Model.FacesCount(),
+			mesh.Model.Metadata()[model.NameKey],
+			last,
+		)
+	}
+	fmt.Println("tetrahedron meshes:", len(scene.Find("tetrahedron")), "sides meshes:", len(scene.Find("sides")))
+	// Output:
+	//scene: model
+	//"tetrahedron" [bottom]: 3 vertices, 1 faces, name: tetrahedron, last vertex: {0 1 0}
+	//"tetrahedron" [sides]: 4 vertices, 3 faces, name: tetrahedron, last vertex: {0 0 1}
+	//"triangle" []: 3 vertices, 1 faces, name: triangle, last vertex: {5 5 5}
+	//tetrahedron meshes: 2 sides meshes: 1
+}
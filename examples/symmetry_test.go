@@ -0,0 +1,67 @@
+package examples
+
+import (
+	"computer_graphics/model"
+	"fmt"
+)
+
+// Creates a model of the right half of a square in the XY plane, one of its vertices is slightly off the plane X = 0.
+// The vertex 5 lies on the left side of the plane X = 0 and does not belong to any face.
+func halfSquare() *model.Model {
+	var m = model.NewModel()
+	m.AppendVertex(0, 0, 0)
+	m.AppendVertex(1, 0, 0)
+	m.AppendVertex(0.001, 1, 0)
+	m.AppendVertex(1, 1, 0)
+	m.AppendVertex(-1, 0.5, 0)
+	_ = m.AppendFace(1, 2, 3)
+	_ = m.AppendFace(2, 4, 3)
+	return m
+}
+
+// Prints the vertices and the faces of the model with the normals of the faces.
+func printMesh(m *model.Model) {
+	for i := 1; i <= m.VerticesCount(); i++ {
+		var v, _ = m.GetVertex(i)
+		fmt.Printf("v %g %g %g\n", v.X, v.Y, v.Z)
+	}
+	for i := 0; i < m.FacesCount(); i++ {
+		var (
+			face       = m.GetFace(i)
+			i1, i2, i3 = face.Indices()
+			x, y, z    = face.Normal()
+		)
+		fmt.Printf("f %d %d %d, normal: %g %g %g\n", i1+1, i2+1, i3+1, x, y, z)
+	}
+}
+
+// Example of mirroring the model relative to the plane X = 0.
+func ExampleModel_Mirror() {
+	var plane = model.NewPlane(model.Vertex{}, model.Vertex{X: 1})
+	printMesh(halfSquare().Mirror(plane))
+	// Output:
+	//v 0 0 0
+	//v -1 0 0
+	//v -0.001 1 0
+	//v -1 1 0
+	//v 1 0.5 0
+	//f 1 3 2, normal: 0 0 -1
+	//f 2 3 4, normal: 0 0 -0.999
+}
+
+// Example of completing the half of the model exported without its left side.
+func ExampleModel_Symmetrize() {
+	var plane = model.NewPlane(model.Vertex{}, model.Vertex{X: 1})
+	printMesh(halfSquare().Symmetrize(plane, 0.01))
+	// Output:
+	//v 0 0 0
+	//v 1 0 0
+	//v 0 1 0
+	//v 1 1 0
+	//v -1 0 0
+	//v -1 1 0
+	//f 1 2 3, normal: 0 0 -1
+	//f 2 4 3, normal: 0 0 -1
+	//f 1 3 5, normal: 0 0 -1
+	//f 5 3 6, normal: 0 0 -1
+}
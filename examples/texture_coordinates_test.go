@@ -0,0 +1,50 @@
+package examples
+
+import (
+	"computer_graphics/obj/importer"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Imports a textured quad divided into two triangles and a face referring to an undefined texture vertex,
+// then prints the texture coordinates of the corners of the faces.
+func ExampleModel_FaceTextureVertices() {
+	const obj = `v 0 0 0
+v 1 0 0
+v 1 1 0
+v 0 1 0
+vt 0 0
+vt 1 0
+vt 1 1
+vt 0 1
+f 1/1 2/2 3/3 4/4
+f 1/1 2/2 4/9
+f 2 3 4
+`
+	var (
+		ipt = importer.Importer{Output: os.Stdout, Triangulation: importer.FanTriangulation}
+		m   = ipt.Import(strings.NewReader(obj))
+	)
+	for i := 0; i < m.FacesCount(); i++ {
+		var (
+			t1, t2, t3   = m.GetFace(i).TextureIndices()
+			vertices, ok = m.FaceTextureVertices(i)
+			coordinates  []string
+		)
+		if !ok {
+			fmt.Println(t1, t2, t3, "no texture vertices")
+			continue
+		}
+		for _, v := range vertices {
+			coordinates = append(coordinates, fmt.Sprintf("(%g %g)", v.U, v.V))
+		}
+		fmt.Println(t1, t2, t3, strings.Join(coordinates, " "))
+	}
+	// Output:
+	//[WARNING] line: 10, message: unresolved texture vertex index: 9, the face is imported without the texture vertices
+	//0 1 2 (0 0) (1 0) (1 1)
+	//0 2 3 (0 0) (1 1) (0 1)
+	//-1 -1 -1 no texture vertices
+	//-1 -1 -1 no texture vertices
+}
@@ -0,0 +1,28 @@
+package examples
+
+import (
+	"computer_graphics/obj/importer"
+	"fmt"
+	"os"
+)
+
+// Prints the texture vertices imported from testdata/fox.obj.
+func ExampleModel_GetTextureVertex_fox() {
+	var input, err = os.Open("testdata/fox.obj")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	defer input.Close()
+	var (
+		ipt = importer.Importer{}
+		m   = ipt.Import(input)
+	)
+	fmt.Println(m.TextureVerticesCount())
+	fmt.Println(m.GetTextureVertex(1))
+	fmt.Println(m.GetTextureVertex(-1))
+	// Output:
+	//434
+	//{0.528712 0.678552 0} <nil>
+	//{0.881947 0.346745 0} <nil>
+}
@@ -54,7 +54,7 @@ func renderTriangle(face *model.Face, buffer [][]float64, img *pngimage.Image, r
 			if l1 > 0 && l2 > 0 && l3 > 0 {
 				z = l1*v1.Z + l2*v2.Z + l3*v3.Z
 				if z < buffer[i][j] {
-					img.Set(i, img.Height()-j, rgb)
+					img.SetRGB(i, img.Height()-j, rgb)
 					buffer[i][j] = z
 				}
 			}
@@ -87,11 +87,7 @@ func RenderWithProjectiveTransformation(m *model.Model, img *pngimage.Image, sca
 				face,
 				buffer,
 				img,
-				pngimage.RGB{
-					R: uint8(-cos * 255),
-					G: uint8(-cos * 255),
-					B: uint8(-cos * 255),
-				},
+				pngimage.WhiteColor().Scale(-cos),
 				scale,
 			)
 		}
@@ -0,0 +1,48 @@
+package examples
+
+import (
+	"computer_graphics/obj/importer"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+)
+
+// Imports an L-shaped hexagon with each of the triangulation methods and prints the triangles and their total area:
+// the fan covers the area outside the concave polygon, the ear clipping divides it correctly.
+func ExampleImporter_Triangulation() {
+	const obj = `v 0 0 0
+v 2 0 0
+v 2 1 0
+v 1 1 0
+v 1 2 0
+v 0 2 0
+f 5 6 1 2 3 4
+`
+	for _, method := range []importer.Triangulation{
+		importer.NoTriangulation,
+		importer.FanTriangulation,
+		importer.EarClippingTriangulation,
+	} {
+		var (
+			ipt  = importer.Importer{Output: os.Stdout, Triangulation: method}
+			m    = ipt.Import(strings.NewReader(obj))
+			area = 0.0
+		)
+		for i := 0; i < m.FacesCount(); i++ {
+			var (
+				face       = m.GetFace(i)
+				x, y, z    = face.Normal()
+				v1, v2, v3 = face.Indices()
+			)
+			fmt.Print("[", v1+1, v2+1, v3+1, "] ")
+			area += math.Sqrt(x*x+y*y+z*z) / 2
+		}
+		fmt.Println("area:", area)
+	}
+	// Output:
+	//[WARNING] line: 7, message: only triangular faces are supported, the first three vertices will be used as a triangle
+	//[5 6 1] area: 1
+	//[5 6 1] [5 1 2] [5 2 3] [5 3 4] area: 4
+	//[4 5 6] [4 6 1] [4 1 2] [2 3 4] area: 3
+}
@@ -0,0 +1,49 @@
+package examples
+
+import (
+	"computer_graphics/obj/importer"
+	"fmt"
+	"strings"
+)
+
+// Imports a file with the problems of the quality and prints the ValidationReport,
+// so that the model can be rejected before it gets to the renderer.
+func ExampleImporter_Validate() {
+	const obj = `v 0 0 0
+v 1 0 0
+v 0 1 0
+v 0 0 1
+v 0 -1 0
+v 2 0 0
+v 1 0 0
+f 1 2 3
+f 1 2 4
+f 1 2 5
+f 1 2 6
+f 1 2 9
+f 1 2 -20
+`
+	var (
+		ipt       = importer.Importer{Validate: true}
+		_, report = ipt.ImportWithReport(strings.NewReader(obj))
+		v         = report.Validation
+	)
+	fmt.Println(v)
+	fmt.Println("valid:", v.Valid())
+	fmt.Println("degenerate faces:", v.DegenerateFaces)
+	fmt.Println("duplicate vertices:", v.DuplicateVertices)
+	fmt.Println("non-manifold edges:", v.NonManifoldEdges)
+	fmt.Println("unreferenced vertices:", v.UnreferencedVertices)
+	for _, dropped := range v.DroppedElements {
+		fmt.Println("dropped:", dropped)
+	}
+	// Output:
+	//degenerate faces: 1, duplicate vertices: 1, dropped elements: 2, non-manifold edges: 1, unreferenced vertices: 1
+	//valid: false
+	//degenerate faces: [3]
+	//duplicate vertices: [{6 1}]
+	//non-manifold edges: [[0 1]]
+	//unreferenced vertices: [6]
+	//dropped: line 13, column 7: unresolved vertex index: -20, the number of the elements defined before it is 7
+	//dropped: line 12: unresolved vertex index: 9
+}
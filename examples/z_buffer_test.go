@@ -30,7 +30,7 @@ func DrawTriangleZBuffer(v1, v2, v3 *model.Vertex, buffer [][]float64, img *pngi
 			if l1 > 0 && l2 > 0 && l3 > 0 {
 				z = l1*v1.Z + l2*v2.Z + l3*v3.Z
 				if z < buffer[i][j] {
-					img.Set(i, j, rgb)
+					img.SetRGB(i, j, rgb)
 					buffer[i][j] = z
 				}
 			}
@@ -67,11 +67,7 @@ func BasicLighting(m *model.Model, img *pngimage.Image, rgb pngimage.RGB) {
 				&v3,
 				buffer,
 				img,
-				pngimage.RGB{
-					R: uint8(-float64(rgb.R) * cos),
-					G: uint8(-float64(rgb.G) * cos),
-					B: uint8(-float64(rgb.B) * cos),
-				},
+				rgb.Scale(-cos),
 			)
 		}
 	}
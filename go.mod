@@ -1,3 +1,5 @@
 module github.com/as30606552/go-render
 
 go 1.17
+
+require golang.org/x/image v0.0.0-20220902085622-e7cb96979f69
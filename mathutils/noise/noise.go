@@ -0,0 +1,266 @@
+package noise
+
+import "math"
+
+// Deterministic two- and three-dimensional noise for procedural shading, for example, marble, wood or dithered shadows.
+// The values at the points of the integer lattice are derived from the hash of their coordinates and the seed,
+// so the noise does not need tables and the same seed always gives the same noise.
+// The products are rounded explicitly, which prevents the compiler from fusing them with the additions,
+// so the noise is bit-for-bit the same on all platforms and can be used by the golden-image tests.
+// The zero Noise is the noise with the zero seed.
+type Noise struct {
+	seed uint64 // Selects one of the noises.
+}
+
+// Creates a new Noise with the specified seed.
+func New(seed uint64) Noise {
+	return Noise{seed: seed}
+}
+
+// Returns the seed of the Noise.
+func (n Noise) Seed() uint64 {
+	return n.seed
+}
+
+// Returns the value noise at the point: the smooth interpolation of the random values
+// at the surrounding points of the integer lattice. The result is from 0 to 1.
+func (n Noise) Value2(x, y float64) float64 {
+	var (
+		x0, fx = split(x)
+		y0, fy = split(y)
+		u, v   = fade(fx), fade(fy)
+	)
+	return lerp(v,
+		lerp(u, n.value(x0, y0, 0), n.value(x0+1, y0, 0)),
+		lerp(u, n.value(x0, y0+1, 0), n.value(x0+1, y0+1, 0)),
+	)
+}
+
+// Returns the value noise at the point: the smooth interpolation of the random values
+// at the surrounding points of the integer lattice. The result is from 0 to 1.
+func (n Noise) Value3(x, y, z float64) float64 {
+	var (
+		x0, fx  = split(x)
+		y0, fy  = split(y)
+		z0, fz  = split(z)
+		u, v, w = fade(fx), fade(fy), fade(fz)
+	)
+	return lerp(w,
+		lerp(v,
+			lerp(u, n.value(x0, y0, z0), n.value(x0+1, y0, z0)),
+			lerp(u, n.value(x0, y0+1, z0), n.value(x0+1, y0+1, z0)),
+		),
+		lerp(v,
+			lerp(u, n.value(x0, y0, z0+1), n.value(x0+1, y0, z0+1)),
+			lerp(u, n.value(x0, y0+1, z0+1), n.value(x0+1, y0+1, z0+1)),
+		),
+	)
+}
+
+// Returns the gradient (Perlin) noise at the point: the smooth interpolation of the random gradients
+// at the surrounding points of the integer lattice. The result is approximately from -1 to 1
+// and is zero at the points of the lattice.
+func (n Noise) Perlin2(x, y float64) float64 {
+	var (
+		x0, fx = split(x)
+		y0, fy = split(y)
+		u, v   = fade(fx), fade(fy)
+	)
+	return lerp(v,
+		lerp(u, n.gradient2(x0, y0, fx, fy), n.gradient2(x0+1, y0, fx-1, fy)),
+		lerp(u, n.gradient2(x0, y0+1, fx, fy-1), n.gradient2(x0+1, y0+1, fx-1, fy-1)),
+	)
+}
+
+// Returns the gradient (Perlin) noise at the point: the smooth interpolation of the random gradients
+// at the surrounding points of the integer lattice. The result is approximately from -1 to 1
+// and is zero at the points of the lattice.
+func (n Noise) Perlin3(x, y, z float64) float64 {
+	var (
+		x0, fx  = split(x)
+		y0, fy  = split(y)
+		z0, fz  = split(z)
+		u, v, w = fade(fx), fade(fy), fade(fz)
+	)
+	return lerp(w,
+		lerp(v,
+			lerp(u, n.gradient3(x0, y0, z0, fx, fy, fz), n.gradient3(x0+1, y0, z0, fx-1, fy, fz)),
+			lerp(u, n.gradient3(x0, y0+1, z0, fx, fy-1, fz), n.gradient3(x0+1, y0+1, z0, fx-1, fy-1, fz)),
+		),
+		lerp(v,
+			lerp(u, n.gradient3(x0, y0, z0+1, fx, fy, fz-1), n.gradient3(x0+1, y0, z0+1, fx-1, fy, fz-1)),
+			lerp(u, n.gradient3(x0, y0+1, z0+1, fx, fy-1, fz-1), n.gradient3(x0+1, y0+1, z0+1, fx-1, fy-1, fz-1)),
+		),
+	)
+}
+
+// Returns the fractal sum of the octaves of the Perlin3 noise at the point (fractional Brownian motion).
+// Each next octave has the frequency multiplied by the lacunarity and the amplitude multiplied by the gain,
+// usually 2 and 0.5. The sum is divided by the sum of the amplitudes, so it is approximately from -1 to 1.
+// Returns 0 if the number of the octaves is not positive.
+func (n Noise) Fractal3(x, y, z float64, octaves int, lacunarity, gain float64) float64 {
+	var sum, total, frequency, amplitude = 0.0, 0.0, 1.0, 1.0
+	for i := 0; i < octaves; i++ {
+		sum += float64(amplitude * n.Perlin3(float64(x*frequency), float64(y*frequency), float64(z*frequency)))
+		total += amplitude
+		frequency = float64(frequency * lacunarity)
+		amplitude = float64(amplitude * gain)
+	}
+	if total == 0 {
+		return 0
+	}
+	return sum / total
+}
+
+// Returns the turbulence at the point: the same as Fractal3, but with the absolute values of the octaves,
+// which gives the sharp creases used by the marble and the fire. The result is from 0 to approximately 1.
+func (n Noise) Turbulence3(x, y, z float64, octaves int, lacunarity, gain float64) float64 {
+	var sum, total, frequency, amplitude = 0.0, 0.0, 1.0, 1.0
+	for i := 0; i < octaves; i++ {
+		sum += float64(amplitude * math.Abs(n.Perlin3(float64(x*frequency), float64(y*frequency), float64(z*frequency))))
+		total += amplitude
+		frequency = float64(frequency * lacunarity)
+		amplitude = float64(amplitude * gain)
+	}
+	if total == 0 {
+		return 0
+	}
+	return sum / total
+}
+
+// Returns the marble pattern at the point from 0 to 1: the stripes along the x axis distorted by the turbulence.
+// The scale is the number of the stripes per unit of length.
+func (n Noise) Marble(x, y, z, scale float64) float64 {
+	var turbulence = n.Turbulence3(x, y, z, 5, 2, 0.5)
+	return float64(0.5*sin(float64(scale*x)+float64(8*turbulence))) + 0.5
+}
+
+// Returns the wood pattern at the point from 0 to 1: the rings around the z axis distorted by the noise.
+// The rings is the number of the rings per unit of length.
+func (n Noise) Wood(x, y, z, rings float64) float64 {
+	var (
+		distortion = float64(0.1 * n.Perlin3(float64(2*x), float64(2*y), float64(2*z)))
+		radius     = math.Sqrt(float64(x*x)+float64(y*y)) + distortion
+		ring       = float64(radius * rings)
+	)
+	return ring - math.Floor(ring)
+}
+
+// Returns the random threshold from 0 to 1 of the pixel for the dithering,
+// for example, the pixel is in the shadow if its shadow factor is greater than the threshold.
+// The thresholds of the pixels are independent, so the dithering has no regular pattern.
+func (n Noise) Threshold(x, y int) float64 {
+	return n.value(int64(x), int64(y), -1)
+}
+
+// Returns the random value from 0 to 1 of the point of the integer lattice.
+func (n Noise) value(x, y, z int64) float64 {
+	return float64(n.hash(x, y, z)>>11) / (1 << 53)
+}
+
+// Returns the dot product of the random gradient of the point of the integer lattice
+// and the offset from it, choosing one of the 8 directions to the edges and the corners of a square.
+func (n Noise) gradient2(x, y int64, dx, dy float64) float64 {
+	switch n.hash(x, y, 0) & 7 {
+	case 0:
+		return dx + dy
+	case 1:
+		return -dx + dy
+	case 2:
+		return dx - dy
+	case 3:
+		return -dx - dy
+	case 4:
+		return dx
+	case 5:
+		return -dx
+	case 6:
+		return dy
+	default:
+		return -dy
+	}
+}
+
+// Returns the dot product of the random gradient of the point of the integer lattice
+// and the offset from it, choosing one of the 12 directions to the edges of a cube as in the improved Perlin noise.
+func (n Noise) gradient3(x, y, z int64, dx, dy, dz float64) float64 {
+	switch n.hash(x, y, z) % 12 {
+	case 0:
+		return dx + dy
+	case 1:
+		return -dx + dy
+	case 2:
+		return dx - dy
+	case 3:
+		return -dx - dy
+	case 4:
+		return dx + dz
+	case 5:
+		return -dx + dz
+	case 6:
+		return dx - dz
+	case 7:
+		return -dx - dz
+	case 8:
+		return dy + dz
+	case 9:
+		return -dy + dz
+	case 10:
+		return dy - dz
+	default:
+		return -dy - dz
+	}
+}
+
+// Returns the hash of the point of the integer lattice for the seed of the Noise.
+func (n Noise) hash(x, y, z int64) uint64 {
+	var h = mix(n.seed + 0x9e3779b97f4a7c15)
+	h = mix(h ^ uint64(x))
+	h = mix(h ^ uint64(y))
+	return mix(h ^ uint64(z))
+}
+
+// Mixes the bits of the number, so that close numbers give unrelated results (the finalizer of the SplitMix64).
+func mix(h uint64) uint64 {
+	h = (h ^ h>>30) * 0xbf58476d1ce4e5b9
+	h = (h ^ h>>27) * 0x94d049bb133111eb
+	return h ^ h>>31
+}
+
+// Splits the coordinate into the integer part and the fractional part from 0 to 1.
+func split(coordinate float64) (int64, float64) {
+	var floor = math.Floor(coordinate)
+	return int64(floor), coordinate - floor
+}
+
+// Returns the smooth interpolation factor of the fractional part: 6t^5 - 15t^4 + 10t^3.
+func fade(t float64) float64 {
+	return float64(float64(float64(t*t)*t) * (float64(t*(float64(t*6)-15)) + 10))
+}
+
+// Returns the sine of the angle in radians with the error below 1e-7, used instead of the math.Sin,
+// whose result depends on the platform, because its polynomial can be computed with the fused operations.
+// The angle is reduced to the range from -π/2 to π/2, where the Taylor series up to the 11th power is used.
+func sin(angle float64) float64 {
+	var x = angle - float64(2*math.Pi*math.Floor(angle/(2*math.Pi)+0.5))
+	switch {
+	case x > math.Pi/2:
+		x = math.Pi - x
+	case x < -math.Pi/2:
+		x = -math.Pi - x
+	}
+	var (
+		x2 = float64(x * x)
+		p  = float64(x2*(-1.0/39916800)) + 1.0/362880
+	)
+	p = float64(x2*p) - 1.0/5040
+	p = float64(x2*p) + 1.0/120
+	p = float64(x2*p) - 1.0/6
+	p = float64(x2*p) + 1
+	return float64(x * p)
+}
+
+// Interpolates linearly between a and b by the factor t.
+func lerp(t, a, b float64) float64 {
+	return a + float64(t*(b-a))
+}
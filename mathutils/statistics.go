@@ -0,0 +1,90 @@
+package mathutils
+
+import (
+	"math"
+	"sort"
+)
+
+// Returns the sum of all values.
+func Sum(values []float64) float64 {
+	var sum float64
+	for _, value := range values {
+		sum += value
+	}
+	return sum
+}
+
+// Returns the arithmetic mean of the values.
+// Returns NaN if there are no values.
+func Mean(values []float64) float64 {
+	if len(values) == 0 {
+		return math.NaN()
+	}
+	return Sum(values) / float64(len(values))
+}
+
+// Returns the population variance of the values: the mean of the squared deviations from the mean.
+// Returns NaN if there are no values.
+func Variance(values []float64) float64 {
+	if len(values) == 0 {
+		return math.NaN()
+	}
+	var (
+		mean = Mean(values)
+		sum  float64
+	)
+	for _, value := range values {
+		sum += (value - mean) * (value - mean)
+	}
+	return sum / float64(len(values))
+}
+
+// Returns the population standard deviation of the values.
+// Returns NaN if there are no values.
+func StdDev(values []float64) float64 {
+	return math.Sqrt(Variance(values))
+}
+
+// Returns the p-th percentile of the values, where p is in the range [0, 100].
+// The percentile is linearly interpolated between the closest ranks,
+// so Percentile(values, 50) is the median and Percentile(values, 0) and Percentile(values, 100) are the minimum and maximum.
+// The values are not modified.
+// Returns NaN if there are no values or p is out of range.
+func Percentile(values []float64, p float64) float64 {
+	if len(values) == 0 || !(0 <= p && p <= 100) {
+		return math.NaN()
+	}
+	var sorted = make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+	return percentileSorted(sorted, p)
+}
+
+// Returns the percentiles of the values for each p, sorting the values only once.
+// See Percentile for details.
+func Percentiles(values []float64, p ...float64) []float64 {
+	var (
+		result = make([]float64, len(p))
+		sorted = make([]float64, len(values))
+	)
+	copy(sorted, values)
+	sort.Float64s(sorted)
+	for i := range p {
+		if len(sorted) == 0 || !(0 <= p[i] && p[i] <= 100) {
+			result[i] = math.NaN()
+		} else {
+			result[i] = percentileSorted(sorted, p[i])
+		}
+	}
+	return result
+}
+
+// Returns the p-th percentile of the sorted non-empty values.
+func percentileSorted(sorted []float64, p float64) float64 {
+	var (
+		rank  = p / 100 * float64(len(sorted)-1)
+		lower = int(math.Floor(rank))
+		upper = int(math.Ceil(rank))
+	)
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(rank-float64(lower))
+}
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Well-known names of the vertex attributes.
+const (
+	MeanCurvatureAttribute     = "mean_curvature"     // The mean curvature of the surface at the vertex.
+	GaussianCurvatureAttribute = "gaussian_curvature" // The Gaussian curvature of the surface at the vertex.
+	DistanceAttribute          = "distance"           // The distance from the vertex to another model, see Model.DistancesTo.
+)
+
+// Sets the named attribute of the vertices: a value for each vertex of the model in the order of the vertices.
+// Attributes store the results of analysis, for example, the curvature or the error of the alignment.
+// Returns an error if the number of values does not match the number of vertices.
+// The values are not copied.
+func (model *Model) SetVertexAttribute(name string, values []float64) error {
+	if len(values) != len(model.vertices) {
+		return fmt.Errorf(
+			"the %s attribute has %d values, but the model has %d vertices",
+			name,
+			len(values),
+			len(model.vertices),
+		)
+	}
+	if model.vertexAttributes == nil {
+		model.vertexAttributes = make(map[string][]float64)
+	}
+	model.vertexAttributes[name] = values
+	return nil
+}
+
+// Returns the values of the named vertex attribute and true if the model has such an attribute.
+// The attribute becomes unavailable if the number of vertices has changed since it was set.
+func (model *Model) VertexAttribute(name string) ([]float64, bool) {
+	var values, ok = model.vertexAttributes[name]
+	if !ok || len(values) != len(model.vertices) {
+		return nil, false
+	}
+	return values, true
+}
+
+// Removes the named vertex attribute.
+func (model *Model) DeleteVertexAttribute(name string) {
+	delete(model.vertexAttributes, name)
+}
+
+// Returns the names of all vertex attributes of the model in alphabetical order.
+func (model *Model) VertexAttributeNames() []string {
+	var names = make([]string, 0, len(model.vertexAttributes))
+	for name := range model.vertexAttributes {
+		if _, ok := model.VertexAttribute(name); ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
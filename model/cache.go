@@ -0,0 +1,188 @@
+package model
+
+// Returns the average number of vertices that miss a FIFO vertex cache of the specified size per face (ACMR)
+// when the faces are drawn in their order: from 0.5 for the ideal order of a large mesh to 3 for the worst one.
+// The lower the ratio, the better the locality of access to the vertices.
+func (model *Model) CacheMissRatio(cacheSize int) float64 {
+	if len(model.faces) == 0 {
+		return 0
+	}
+	var (
+		cache  = make([]int, 0, cacheSize) // The vertices in the cache from the oldest to the newest.
+		misses = 0
+	)
+	for _, face := range model.faces {
+		for _, index := range face.indices {
+			var cached = false
+			for _, v := range cache {
+				if v == index {
+					cached = true
+					break
+				}
+			}
+			if cached {
+				continue
+			}
+			misses++
+			if len(cache) == cacheSize {
+				cache = append(cache[:0], cache[1:]...)
+			}
+			cache = append(cache, index)
+		}
+	}
+	return float64(misses) / float64(len(model.faces))
+}
+
+// Reorders the faces and the vertices of the model to improve the locality of access to the vertices
+// for a vertex cache of the specified size, for example, before exporting the model to a GPU renderer.
+// The model is not reordered by default: the software renderers draw each face on its own without a vertex cache,
+// so the order does not speed them up, and the changed order of the faces can even increase the overdraw.
+// The faces are reordered by the Tipsify algorithm (Sander, Nehab, Barczak, 2007) within each sub-mesh,
+// so that the sub-meshes keep their faces.
+// The vertices are then numbered in the order of their first use by the faces,
+// the vertices that are not used by the faces follow them in their original order.
+// The indices of the faces, lines and points and the values of the vertex attributes are updated.
+func (model *Model) ReorderForCache(cacheSize int) {
+	var ranges = model.SubMeshes()
+	if ranges == nil {
+		ranges = []SubMesh{{FirstFace: 0, FacesCount: len(model.faces)}}
+	}
+	var faces = make([]*Face, 0, len(model.faces))
+	for _, subMesh := range ranges {
+		faces = append(faces, model.tipsify(model.faces[subMesh.FirstFace:subMesh.FirstFace+subMesh.FacesCount], cacheSize)...)
+	}
+	model.faces = faces
+	model.reorderVertices()
+}
+
+// Returns the faces in the order found by the Tipsify algorithm for the vertex cache of the specified size.
+// The algorithm moves from vertex to vertex, emitting all remaining faces of the current vertex
+// and choosing as the next one the vertex of the emitted faces that stays in the cache
+// after its remaining faces are emitted.
+func (model *Model) tipsify(faces []*Face, cacheSize int) []*Face {
+	var (
+		verticesCount = len(model.vertices)
+		adjacency     = make([][]int, verticesCount) // The indices of the faces of each vertex.
+		live          = make([]int, verticesCount)   // The number of the faces of each vertex that are not emitted.
+		cacheTime     = make([]int, verticesCount)   // The time when each vertex entered the cache.
+		emitted       = make([]bool, len(faces))
+		deadEnds      []int // The stack of the vertices of the emitted faces, used when there are no candidates.
+		time          = cacheSize + 1
+		cursor        = 0 // The next vertex to check when the dead-end stack is empty.
+		res           = make([]*Face, 0, len(faces))
+	)
+	if len(faces) == 0 {
+		return res
+	}
+	for i, face := range faces {
+		for _, index := range face.indices {
+			adjacency[index] = append(adjacency[index], i)
+			live[index]++
+		}
+	}
+	// Returns the next vertex with the faces that are not emitted after a dead end, -1 if all faces are emitted.
+	var skipDeadEnd = func() int {
+		for len(deadEnds) > 0 {
+			var v = deadEnds[len(deadEnds)-1]
+			deadEnds = deadEnds[:len(deadEnds)-1]
+			if live[v] > 0 {
+				return v
+			}
+		}
+		for ; cursor < verticesCount; cursor++ {
+			if live[cursor] > 0 {
+				return cursor
+			}
+		}
+		return -1
+	}
+	var current = faces[0].indices[0]
+	for current >= 0 {
+		var candidates []int
+		for _, i := range adjacency[current] {
+			if emitted[i] {
+				continue
+			}
+			for _, index := range faces[i].indices {
+				deadEnds = append(deadEnds, index)
+				candidates = append(candidates, index)
+				live[index]--
+				if time-cacheTime[index] > cacheSize {
+					cacheTime[index] = time
+					time++
+				}
+			}
+			emitted[i] = true
+			res = append(res, faces[i])
+		}
+		// The best candidate is the oldest vertex in the cache that stays there after emitting its remaining faces.
+		var best, bestPriority = -1, -1
+		for _, v := range candidates {
+			if live[v] == 0 {
+				continue
+			}
+			var priority = 0
+			if time-cacheTime[v]+2*live[v] <= cacheSize {
+				priority = time - cacheTime[v]
+			}
+			if priority > bestPriority {
+				best, bestPriority = v, priority
+			}
+		}
+		if best < 0 {
+			best = skipDeadEnd()
+		}
+		current = best
+	}
+	return res
+}
+
+// Numbers the vertices in the order of their first use by the faces, then by the lines and the points,
+// the unused vertices follow them in their original order.
+func (model *Model) reorderVertices() {
+	var (
+		mapping  = make([]int, len(model.vertices)) // The new index of each vertex, -1 until it is numbered.
+		order    = make([]int, 0, len(model.vertices))
+		numberOf = func(index int) int {
+			if mapping[index] < 0 {
+				mapping[index] = len(order)
+				order = append(order, index)
+			}
+			return mapping[index]
+		}
+	)
+	for i := range mapping {
+		mapping[i] = -1
+	}
+	for _, face := range model.faces {
+		for i, index := range face.indices {
+			face.indices[i] = numberOf(index)
+		}
+	}
+	for _, line := range model.lines {
+		for i, index := range line.indices {
+			line.indices[i] = numberOf(index)
+		}
+	}
+	for i, index := range model.points {
+		model.points[i] = numberOf(index)
+	}
+	for i := range model.vertices {
+		numberOf(i)
+	}
+	var vertices = make([]*Vertex, len(order))
+	for i, index := range order {
+		vertices[i] = model.vertices[index]
+	}
+	model.vertices = vertices
+	for name, values := range model.vertexAttributes {
+		if len(values) != len(order) {
+			continue
+		}
+		var reordered = make([]float64, len(order))
+		for i, index := range order {
+			reordered[i] = values[index]
+		}
+		model.vertexAttributes[name] = reordered
+	}
+}
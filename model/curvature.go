@@ -0,0 +1,135 @@
+package model
+
+import "math"
+
+// Estimates the mean and Gaussian curvature of the surface at each vertex of the model
+// and stores them in the MeanCurvatureAttribute and GaussianCurvatureAttribute vertex attributes.
+//
+// The discrete operators of Meyer et al. are used:
+// the Gaussian curvature is the angle deficit of the vertex divided by its mixed Voronoi area,
+// the mean curvature is half the length of the cotangent Laplacian of the vertex position.
+// The mean curvature is positive where the surface bends away from the normals of the faces (Face.Normal),
+// for example, on a sphere with the normals directed outwards.
+// For the vertices on the boundary of the surface, the angle deficit is measured from π instead of 2π.
+// The vertices that do not belong to any face get zero curvature.
+func (model *Model) EstimateCurvature() (mean, gaussian []float64) {
+	var (
+		n         = len(model.vertices)
+		areas     = make([]float64, n)
+		angles    = make([]float64, n)
+		laplacian = make([]Vertex, n)
+		normals   = make([]Vertex, n)
+		edges     = make(map[[2]int]int) // The number of faces containing each edge.
+	)
+	for _, f := range model.faces {
+		var (
+			idx     = f.indices
+			p       = [3]*Vertex{f.vertex1, f.vertex2, f.vertex3}
+			x, y, z = f.Normal()
+		)
+		for corner := 0; corner < 3; corner++ {
+			var i = idx[corner]
+			normals[i] = add(normals[i], Vertex{X: x, Y: y, Z: z})
+			edges[edgeKey(i, idx[(corner+1)%3])]++
+		}
+		var (
+			e    [3]Vertex  // The edge opposite to the corner.
+			cot  [3]float64 // The cotangent of the angle at the corner.
+			area = vectorLength(cross(sub(*p[1], *p[0]), sub(*p[2], *p[0]))) / 2
+		)
+		if area == 0 {
+			continue
+		}
+		for corner := 0; corner < 3; corner++ {
+			var (
+				a = sub(*p[(corner+1)%3], *p[corner])
+				b = sub(*p[(corner+2)%3], *p[corner])
+			)
+			e[corner] = sub(*p[(corner+2)%3], *p[(corner+1)%3])
+			cot[corner] = dot(a, b) / vectorLength(cross(a, b))
+			angles[idx[corner]] += math.Atan2(vectorLength(cross(a, b)), dot(a, b))
+		}
+		for corner := 0; corner < 3; corner++ {
+			var (
+				i    = idx[corner]
+				j, k = (corner + 1) % 3, (corner + 2) % 3
+			)
+			// The cotangent Laplacian: the edge from i to j is weighted by the cotangent of the angle opposite to it.
+			laplacian[i] = add(laplacian[i], scale(sub(*p[corner], *p[j]), cot[k]))
+			laplacian[i] = add(laplacian[i], scale(sub(*p[corner], *p[k]), cot[j]))
+			// The mixed Voronoi area.
+			switch {
+			case cot[corner] < 0:
+				areas[i] += area / 2
+			case cot[j] < 0 || cot[k] < 0:
+				areas[i] += area / 4
+			default:
+				areas[i] += (dot(e[k], e[k])*cot[k] + dot(e[j], e[j])*cot[j]) / 8
+			}
+		}
+	}
+	var boundary = make([]bool, n)
+	for edge, count := range edges {
+		if count == 1 {
+			boundary[edge[0]] = true
+			boundary[edge[1]] = true
+		}
+	}
+	mean = make([]float64, n)
+	gaussian = make([]float64, n)
+	for i := 0; i < n; i++ {
+		if areas[i] == 0 {
+			continue
+		}
+		var full = 2 * math.Pi
+		if boundary[i] {
+			full = math.Pi
+		}
+		gaussian[i] = (full - angles[i]) / areas[i]
+		var (
+			k      = scale(laplacian[i], 1/(2*areas[i]))
+			length = vectorLength(normals[i])
+		)
+		if length == 0 {
+			mean[i] = vectorLength(k) / 2
+		} else {
+			mean[i] = dot(k, normals[i]) / length / 2
+		}
+	}
+	_ = model.SetVertexAttribute(MeanCurvatureAttribute, mean)
+	_ = model.SetVertexAttribute(GaussianCurvatureAttribute, gaussian)
+	return mean, gaussian
+}
+
+// Returns the key of the edge between the vertices with the specified indices that does not depend on the direction.
+func edgeKey(i, j int) [2]int {
+	if j < i {
+		return [2]int{j, i}
+	}
+	return [2]int{i, j}
+}
+
+// Returns the sum of the vectors.
+func add(a, b Vertex) Vertex {
+	return Vertex{X: a.X + b.X, Y: a.Y + b.Y, Z: a.Z + b.Z}
+}
+
+// Returns the difference of the vectors.
+func sub(a, b Vertex) Vertex {
+	return Vertex{X: a.X - b.X, Y: a.Y - b.Y, Z: a.Z - b.Z}
+}
+
+// Returns the vector multiplied by the number.
+func scale(a Vertex, k float64) Vertex {
+	return Vertex{X: a.X * k, Y: a.Y * k, Z: a.Z * k}
+}
+
+// Returns the dot product of the vectors.
+func dot(a, b Vertex) float64 {
+	return a.X*b.X + a.Y*b.Y + a.Z*b.Z
+}
+
+// Returns the length of the vector.
+func vectorLength(a Vertex) float64 {
+	return math.Sqrt(dot(a, a))
+}
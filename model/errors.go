@@ -0,0 +1,48 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+)
+
+// The kinds of the errors returned by the methods of the Model, they can be checked by errors.Is.
+var (
+	// The index refers to no element of the model: it is greater than the number of the elements
+	// or its absolute value is greater than it for the negative index.
+	ErrIndexOutOfRange = errors.New("index out of range")
+	// The index is zero, the indices of the elements of the model start from 1.
+	ErrZeroIndex = errors.New("index cannot be zero")
+	// The face refers to the same vertex several times, so it has no area.
+	ErrDegenerateFace = errors.New("degenerate face")
+	// The line has less than two vertices.
+	ErrDegenerateLine = errors.New("degenerate line")
+)
+
+// The error of an index of the vertex, texture vertex or parameter space vertex specified incorrectly.
+// It wraps the ErrIndexOutOfRange or the ErrZeroIndex, and errors.As gives the index that caused the error.
+type IndexError struct {
+	Element string // The name of the element referred to by the index, for example, 'vertex'.
+	Index   int    // The index as it was specified, starting from 1 or negative.
+	Err     error  // The kind of the error: ErrIndexOutOfRange or ErrZeroIndex.
+}
+
+// Returns the description of the error, for example, 'unresolved vertex index: 5'.
+func (e *IndexError) Error() string {
+	if e.Err == ErrZeroIndex {
+		return fmt.Sprintf("%s index cannot be zero", e.Element)
+	}
+	return fmt.Sprintf("unresolved %s index: %d", e.Element, e.Index)
+}
+
+// Returns the kind of the error, so that errors.Is can check it.
+func (e *IndexError) Unwrap() error {
+	return e.Err
+}
+
+// Returns the IndexError for the index of the element of the model that refers to no element.
+func indexError(element string, index int) error {
+	if index == 0 {
+		return &IndexError{Element: element, Index: index, Err: ErrZeroIndex}
+	}
+	return &IndexError{Element: element, Index: index, Err: ErrIndexOutOfRange}
+}
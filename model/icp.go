@@ -0,0 +1,387 @@
+package model
+
+import "math"
+
+// A transformation that rotates and then shifts the points without changing the distances between them.
+type RigidTransform struct {
+	Rotation    [3][3]float64 // The rotation matrix.
+	Translation Vertex        // The shift applied after the rotation.
+}
+
+// Returns the transformation that does not change the points.
+func IdentityTransform() RigidTransform {
+	return RigidTransform{Rotation: [3][3]float64{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}}
+}
+
+// Returns the transformation that rotates the points around the axis passing through the origin
+// by the angle in radians, counterclockwise when looking from the end of the axis vector.
+func AxisRotation(axis Vertex, angle float64) RigidTransform {
+	var length = math.Sqrt(axis.X*axis.X + axis.Y*axis.Y + axis.Z*axis.Z)
+	if length == 0 {
+		return IdentityTransform()
+	}
+	return RigidTransform{Rotation: rotationFromVector(Vertex{
+		X: axis.X / length * angle,
+		Y: axis.Y / length * angle,
+		Z: axis.Z / length * angle,
+	})}
+}
+
+// Applies the transformation to the point.
+// Has the signature of the Model.Transform function argument, so the model can be transformed by calling
+// model.Transform(t.Apply).
+func (t RigidTransform) Apply(x, y, z float64) (float64, float64, float64) {
+	var r = &t.Rotation
+	return r[0][0]*x + r[0][1]*y + r[0][2]*z + t.Translation.X,
+		r[1][0]*x + r[1][1]*y + r[1][2]*z + t.Translation.Y,
+		r[2][0]*x + r[2][1]*y + r[2][2]*z + t.Translation.Z
+}
+
+// Returns the transformation equivalent to applying t and then next.
+func (t RigidTransform) Then(next RigidTransform) RigidTransform {
+	var result RigidTransform
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			for k := 0; k < 3; k++ {
+				result.Rotation[i][j] += next.Rotation[i][k] * t.Rotation[k][j]
+			}
+		}
+	}
+	var x, y, z = next.Apply(t.Translation.X, t.Translation.Y, t.Translation.Z)
+	result.Translation = Vertex{X: x, Y: y, Z: z}
+	return result
+}
+
+// Returns the angle of the rotation of the transformation in radians.
+func (t RigidTransform) Angle() float64 {
+	var cos = (t.Rotation[0][0] + t.Rotation[1][1] + t.Rotation[2][2] - 1) / 2
+	return math.Acos(math.Max(-1, math.Min(1, cos)))
+}
+
+// Settings of the iterative closest point algorithm.
+type ICPOptions struct {
+	MaxIterations int     // The maximum number of iterations, 50 if not positive.
+	Tolerance     float64 // The algorithm stops when the mean squared distance changes by less than Tolerance.
+	MaxDistance   float64 // The pairs of points farther from each other than MaxDistance are ignored, if positive.
+	// If true, the distances from the points to the tangent planes of the target are minimized (point-to-plane),
+	// which usually converges in fewer iterations on smooth surfaces.
+	// The normals of the target vertices are calculated from its faces,
+	// so the target without faces is aligned point-to-point.
+	PointToPlane bool
+}
+
+// The result of the iterative closest point algorithm.
+type ICPResult struct {
+	Transform  RigidTransform // The transformation that aligns the source with the target.
+	Error      float64        // The root mean square distance from the transformed source vertices to the closest target vertices.
+	Iterations int            // The number of iterations performed.
+	Converged  bool           // true if the algorithm stopped because of the Tolerance.
+}
+
+// Finds the rigid transformation that aligns the vertices of the source with the vertices of the target
+// using the iterative closest point (ICP) algorithm:
+// each vertex of the source is paired with the closest vertex of the target found using the KDTree,
+// and the transformation minimizing the distances between the pairs is calculated, until the distances stop decreasing.
+// The algorithm finds the local minimum, so the models should be roughly aligned in advance.
+// The models are not changed, use source.Transform(result.Transform.Apply) to apply the transformation.
+func AlignICP(source, target *Model, options ICPOptions) ICPResult {
+	var result = ICPResult{Transform: IdentityTransform(), Error: math.NaN()}
+	if len(source.vertices) == 0 || len(target.vertices) == 0 {
+		return result
+	}
+	if options.MaxIterations <= 0 {
+		options.MaxIterations = 50
+	}
+	var (
+		tree    = target.KDTree()
+		normals []Vertex
+		points  = make([]Vertex, len(source.vertices))
+		pairs   = make([]icpPair, 0, len(source.vertices))
+		last    = math.Inf(+1)
+	)
+	if options.PointToPlane && len(target.faces) != 0 {
+		normals = target.vertexNormals()
+	}
+	for i, v := range source.vertices {
+		points[i] = *v
+	}
+	for result.Iterations < options.MaxIterations {
+		var mse = findPairs(tree, points, options.MaxDistance, &pairs)
+		if len(pairs) < 3 || math.Abs(last-mse) < options.Tolerance {
+			result.Converged = len(pairs) >= 3
+			break
+		}
+		last = mse
+		var (
+			step RigidTransform
+			ok   bool
+		)
+		if normals != nil {
+			step, ok = pointToPlaneStep(tree, normals, pairs)
+		}
+		if !ok {
+			step = pointToPointStep(tree, pairs)
+		}
+		for i := range points {
+			var x, y, z = step.Apply(points[i].X, points[i].Y, points[i].Z)
+			points[i] = Vertex{X: x, Y: y, Z: z}
+		}
+		result.Transform = result.Transform.Then(step)
+		result.Iterations++
+	}
+	result.Error = math.Sqrt(findPairs(tree, points, options.MaxDistance, &pairs))
+	return result
+}
+
+// A pair of the transformed source vertex and the index of the closest target vertex.
+type icpPair struct {
+	point  Vertex
+	target int
+}
+
+// Pairs each point with the closest point of the tree, ignoring the pairs farther than maxDistance if it is positive.
+// Returns the mean squared distance between the points of the pairs.
+func findPairs(tree *KDTree, points []Vertex, maxDistance float64, pairs *[]icpPair) float64 {
+	var sum float64
+	*pairs = (*pairs)[:0]
+	for _, p := range points {
+		var index, distance = tree.Nearest(p)
+		if maxDistance > 0 && distance > maxDistance*maxDistance {
+			continue
+		}
+		*pairs = append(*pairs, icpPair{point: p, target: index})
+		sum += distance
+	}
+	if len(*pairs) == 0 {
+		return math.NaN()
+	}
+	return sum / float64(len(*pairs))
+}
+
+// Calculates the rigid transformation minimizing the squared distances between the points of the pairs
+// using the closed-form solution of Horn based on unit quaternions.
+func pointToPointStep(tree *KDTree, pairs []icpPair) RigidTransform {
+	var (
+		n                      = float64(len(pairs))
+		sourceMean, targetMean Vertex
+	)
+	for _, pair := range pairs {
+		var q = tree.Point(pair.target)
+		sourceMean = Vertex{sourceMean.X + pair.point.X/n, sourceMean.Y + pair.point.Y/n, sourceMean.Z + pair.point.Z/n}
+		targetMean = Vertex{targetMean.X + q.X/n, targetMean.Y + q.Y/n, targetMean.Z + q.Z/n}
+	}
+	// The cross-covariance matrix of the centered points.
+	var s [3][3]float64
+	for _, pair := range pairs {
+		var (
+			q = tree.Point(pair.target)
+			a = [3]float64{pair.point.X - sourceMean.X, pair.point.Y - sourceMean.Y, pair.point.Z - sourceMean.Z}
+			b = [3]float64{q.X - targetMean.X, q.Y - targetMean.Y, q.Z - targetMean.Z}
+		)
+		for i := 0; i < 3; i++ {
+			for j := 0; j < 3; j++ {
+				s[i][j] += a[i] * b[j]
+			}
+		}
+	}
+	// The rotation is given by the eigenvector of the largest eigenvalue of this matrix.
+	var horn = [4][4]float64{
+		{s[0][0] + s[1][1] + s[2][2], s[1][2] - s[2][1], s[2][0] - s[0][2], s[0][1] - s[1][0]},
+		{s[1][2] - s[2][1], s[0][0] - s[1][1] - s[2][2], s[0][1] + s[1][0], s[2][0] + s[0][2]},
+		{s[2][0] - s[0][2], s[0][1] + s[1][0], -s[0][0] + s[1][1] - s[2][2], s[1][2] + s[2][1]},
+		{s[0][1] - s[1][0], s[2][0] + s[0][2], s[1][2] + s[2][1], -s[0][0] - s[1][1] + s[2][2]},
+	}
+	var (
+		transform = RigidTransform{Rotation: quaternionToMatrix(largestEigenvector(horn))}
+		x, y, z   = transform.Apply(sourceMean.X, sourceMean.Y, sourceMean.Z)
+	)
+	transform.Translation = Vertex{X: targetMean.X - x, Y: targetMean.Y - y, Z: targetMean.Z - z}
+	return transform
+}
+
+// Calculates the rigid transformation minimizing the squared distances from the source points of the pairs
+// to the tangent planes at the target points, linearized for small rotations.
+// Returns false if the linear system is degenerate, for example, if all the normals are parallel.
+func pointToPlaneStep(tree *KDTree, normals []Vertex, pairs []icpPair) (RigidTransform, bool) {
+	// The normal equations of the linear least squares problem for the rotation vector and the translation.
+	var (
+		a [6][6]float64
+		b [6]float64
+	)
+	for _, pair := range pairs {
+		var (
+			p   = pair.point
+			q   = tree.Point(pair.target)
+			n   = normals[pair.target]
+			c   = cross(p, n)
+			row = [6]float64{c.X, c.Y, c.Z, n.X, n.Y, n.Z}
+			r   = (q.X-p.X)*n.X + (q.Y-p.Y)*n.Y + (q.Z-p.Z)*n.Z
+		)
+		for i := 0; i < 6; i++ {
+			for j := 0; j < 6; j++ {
+				a[i][j] += row[i] * row[j]
+			}
+			b[i] += row[i] * r
+		}
+	}
+	var x, ok = solveLinear(a, b)
+	if !ok {
+		return RigidTransform{}, false
+	}
+	return RigidTransform{
+		Rotation:    rotationFromVector(Vertex{X: x[0], Y: x[1], Z: x[2]}),
+		Translation: Vertex{X: x[3], Y: x[4], Z: x[5]},
+	}, true
+}
+
+// Calculates the unit normals of the vertices as the sums of the normals of the adjacent faces,
+// so that larger faces have more influence.
+func (model *Model) vertexNormals() []Vertex {
+	var (
+		indices = make(map[*Vertex]int, len(model.vertices))
+		normals = make([]Vertex, len(model.vertices))
+	)
+	for i, v := range model.vertices {
+		indices[v] = i
+	}
+	for _, f := range model.faces {
+		var x, y, z = f.Normal()
+		for _, v := range [...]*Vertex{f.vertex1, f.vertex2, f.vertex3} {
+			var n = &normals[indices[v]]
+			n.X, n.Y, n.Z = n.X+x, n.Y+y, n.Z+z
+		}
+	}
+	for i := range normals {
+		var n = &normals[i]
+		if length := math.Sqrt(n.X*n.X + n.Y*n.Y + n.Z*n.Z); length != 0 {
+			n.X, n.Y, n.Z = n.X/length, n.Y/length, n.Z/length
+		}
+	}
+	return normals
+}
+
+// Returns the cross product of the vectors.
+func cross(a, b Vertex) Vertex {
+	return Vertex{X: a.Y*b.Z - a.Z*b.Y, Y: a.Z*b.X - a.X*b.Z, Z: a.X*b.Y - a.Y*b.X}
+}
+
+// Returns the rotation matrix around the direction of the vector by the angle equal to its length (Rodrigues' formula).
+func rotationFromVector(v Vertex) [3][3]float64 {
+	var angle = math.Sqrt(v.X*v.X + v.Y*v.Y + v.Z*v.Z)
+	if angle == 0 {
+		return IdentityTransform().Rotation
+	}
+	var (
+		sin, cos = math.Sincos(angle / 2)
+		q        = [4]float64{cos, v.X / angle * sin, v.Y / angle * sin, v.Z / angle * sin}
+	)
+	return quaternionToMatrix(q)
+}
+
+// Returns the rotation matrix of the unit quaternion (w, x, y, z).
+func quaternionToMatrix(q [4]float64) [3][3]float64 {
+	var w, x, y, z = q[0], q[1], q[2], q[3]
+	return [3][3]float64{
+		{w*w + x*x - y*y - z*z, 2 * (x*y - w*z), 2 * (x*z + w*y)},
+		{2 * (x*y + w*z), w*w - x*x + y*y - z*z, 2 * (y*z - w*x)},
+		{2 * (x*z - w*y), 2 * (y*z + w*x), w*w - x*x - y*y + z*z},
+	}
+}
+
+// Returns the unit eigenvector of the largest eigenvalue of the symmetric matrix using the Jacobi eigenvalue algorithm.
+func largestEigenvector(m [4][4]float64) [4]float64 {
+	var vectors = [4][4]float64{{1, 0, 0, 0}, {0, 1, 0, 0}, {0, 0, 1, 0}, {0, 0, 0, 1}}
+	for sweep := 0; sweep < 50; sweep++ {
+		var off float64
+		for p := 0; p < 4; p++ {
+			for q := p + 1; q < 4; q++ {
+				off += m[p][q] * m[p][q]
+			}
+		}
+		if off < 1e-30 {
+			break
+		}
+		for p := 0; p < 4; p++ {
+			for q := p + 1; q < 4; q++ {
+				if m[p][q] == 0 {
+					continue
+				}
+				// The rotation that zeroes the element (p, q).
+				var (
+					theta = (m[q][q] - m[p][p]) / (2 * m[p][q])
+					t     = 1 / (math.Abs(theta) + math.Sqrt(theta*theta+1))
+				)
+				if theta < 0 {
+					t = -t
+				}
+				var (
+					c = 1 / math.Sqrt(t*t+1)
+					s = t * c
+				)
+				for k := 0; k < 4; k++ {
+					var mkp, mkq = m[k][p], m[k][q]
+					m[k][p] = c*mkp - s*mkq
+					m[k][q] = s*mkp + c*mkq
+				}
+				for k := 0; k < 4; k++ {
+					var mpk, mqk = m[p][k], m[q][k]
+					m[p][k] = c*mpk - s*mqk
+					m[q][k] = s*mpk + c*mqk
+				}
+				for k := 0; k < 4; k++ {
+					var vkp, vkq = vectors[k][p], vectors[k][q]
+					vectors[k][p] = c*vkp - s*vkq
+					vectors[k][q] = s*vkp + c*vkq
+				}
+			}
+		}
+	}
+	var largest = 0
+	for i := 1; i < 4; i++ {
+		if m[i][i] > m[largest][largest] {
+			largest = i
+		}
+	}
+	return [4]float64{vectors[0][largest], vectors[1][largest], vectors[2][largest], vectors[3][largest]}
+}
+
+// Solves the system of linear equations a * x = b using the Gaussian elimination with partial pivoting.
+// Returns false if the matrix is singular.
+func solveLinear(a [6][6]float64, b [6]float64) ([6]float64, bool) {
+	const n = 6
+	var (
+		x     [n]float64
+		scale float64 // The threshold for the pivot is relative to the scale of the matrix.
+	)
+	for i := 0; i < n; i++ {
+		scale = math.Max(scale, math.Abs(a[i][i]))
+	}
+	for col := 0; col < n; col++ {
+		var pivot = col
+		for row := col + 1; row < n; row++ {
+			if math.Abs(a[row][col]) > math.Abs(a[pivot][col]) {
+				pivot = row
+			}
+		}
+		if math.Abs(a[pivot][col]) <= scale*1e-12 {
+			return x, false
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+		b[col], b[pivot] = b[pivot], b[col]
+		for row := col + 1; row < n; row++ {
+			var factor = a[row][col] / a[col][col]
+			for k := col; k < n; k++ {
+				a[row][k] -= factor * a[col][k]
+			}
+			b[row] -= factor * b[col]
+		}
+	}
+	for row := n - 1; row >= 0; row-- {
+		var sum = b[row]
+		for k := row + 1; k < n; k++ {
+			sum -= a[row][k] * x[k]
+		}
+		x[row] = sum / a[row][row]
+	}
+	return x, true
+}
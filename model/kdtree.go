@@ -0,0 +1,156 @@
+package model
+
+import "math"
+
+// A k-d tree over a set of points in three-dimensional space.
+// Allows to find the point closest to the specified one in logarithmic time on average.
+type KDTree struct {
+	points  []Vertex // The points of the tree.
+	indices []int    // The indices of the points arranged so that the median of each range is the node of the tree.
+}
+
+// Creates a new KDTree containing the points.
+// The points are copied, so changing them after that does not affect the tree.
+func NewKDTree(points []Vertex) *KDTree {
+	var tree = &KDTree{
+		points:  make([]Vertex, len(points)),
+		indices: make([]int, len(points)),
+	}
+	copy(tree.points, points)
+	for i := range tree.indices {
+		tree.indices[i] = i
+	}
+	tree.build(0, len(tree.indices), 0)
+	return tree
+}
+
+// Creates a new KDTree containing the vertices of the model.
+// The indices returned by the tree are the indices of the vertices starting from 0.
+func (model *Model) KDTree() *KDTree {
+	return NewKDTree(model.vertexCopies())
+}
+
+// Calculates the distance from each vertex of the model to the nearest vertex of the target model.
+// The distances are in the order of the vertices of the model and can be stored in the DistanceAttribute.
+// The distances are infinite if the target model has no vertices.
+// The nearest vertices are found by the PointLocator chosen for the target model, see Model.PointLocator.
+func (model *Model) DistancesTo(target *Model) []float64 {
+	var (
+		locator   = target.PointLocator()
+		distances = make([]float64, len(model.vertices))
+	)
+	for i, v := range model.vertices {
+		var _, distance = locator.Nearest(*v)
+		distances[i] = math.Sqrt(distance)
+	}
+	return distances
+}
+
+// Returns the number of points in the tree.
+func (tree *KDTree) Len() int {
+	return len(tree.points)
+}
+
+// Returns the point of the tree by its index.
+func (tree *KDTree) Point(index int) Vertex {
+	return tree.points[index]
+}
+
+// Returns the index of the point closest to p and the squared distance to it.
+// Returns -1 and the positive infinity if the tree is empty.
+func (tree *KDTree) Nearest(p Vertex) (int, float64) {
+	var (
+		best     = -1
+		distance = math.Inf(+1)
+	)
+	tree.nearest(p, 0, len(tree.indices), 0, &best, &distance)
+	return best, distance
+}
+
+// Returns the coordinate of the point along the axis with the specified number.
+func coordinate(v *Vertex, axis int) float64 {
+	switch axis {
+	case 0:
+		return v.X
+	case 1:
+		return v.Y
+	default:
+		return v.Z
+	}
+}
+
+// Returns the squared distance between the points.
+func distanceSquared(a, b *Vertex) float64 {
+	var dx, dy, dz = a.X - b.X, a.Y - b.Y, a.Z - b.Z
+	return dx*dx + dy*dy + dz*dz
+}
+
+// Arranges the indices in the range [from, to) so that the median along the axis of the depth is in the middle,
+// the points that are not greater are before it and the points that are not less are after it,
+// then arranges both halves recursively.
+func (tree *KDTree) build(from, to, depth int) {
+	if to-from < 2 {
+		return
+	}
+	var mid = (from + to) / 2
+	tree.selectNth(from, to, mid, depth%3)
+	tree.build(from, mid, depth+1)
+	tree.build(mid+1, to, depth+1)
+}
+
+// Moves the index of the n-th smallest point along the axis in the range [from, to) to the position n (quickselect).
+func (tree *KDTree) selectNth(from, to, n, axis int) {
+	var indices = tree.indices
+	for to-from > 1 {
+		var (
+			pivotIndex = indices[(from+to)/2]
+			pivot      = coordinate(&tree.points[pivotIndex], axis)
+			store      = from
+		)
+		indices[(from+to)/2], indices[to-1] = indices[to-1], indices[(from+to)/2]
+		for i := from; i < to-1; i++ {
+			if coordinate(&tree.points[indices[i]], axis) < pivot {
+				indices[i], indices[store] = indices[store], indices[i]
+				store++
+			}
+		}
+		indices[store], indices[to-1] = indices[to-1], indices[store]
+		switch {
+		case n < store:
+			to = store
+		case n > store:
+			from = store + 1
+		default:
+			return
+		}
+	}
+}
+
+// Searches for the point closest to p in the range [from, to) of the tree,
+// updating the best index and distance if a closer point is found.
+func (tree *KDTree) nearest(p Vertex, from, to, depth int, best *int, distance *float64) {
+	if from >= to {
+		return
+	}
+	var (
+		mid   = (from + to) / 2
+		index = tree.indices[mid]
+		node  = &tree.points[index]
+	)
+	if d := distanceSquared(&p, node); d < *distance {
+		*best = index
+		*distance = d
+	}
+	var delta = coordinate(&p, depth%3) - coordinate(node, depth%3)
+	if delta < 0 {
+		tree.nearest(p, from, mid, depth+1, best, distance)
+		if delta*delta < *distance {
+			tree.nearest(p, mid+1, to, depth+1, best, distance)
+		}
+	} else {
+		tree.nearest(p, mid+1, to, depth+1, best, distance)
+		if delta*delta < *distance {
+			tree.nearest(p, from, mid, depth+1, best, distance)
+		}
+	}
+}
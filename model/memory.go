@@ -0,0 +1,134 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"unsafe"
+)
+
+// The sizes of the structures stored by the model in bytes.
+const (
+	pointerSize         = int(unsafe.Sizeof(uintptr(0)))
+	vertexSize          = int(unsafe.Sizeof(Vertex{}))
+	textureVertexSize   = int(unsafe.Sizeof(TextureVertex{}))
+	parameterVertexSize = int(unsafe.Sizeof(ParameterVertex{}))
+	faceSize            = int(unsafe.Sizeof(Face{}))
+	lineSize            = int(unsafe.Sizeof(Line{}))
+	intSize             = int(unsafe.Sizeof(0))
+	subMeshSize         = int(unsafe.Sizeof(SubMesh{}))
+	float64Size         = int(unsafe.Sizeof(float64(0)))
+)
+
+// The approximate memory used by the arrays of the model in bytes, see Model.MemoryUsage.
+// The allocated capacity of the arrays is counted, not only their length.
+type MemoryUsage struct {
+	Vertices          int // The vertices and the pointers to them.
+	TextureVertices   int // The texture vertices.
+	ParameterVertices int // The parameter space vertices.
+	Faces             int // The faces, the pointers to them and the normals of their corners.
+	Lines             int // The lines with their vertices and the pointers to them.
+	Points            int // The indices of the vertices of the points.
+	SubMeshes         int // The sub-meshes, without their names.
+	Metadata          int // The keys and the values of the metadata.
+	VertexAttributes  int // The values and the names of the vertex attributes.
+	Reserved          int // The vertices and faces reserved by Model.Reserve and not used yet.
+}
+
+// Returns the total memory used by the arrays of the model in bytes.
+func (usage MemoryUsage) Total() int {
+	return usage.Vertices + usage.TextureVertices + usage.ParameterVertices + usage.Faces + usage.Lines +
+		usage.Points + usage.SubMeshes + usage.Metadata + usage.VertexAttributes + usage.Reserved
+}
+
+// Returns the summary of the memory usage in KiB, for example,
+// 'vertices: 1875.0 KiB, faces: 3281.3 KiB, ..., total: 5320.1 KiB'.
+func (usage MemoryUsage) String() string {
+	var parts []string
+	for _, part := range [...]struct {
+		name  string
+		bytes int
+	}{
+		{"vertices", usage.Vertices},
+		{"texture vertices", usage.TextureVertices},
+		{"parameter vertices", usage.ParameterVertices},
+		{"faces", usage.Faces},
+		{"lines", usage.Lines},
+		{"points", usage.Points},
+		{"sub-meshes", usage.SubMeshes},
+		{"metadata", usage.Metadata},
+		{"vertex attributes", usage.VertexAttributes},
+		{"reserved", usage.Reserved},
+		{"total", usage.Total()},
+	} {
+		parts = append(parts, fmt.Sprintf("%s: %.1f KiB", part.name, float64(part.bytes)/1024))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Returns the approximate memory used by the arrays of the model.
+// The vertices and faces shared by the model with its copies are counted in each of them.
+func (model *Model) MemoryUsage() MemoryUsage {
+	var usage = MemoryUsage{
+		Vertices:          cap(model.vertices)*pointerSize + len(model.vertices)*vertexSize,
+		TextureVertices:   cap(model.textureVertices) * textureVertexSize,
+		ParameterVertices: cap(model.paramVertices) * parameterVertexSize,
+		Faces:             cap(model.faces)*pointerSize + len(model.faces)*faceSize + cap(model.normals)*3*vertexSize,
+		Lines:             cap(model.lines) * pointerSize,
+		Points:            cap(model.points) * intSize,
+		SubMeshes:         cap(model.subMeshes) * subMeshSize,
+		Reserved:          len(model.spareVertices)*vertexSize + len(model.spareFaces)*faceSize,
+	}
+	for _, line := range model.lines {
+		usage.Lines += lineSize + cap(line.vertices)*pointerSize + cap(line.indices)*intSize
+	}
+	for key, value := range model.metadata {
+		usage.Metadata += len(key) + len(value)
+	}
+	for name, values := range model.vertexAttributes {
+		usage.VertexAttributes += len(name) + cap(values)*float64Size
+	}
+	return usage
+}
+
+// Preallocates the memory for the specified numbers of vertices and faces that will be added to the model,
+// so that importing a huge model does not grow the arrays repeatedly.
+// The vertices and faces are allocated in bulk: the next added vertices and faces are taken from the reserved arrays
+// instead of being allocated one by one, which also reduces the work of the garbage collector.
+// The numbers can be taken from the statistics of the parser, for example, from a counting pass over the file.
+// The reserved memory that is not used stays allocated while the model is alive.
+func (model *Model) Reserve(vertices, faces int) {
+	if spare := vertices - len(model.spareVertices); spare > 0 {
+		model.spareVertices = append(model.spareVertices, make([]Vertex, spare)...)
+	}
+	if spare := faces - len(model.spareFaces); spare > 0 {
+		model.spareFaces = append(model.spareFaces, make([]Face, spare)...)
+	}
+	if count := len(model.vertices) + vertices; count > cap(model.vertices) {
+		model.vertices = append(make([]*Vertex, 0, count), model.vertices...)
+	}
+	if count := len(model.faces) + faces; count > cap(model.faces) {
+		model.faces = append(make([]*Face, 0, count), model.faces...)
+	}
+}
+
+// Returns a new vertex of the model, taking it from the memory reserved by Model.Reserve if there is any.
+func (model *Model) newVertex(x, y, z float64) *Vertex {
+	if len(model.spareVertices) == 0 {
+		return NewVertex(x, y, z)
+	}
+	var v = &model.spareVertices[0]
+	model.spareVertices = model.spareVertices[1:]
+	v.X, v.Y, v.Z = x, y, z
+	return v
+}
+
+// Returns a new face of the model, taking it from the memory reserved by Model.Reserve if there is any.
+func (model *Model) newFace(vertex1, vertex2, vertex3 *Vertex) *Face {
+	if len(model.spareFaces) == 0 {
+		return newFace(vertex1, vertex2, vertex3)
+	}
+	var face = &model.spareFaces[0]
+	model.spareFaces = model.spareFaces[1:]
+	*face = Face{vertex1: vertex1, vertex2: vertex2, vertex3: vertex3}
+	return face
+}
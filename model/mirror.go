@@ -0,0 +1,232 @@
+package model
+
+import "math"
+
+// Describes a plane in three-dimensional space: the points p for which dot(Normal, p) equals Offset.
+// The Normal does not have to be a unit vector, but must not be zero.
+// The points for which dot(Normal, p) is greater than Offset lie on the positive side of the plane.
+type Plane struct {
+	Normal Vertex  // The normal to the plane directed to its positive side.
+	Offset float64 // The value of dot(Normal, p) for the points of the plane.
+}
+
+// Creates a Plane passing through the point with the specified normal.
+func NewPlane(point, normal Vertex) Plane {
+	return Plane{Normal: normal, Offset: dot(normal, point)}
+}
+
+// Returns the signed distance from the point to the plane, positive on the positive side of the plane.
+func (plane Plane) Distance(p Vertex) float64 {
+	return (dot(plane.Normal, p) - plane.Offset) / vectorLength(plane.Normal)
+}
+
+// Returns the point symmetrical to the specified one relative to the plane.
+func (plane Plane) Reflect(p Vertex) Vertex {
+	var n = scale(plane.Normal, 1/vectorLength(plane.Normal))
+	return sub(p, scale(n, 2*plane.Distance(p)))
+}
+
+// Returns the projection of the point onto the plane.
+func (plane Plane) Project(p Vertex) Vertex {
+	var n = scale(plane.Normal, 1/vectorLength(plane.Normal))
+	return sub(p, scale(n, plane.Distance(p)))
+}
+
+// Creates a copy of the model mirrored relative to the plane.
+// The order of the vertices of the faces is reversed, so that their normals still point outward.
+// The texture vertices, lines, points, sub-meshes, metadata and vertex attributes are copied unchanged.
+func (model *Model) Mirror(plane Plane) *Model {
+	var (
+		res     = model.copyWithoutGeometry()
+		mapping = make([]int, len(model.vertices))
+	)
+	for i, v := range model.vertices {
+		var reflected = plane.Reflect(*v)
+		res.vertices = append(res.vertices, &reflected)
+		mapping[i] = i
+	}
+	for name, values := range model.vertexAttributes {
+		res.vertexAttributes[name] = append([]float64(nil), values...)
+	}
+	for _, face := range model.faces {
+		res.appendMirroredFace(face, mapping)
+	}
+	for _, line := range model.lines {
+		res.appendMappedLine(line, mapping)
+	}
+	res.points = append(res.points, model.points...)
+	res.subMeshes = append(res.subMeshes, model.subMeshes...)
+	return res
+}
+
+// Creates a symmetrical copy of the model: the part of the model on the positive side of the plane
+// is mirrored to the negative side, the part on the negative side is removed.
+// It is a common cleanup of the models of which only a half was exported.
+//
+// The vertices closer to the plane than the tolerance form the seam: they are projected onto the plane
+// and shared by the both halves, so that the halves are welded.
+// The faces, lines and points are kept if all their vertices are on the positive side or on the seam,
+// the faces lying entirely on the seam are not mirrored.
+// The faces of the mirrored half follow the faces of the same sub-mesh of the original half.
+// The vertex attributes of the mirrored vertices are copied from the original ones.
+func (model *Model) Symmetrize(plane Plane, tolerance float64) *Model {
+	var (
+		res      = model.copyWithoutGeometry()
+		kept     = make([]int, len(model.vertices))  // The indices of the vertices of the original half in the result, -1 if removed.
+		mirrored = make([]int, len(model.vertices))  // The indices of the vertices of the mirrored half in the result.
+		seam     = make([]bool, len(model.vertices)) // true for the vertices on the seam.
+		sources  []int                               // The indices of the vertices of the model from which the vertices of the result were created.
+	)
+	for i, v := range model.vertices {
+		var distance = plane.Distance(*v)
+		switch {
+		case math.Abs(distance) <= tolerance:
+			var projected = plane.Project(*v)
+			seam[i] = true
+			kept[i] = len(res.vertices)
+			res.vertices = append(res.vertices, &projected)
+		case distance > 0:
+			var copied = *v
+			kept[i] = len(res.vertices)
+			res.vertices = append(res.vertices, &copied)
+		default:
+			kept[i] = -1
+			continue
+		}
+		sources = append(sources, i)
+	}
+	for i, v := range model.vertices {
+		switch {
+		case kept[i] < 0:
+			mirrored[i] = -1
+		case seam[i]:
+			mirrored[i] = kept[i]
+		default:
+			var reflected = plane.Reflect(*v)
+			mirrored[i] = len(res.vertices)
+			res.vertices = append(res.vertices, &reflected)
+			sources = append(sources, i)
+		}
+	}
+	for name, values := range model.vertexAttributes {
+		if len(values) != len(model.vertices) {
+			continue
+		}
+		var copied = make([]float64, len(sources))
+		for i, source := range sources {
+			copied[i] = values[source]
+		}
+		res.vertexAttributes[name] = copied
+	}
+	var (
+		// Reports whether all the vertices with the specified indices are kept and whether all of them are on the seam.
+		classify = func(indices []int) (keep, onSeam bool) {
+			onSeam = true
+			for _, index := range indices {
+				if kept[index] < 0 {
+					return false, false
+				}
+				onSeam = onSeam && seam[index]
+			}
+			return true, onSeam
+		}
+		ranges = model.SubMeshes()
+	)
+	if ranges == nil {
+		ranges = []SubMesh{{FirstFace: 0, FacesCount: len(model.faces)}}
+	}
+	for _, subMesh := range ranges {
+		var (
+			first = len(res.faces)
+			faces = model.faces[subMesh.FirstFace : subMesh.FirstFace+subMesh.FacesCount]
+		)
+		for _, face := range faces {
+			if keep, _ := classify(face.indices[:]); keep {
+				res.appendMappedFace(face, kept)
+			}
+		}
+		for _, face := range faces {
+			if keep, onSeam := classify(face.indices[:]); keep && !onSeam {
+				res.appendMirroredFace(face, mirrored)
+			}
+		}
+		if model.subMeshes != nil && len(res.faces) > first {
+			subMesh.FirstFace = first
+			subMesh.FacesCount = 0
+			res.subMeshes = append(res.subMeshes, subMesh)
+		}
+	}
+	for _, line := range model.lines {
+		if keep, onSeam := classify(line.indices); keep {
+			res.appendMappedLine(line, kept)
+			if !onSeam {
+				res.appendMappedLine(line, mirrored)
+			}
+		}
+	}
+	for _, point := range model.points {
+		if kept[point] >= 0 {
+			res.points = append(res.points, kept[point])
+			if !seam[point] {
+				res.points = append(res.points, mirrored[point])
+			}
+		}
+	}
+	return res
+}
+
+// Creates a new model with the texture vertices, parameter space vertices, metadata and the state of the faces
+// being added copied from the model, but without vertices, faces, lines, points, sub-meshes and vertex attributes.
+func (model *Model) copyWithoutGeometry() *Model {
+	var res = NewModel()
+	res.textureVertices = append(res.textureVertices, model.textureVertices...)
+	res.paramVertices = append(res.paramVertices, model.paramVertices...)
+	res.smoothingGroup = model.smoothingGroup
+	res.material = model.material
+	res.materialNumber = model.materialNumber
+	res.materials = append(res.materials, model.materials...)
+	for key, value := range model.metadata {
+		res.metadata[key] = value
+	}
+	res.vertexAttributes = make(map[string][]float64)
+	return res
+}
+
+// Adds a copy of the face of another model with the indices of the vertices replaced by the mapping.
+func (model *Model) appendMappedFace(face *Face, mapping []int) {
+	model.appendFaceWithIndices(face, [3]int{mapping[face.indices[0]], mapping[face.indices[1]], mapping[face.indices[2]]}, [3]int{0, 1, 2})
+}
+
+// Adds a copy of the face of another model with the indices of the vertices replaced by the mapping
+// and the reversed order of the vertices.
+func (model *Model) appendMirroredFace(face *Face, mapping []int) {
+	model.appendFaceWithIndices(face, [3]int{mapping[face.indices[0]], mapping[face.indices[2]], mapping[face.indices[1]]}, [3]int{0, 2, 1})
+}
+
+// Adds a face with the smoothing group and the material of the specified face and the specified indices of the vertices.
+// The corners are the positions of the corners of the specified face in the order of the vertices of the new face,
+// the texture vertices of the corners are taken from them.
+func (model *Model) appendFaceWithIndices(face *Face, indices [3]int, corners [3]int) {
+	var res = model.newFace(model.vertices[indices[0]], model.vertices[indices[1]], model.vertices[indices[2]])
+	res.indices = indices
+	res.smoothingGroup = face.smoothingGroup
+	res.material = face.material
+	res.materialNumber = face.materialNumber
+	for i, corner := range corners {
+		res.textureNumbers[i] = face.textureNumbers[corner]
+	}
+	model.faces = append(model.faces, res)
+}
+
+// Adds a copy of the line of another model with the indices of the vertices replaced by the mapping.
+func (model *Model) appendMappedLine(line *Line, mapping []int) {
+	var res = &Line{
+		vertices: make([]*Vertex, len(line.indices)),
+		indices:  make([]int, len(line.indices)),
+	}
+	for i, index := range line.indices {
+		res.indices[i] = mapping[index]
+		res.vertices[i] = model.vertices[mapping[index]]
+	}
+	model.lines = append(model.lines, res)
+}
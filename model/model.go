@@ -1,7 +1,6 @@
 package model
 
 import (
-	"errors"
 	"fmt"
 	"math"
 )
@@ -17,10 +16,27 @@ func NewVertex(x, y, z float64) *Vertex {
 	return &Vertex{X: x, Y: y, Z: z}
 }
 
+// Describes a vertex of the texture.
+// Contains three texture coordinates: U, V, W.
+type TextureVertex struct {
+	U, V, W float64
+}
+
+// Describes a point in the parameter space of a curve or surface.
+// Contains the coordinates U, V and the weight W.
+type ParameterVertex struct {
+	U, V, W float64
+}
+
 // Describes a triangle in three-dimensional space.
 // Contains three vertices of the triangle.
 type Face struct {
 	vertex1, vertex2, vertex3 *Vertex
+	indices                   [3]int // The indices of the vertices in the model starting from 0.
+	smoothingGroup            int    // The number of the smoothing group of the face, 0 if the smoothing is turned off.
+	material                  string // The name of the material of the face, empty if the material is not specified.
+	materialNumber            int    // The index of the material of the face in Model.Materials plus 1, 0 if it is not specified.
+	textureNumbers            [3]int // The indices of the texture vertices of the corners plus 1, zeros if there are none.
 }
 
 // Returns the first vertex of the triangle.
@@ -38,6 +54,36 @@ func (f *Face) Vertex3() Vertex {
 	return *f.vertex3
 }
 
+// Returns the indices of the three vertices of the triangle in the model.
+// Unlike the indices passed to Model.AppendFace, these indices start from 0 and are never negative.
+func (f *Face) Indices() (int, int, int) {
+	return f.indices[0], f.indices[1], f.indices[2]
+}
+
+// Returns the indices of the texture vertices of the three corners of the triangle in the model starting from 0,
+// -1 if the face has no texture vertices, see Model.AppendTexturedFace.
+func (f *Face) TextureIndices() (int, int, int) {
+	return f.textureNumbers[0] - 1, f.textureNumbers[1] - 1, f.textureNumbers[2] - 1
+}
+
+// Returns the number of the smoothing group to which the face belongs, 0 if the smoothing is turned off.
+// The normals of the faces from different smoothing groups should not be averaged at the common vertices.
+func (f *Face) SmoothingGroup() int {
+	return f.smoothingGroup
+}
+
+// Returns the name of the material applied to the face, empty if the material is not specified.
+// The materials are defined in the files listed in the MaterialLibrariesKey of the metadata.
+func (f *Face) Material() string {
+	return f.material
+}
+
+// Returns the index of the material applied to the face in the Model.Materials, -1 if the material is not specified,
+// so that the renderers can look up the properties of the materials in a slice instead of a map.
+func (f *Face) MaterialIndex() int {
+	return f.materialNumber - 1
+}
+
 // Calculates the normal to the surface of the triangle.
 func (f *Face) Normal() (float64, float64, float64) {
 	var (
@@ -51,6 +97,19 @@ func (f *Face) Normal() (float64, float64, float64) {
 	return x, y, z
 }
 
+// Returns an error wrapping the ErrDegenerateFace if the triangle has no area:
+// it refers to the same vertex several times or its vertices lie on a line. Returns nil otherwise.
+// The Model accepts such faces, so that the models are imported as they are, but they have no normal.
+func (f *Face) Check() error {
+	if f.indices[0] == f.indices[1] || f.indices[1] == f.indices[2] || f.indices[2] == f.indices[0] {
+		return fmt.Errorf("%w: the face refers to the same vertex several times", ErrDegenerateFace)
+	}
+	if x, y, z := f.Normal(); x == 0 && y == 0 && z == 0 {
+		return fmt.Errorf("%w: the vertices of the face lie on a line", ErrDegenerateFace)
+	}
+	return nil
+}
+
 // Creates a Face based on its three vertices.
 func newFace(vertex1, vertex2, vertex3 *Vertex) *Face {
 	return &Face{
@@ -60,43 +119,114 @@ func newFace(vertex1, vertex2, vertex3 *Vertex) *Face {
 	}
 }
 
+// Describes a broken line in three-dimensional space.
+// Contains the vertices of the line in the order in which they are connected.
+type Line struct {
+	vertices []*Vertex // The vertices of the line.
+	indices  []int     // The indices of the vertices in the model starting from 0.
+}
+
+// Returns the number of vertices of the line.
+func (l *Line) VerticesCount() int {
+	return len(l.vertices)
+}
+
+// Returns the vertex of the line by its index starting from 0.
+func (l *Line) Vertex(index int) Vertex {
+	return *l.vertices[index]
+}
+
+// Returns the index of the vertex of the line in the model.
+// Unlike the indices passed to Model.AppendLine, these indices start from 0 and are never negative.
+func (l *Line) Index(index int) int {
+	return l.indices[index]
+}
+
+// Well-known keys of the Metadata.
+const (
+	NameKey                  = "name"                   // The name of the model, for example, the name of the object in the .obj file.
+	SourceKey                = "source"                 // The path to the file from which the model was imported.
+	UnitsKey                 = "units"                  // The units in which the coordinates of the vertices are specified.
+	HeaderKey                = "header"                 // The comment lines at the beginning of the file from which the model was imported, separated by '\n'.
+	MaterialLibrariesKey     = "material_libraries"     // The names of the files with the materials of the faces, separated by '\n'.
+	LevelOfDetailKey         = "level_of_detail"        // The level of detail to be displayed, specified by the last lod statement.
+	BevelInterpolationKey    = "bevel_interpolation"    // Whether the bevel interpolation is on or off, specified by the last bevel statement.
+	ColorInterpolationKey    = "color_interpolation"    // Whether the color interpolation is on or off, specified by the last c_interp statement.
+	DissolveInterpolationKey = "dissolve_interpolation" // Whether the dissolve interpolation is on or off, specified by the last d_interp statement.
+)
+
+// Dictionary of non-geometric information about the model.
+// Contains the values of the well-known keys and any custom key/value pairs.
+type Metadata map[string]string
+
 // Describes a complete three-dimensional model.
 type Model struct {
-	vertices []*Vertex // A list of all the vertices of the model.
-	faces    []*Face   // A list of all the faces of the model.
+	vertices         []*Vertex            // A list of all the vertices of the model.
+	textureVertices  []TextureVertex      // A list of all the texture vertices of the model.
+	paramVertices    []ParameterVertex    // A list of all the parameter space vertices of the model.
+	faces            []*Face              // A list of all the faces of the model.
+	lines            []*Line              // A list of all the lines of the model.
+	points           []int                // The indices of the vertices that are the points of the model.
+	subMeshes        []SubMesh            // The named parts of the model, see Model.StartSubMesh.
+	smoothingGroup   int                  // The smoothing group of the faces being added, see Model.SetSmoothingGroup.
+	material         string               // The material of the faces being added, see Model.SetMaterial.
+	materialNumber   int                  // The index of the material of the faces being added plus 1, 0 if there is none.
+	materials        []string             // The names of the materials of the faces, see Model.Materials.
+	metadata         Metadata             // Non-geometric information about the model.
+	vertexAttributes map[string][]float64 // Named values of the vertices, see Model.SetVertexAttribute.
+	spareVertices    []Vertex             // The memory reserved for the vertices being added, see Model.Reserve.
+	spareFaces       []Face               // The memory reserved for the faces being added, see Model.Reserve.
+	normals          [][3]Vertex          // The normals of the corners of the faces, see Model.GenerateNormals.
 }
 
 // Returns a pointer to a vertex by its index and an error if the index is specified incorrectly.
 // Supports negative indexing, the index of the first vertex is 1.
 func (model *Model) vertexByIndex(index int) (*Vertex, error) {
+	var i, err = model.resolveIndex(index)
+	if err != nil {
+		return nil, err
+	}
+	return model.vertices[i], nil
+}
+
+// Converts the index of the vertex to the index in the model.vertices and returns an error if the index is specified incorrectly.
+// Supports negative indexing, the index of the first vertex is 1.
+func (model *Model) resolveIndex(index int) (int, error) {
 	var verticesCount = len(model.vertices)
-	if index > 0 {
-		if index <= verticesCount {
-			return model.vertices[index-1], nil
-		} else {
-			return nil, fmt.Errorf("unresolved vertex index: %d", index)
-		}
-	} else if index < 0 {
-		if -index <= verticesCount {
-			return model.vertices[verticesCount+index], nil
-		} else {
-			return nil, fmt.Errorf("unresolved vertex index: %d", index)
-		}
-	} else {
-		return nil, errors.New("vertex index cannot be zero")
+	if index > 0 && index <= verticesCount {
+		return index - 1, nil
 	}
+	if index < 0 && -index <= verticesCount {
+		return verticesCount + index, nil
+	}
+	return 0, indexError("vertex", index)
 }
 
 // Adds a vertex to the model based on its three coordinates.
 func (model *Model) AppendVertex(x, y, z float64) {
-	model.vertices = append(model.vertices, NewVertex(x, y, z))
+	model.vertices = append(model.vertices, model.newVertex(x, y, z))
 }
 
 // Returns the vertex of the model by index and an error if the index is specified incorrectly.
 // Supports negative indexing, the index of the first vertex is 1.
 func (model *Model) GetVertex(index int) (Vertex, error) {
 	var v, err = model.vertexByIndex(index)
-	return *v, err
+	if err != nil {
+		return Vertex{}, err
+	}
+	return *v, nil
+}
+
+// Changes the coordinates of the vertex of the model by index and returns an error if the index is specified incorrectly.
+// Supports negative indexing, the index of the first vertex is 1.
+// The faces, lines and points of the model share the vertex, so they are changed too.
+func (model *Model) SetVertex(index int, x, y, z float64) error {
+	var v, err = model.vertexByIndex(index)
+	if err != nil {
+		return err
+	}
+	v.X, v.Y, v.Z = x, y, z
+	return nil
 }
 
 // Returns the number of model vertices.
@@ -104,27 +234,151 @@ func (model *Model) VerticesCount() int {
 	return len(model.vertices)
 }
 
+// Adds a texture vertex to the model based on its three texture coordinates.
+func (model *Model) AppendTextureVertex(u, v, w float64) {
+	model.textureVertices = append(model.textureVertices, TextureVertex{U: u, V: v, W: w})
+}
+
+// Returns the texture vertex of the model by index and an error if the index is specified incorrectly.
+// Supports negative indexing, the index of the first texture vertex is 1.
+func (model *Model) GetTextureVertex(index int) (TextureVertex, error) {
+	var i, err = model.resolveTextureIndex(index)
+	if err != nil {
+		return TextureVertex{}, err
+	}
+	return model.textureVertices[i], nil
+}
+
+// Converts the index of the texture vertex to the index in the model.textureVertices
+// and returns an error if the index is specified incorrectly.
+// Supports negative indexing, the index of the first texture vertex is 1.
+func (model *Model) resolveTextureIndex(index int) (int, error) {
+	var count = len(model.textureVertices)
+	if index > 0 && index <= count {
+		return index - 1, nil
+	}
+	if index < 0 && -index <= count {
+		return count + index, nil
+	}
+	return 0, indexError("texture vertex", index)
+}
+
+// Returns the number of model texture vertices.
+func (model *Model) TextureVerticesCount() int {
+	return len(model.textureVertices)
+}
+
+// Adds a parameter space vertex to the model.
+func (model *Model) AppendParameterVertex(u, v, w float64) {
+	model.paramVertices = append(model.paramVertices, ParameterVertex{U: u, V: v, W: w})
+}
+
+// Returns the parameter space vertex of the model by index and an error if the index is specified incorrectly.
+// Supports negative indexing, the index of the first parameter space vertex is 1.
+func (model *Model) GetParameterVertex(index int) (ParameterVertex, error) {
+	var count = len(model.paramVertices)
+	if index > 0 && index <= count {
+		return model.paramVertices[index-1], nil
+	}
+	if index < 0 && -index <= count {
+		return model.paramVertices[count+index], nil
+	}
+	return ParameterVertex{}, indexError("parameter space vertex", index)
+}
+
+// Returns the number of model parameter space vertices.
+func (model *Model) ParameterVerticesCount() int {
+	return len(model.paramVertices)
+}
+
 // Adds a face to the model based on its three vertices.
 func (model *Model) AppendFace(v1, v2, v3 int) error {
 	var (
 		err     error
-		vertex1 *Vertex
-		vertex2 *Vertex
-		vertex3 *Vertex
+		indices [3]int
 	)
-	if vertex1, err = model.vertexByIndex(v1); err != nil {
-		return err
+	for i, index := range [...]int{v1, v2, v3} {
+		if indices[i], err = model.resolveIndex(index); err != nil {
+			return err
+		}
 	}
-	if vertex2, err = model.vertexByIndex(v2); err != nil {
-		return err
+	var face = model.newFace(model.vertices[indices[0]], model.vertices[indices[1]], model.vertices[indices[2]])
+	face.indices = indices
+	face.smoothingGroup = model.smoothingGroup
+	face.material = model.material
+	face.materialNumber = model.materialNumber
+	model.faces = append(model.faces, face)
+	return nil
+}
+
+// Adds a face to the model based on its three vertices and the texture vertices of its corners.
+// Supports negative indexing of both the vertices and the texture vertices, the index of the first one is 1.
+// The face is not added if some of the indices are specified incorrectly.
+func (model *Model) AppendTexturedFace(v1, v2, v3, t1, t2, t3 int) error {
+	var (
+		err      error
+		textures [3]int
+	)
+	for i, index := range [...]int{t1, t2, t3} {
+		if textures[i], err = model.resolveTextureIndex(index); err != nil {
+			return err
+		}
 	}
-	if vertex3, err = model.vertexByIndex(v3); err != nil {
+	if err = model.AppendFace(v1, v2, v3); err != nil {
 		return err
 	}
-	model.faces = append(model.faces, newFace(vertex1, vertex2, vertex3))
+	var face = model.faces[len(model.faces)-1]
+	for i, texture := range textures {
+		face.textureNumbers[i] = texture + 1
+	}
 	return nil
 }
 
+// Returns the texture vertices of the corners of the face with the specified index in the order of its vertices
+// and true if the face has texture vertices, see Model.AppendTexturedFace.
+func (model *Model) FaceTextureVertices(index int) ([3]TextureVertex, bool) {
+	var (
+		face     = model.faces[index]
+		vertices [3]TextureVertex
+	)
+	if face.textureNumbers[0] == 0 {
+		return vertices, false
+	}
+	for i, number := range face.textureNumbers {
+		vertices[i] = model.textureVertices[number-1]
+	}
+	return vertices, true
+}
+
+// Sets the smoothing group of the faces that will be added after that, 0 turns off the smoothing.
+func (model *Model) SetSmoothingGroup(group int) {
+	model.smoothingGroup = group
+}
+
+// Sets the name of the material of the faces that will be added after that.
+// The name is added to the Model.Materials if it is not empty and is set for the first time.
+func (model *Model) SetMaterial(name string) {
+	model.material = name
+	model.materialNumber = 0
+	if name == "" {
+		return
+	}
+	for i, material := range model.materials {
+		if material == name {
+			model.materialNumber = i + 1
+			return
+		}
+	}
+	model.materials = append(model.materials, name)
+	model.materialNumber = len(model.materials)
+}
+
+// Returns the names of the materials of the faces in the order in which they were first set by Model.SetMaterial,
+// the faces refer to them by Face.MaterialIndex. The returned slice must not be changed.
+func (model *Model) Materials() []string {
+	return model.materials
+}
+
 // Returns the vertex of the model by index.
 func (model *Model) GetFace(index int) *Face {
 	return model.faces[index]
@@ -135,6 +389,87 @@ func (model *Model) FacesCount() int {
 	return len(model.faces)
 }
 
+// Adds a line to the model based on its vertices.
+// The line must contain at least two vertices.
+func (model *Model) AppendLine(vertices ...int) error {
+	if len(vertices) < 2 {
+		return fmt.Errorf("%w: line must contain at least two vertices", ErrDegenerateLine)
+	}
+	var line = &Line{
+		vertices: make([]*Vertex, len(vertices)),
+		indices:  make([]int, len(vertices)),
+	}
+	for i, index := range vertices {
+		var j, err = model.resolveIndex(index)
+		if err != nil {
+			return err
+		}
+		line.vertices[i] = model.vertices[j]
+		line.indices[i] = j
+	}
+	model.lines = append(model.lines, line)
+	return nil
+}
+
+// Returns the line of the model by index.
+func (model *Model) GetLine(index int) *Line {
+	return model.lines[index]
+}
+
+// Returns the number of model lines.
+func (model *Model) LinesCount() int {
+	return len(model.lines)
+}
+
+// Adds a point to the model based on its vertex.
+// Supports negative indexing, the index of the first vertex is 1.
+func (model *Model) AppendPoint(vertex int) error {
+	var index, err = model.resolveIndex(vertex)
+	if err != nil {
+		return err
+	}
+	model.points = append(model.points, index)
+	return nil
+}
+
+// Returns the vertex of the point of the model by index.
+func (model *Model) GetPoint(index int) Vertex {
+	return *model.vertices[model.points[index]]
+}
+
+// Returns the index of the vertex of the point of the model in the model starting from 0.
+func (model *Model) PointIndex(index int) int {
+	return model.points[index]
+}
+
+// Returns the number of model points.
+func (model *Model) PointsCount() int {
+	return len(model.points)
+}
+
+// Returns the opposite corners of the axis-aligned box containing all vertices of the model.
+// Returns two zero vertices if the model has no vertices.
+func (model *Model) Bounds() (min, max Vertex) {
+	if len(model.vertices) == 0 {
+		return Vertex{}, Vertex{}
+	}
+	min, max = *model.vertices[0], *model.vertices[0]
+	for _, v := range model.vertices[1:] {
+		min = Vertex{X: math.Min(min.X, v.X), Y: math.Min(min.Y, v.Y), Z: math.Min(min.Z, v.Z)}
+		max = Vertex{X: math.Max(max.X, v.X), Y: math.Max(max.Y, v.Y), Z: math.Max(max.Z, v.Z)}
+	}
+	return min, max
+}
+
+// Returns the metadata of the model.
+// The returned dictionary can be modified to change the metadata.
+func (model *Model) Metadata() Metadata {
+	if model.metadata == nil {
+		model.metadata = make(Metadata)
+	}
+	return model.metadata
+}
+
 // Performs the transformation of each vertex of the model specified by the transformation function.
 func (model *Model) Transform(transformation func(x, y, z float64) (float64, float64, float64)) {
 	var (
@@ -180,5 +515,6 @@ func NewModel() *Model {
 	return &Model{
 		vertices: make([]*Vertex, 0, 10),
 		faces:    make([]*Face, 0, 10),
+		metadata: make(Metadata),
 	}
 }
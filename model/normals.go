@@ -0,0 +1,120 @@
+package model
+
+import "math"
+
+// A vertex shared by the faces of a smoothing group.
+type smoothedVertex struct {
+	index int // The index of the vertex in the model.
+	group int // The number of the smoothing group.
+}
+
+// Calculates the unit normals of the corners of the faces and stores them in the model, see Model.FaceNormals.
+// The normals follow the smoothing groups of the faces, so the model is shaded as intended by the authoring tools:
+// the corners of the faces of the same smoothing group at the same vertex get the same normal,
+// the sum of the normals of these faces, so that the larger faces have more influence and the group looks smooth;
+// the faces with the smoothing turned off get the normal of the face at all corners, so that they look flat.
+// The faces of different smoothing groups do not affect the normals of each other, so there is a crease between them.
+//
+// The normals are not changed by the transformations of the model, they must be generated again after them.
+func (model *Model) GenerateNormals() {
+	var sums = make(map[smoothedVertex]Vertex)
+	for _, face := range model.faces {
+		if face.smoothingGroup == 0 {
+			continue
+		}
+		var x, y, z = face.Normal()
+		for _, index := range face.indices {
+			var (
+				key = smoothedVertex{index: index, group: face.smoothingGroup}
+				sum = sums[key]
+			)
+			sums[key] = Vertex{X: sum.X + x, Y: sum.Y + y, Z: sum.Z + z}
+		}
+	}
+	model.resetNormals()
+	for i, face := range model.faces {
+		if face.smoothingGroup == 0 {
+			var x, y, z = face.Normal()
+			var normal = unit(Vertex{X: x, Y: y, Z: z})
+			model.normals[i] = [3]Vertex{normal, normal, normal}
+			continue
+		}
+		for j, index := range face.indices {
+			model.normals[i][j] = unit(sums[smoothedVertex{index: index, group: face.smoothingGroup}])
+		}
+	}
+}
+
+// Calculates the normals of the corners of the faces like Model.GenerateNormals, but all faces look flat:
+// each corner gets the unit normal of its face regardless of the smoothing groups.
+func (model *Model) GenerateFlatNormals() {
+	model.resetNormals()
+	for i, face := range model.faces {
+		var x, y, z = face.Normal()
+		var normal = unit(Vertex{X: x, Y: y, Z: z})
+		model.normals[i] = [3]Vertex{normal, normal, normal}
+	}
+}
+
+// Calculates the normals of the corners of the faces like Model.GenerateNormals, but ignores the smoothing groups
+// and smooths the surface by the angles between the faces instead, for the files without the smoothing groups:
+// the normal of a corner is the sum of the normals of the faces at the same vertex, including its own face,
+// whose angle with its face is at most the threshold in radians. So the edges sharper than the threshold
+// have a crease, and the smooth surfaces look smooth.
+func (model *Model) GenerateNormalsByAngle(threshold float64) {
+	var (
+		normals     = make([]Vertex, len(model.faces))
+		vertexFaces = make([][]int, len(model.vertices)) // The indices of the faces at each vertex.
+		cosine      = math.Cos(threshold)
+	)
+	for i, face := range model.faces {
+		var x, y, z = face.Normal()
+		normals[i] = Vertex{X: x, Y: y, Z: z}
+		for _, index := range face.indices {
+			vertexFaces[index] = append(vertexFaces[index], i)
+		}
+	}
+	model.resetNormals()
+	for i, face := range model.faces {
+		var own = unit(normals[i])
+		for j, index := range face.indices {
+			var sum Vertex
+			for _, other := range vertexFaces[index] {
+				var n = normals[other]
+				// The cosine of the angle between the faces is compared without normalizing the normal of the other face.
+				if other != i && own.X*n.X+own.Y*n.Y+own.Z*n.Z < cosine*math.Sqrt(n.X*n.X+n.Y*n.Y+n.Z*n.Z) {
+					continue
+				}
+				sum = Vertex{X: sum.X + n.X, Y: sum.Y + n.Y, Z: sum.Z + n.Z}
+			}
+			model.normals[i][j] = unit(sum)
+		}
+	}
+}
+
+// Resizes the normals of the corners of the faces to the number of the faces reusing their memory.
+func (model *Model) resetNormals() {
+	if cap(model.normals) < len(model.faces) {
+		model.normals = make([][3]Vertex, len(model.faces))
+	}
+	model.normals = model.normals[:len(model.faces)]
+}
+
+// Returns the unit normals of the corners of the face with the specified index in the order of its vertices
+// and true if the normals were generated by Model.GenerateNormals.
+// The normals become unavailable if the number of faces has changed since they were generated.
+func (model *Model) FaceNormals(index int) ([3]Vertex, bool) {
+	if len(model.normals) != len(model.faces) {
+		return [3]Vertex{}, false
+	}
+	return model.normals[index], true
+}
+
+// Returns the vector of unit length with the same direction, the zero vector is returned unchanged.
+func unit(v Vertex) Vertex {
+	var length = math.Sqrt(v.X*v.X + v.Y*v.Y + v.Z*v.Z)
+	if length == 0 {
+		return v
+	}
+	return Vertex{X: v.X / length, Y: v.Y / length, Z: v.Z / length}
+}
@@ -0,0 +1,123 @@
+package model
+
+import "strings"
+
+// A separate part of a Scene: the faces of one sub-mesh of a model with the vertices they use.
+type Mesh struct {
+	Object string   // The name of the object, empty if the faces do not belong to any object.
+	Groups []string // The names of the groups, empty if the faces do not belong to any group.
+	Model  *Model   // The faces of the part with their vertices.
+}
+
+// Returns the name of the mesh: the name of the object or, if it is empty, the names of the groups separated by spaces.
+func (mesh *Mesh) Name() string {
+	if mesh.Object != "" {
+		return mesh.Object
+	}
+	return strings.Join(mesh.Groups, " ")
+}
+
+// A model divided into the separate meshes of its objects and groups,
+// for example, to draw or transform the parts of the model independently.
+type Scene struct {
+	Meshes   []*Mesh  // The meshes in the order of the sub-meshes of the model.
+	Metadata Metadata // The metadata of the whole model.
+}
+
+// Returns the meshes with the object or one of the groups named name in the order of the meshes.
+func (scene *Scene) Find(name string) []*Mesh {
+	var meshes []*Mesh
+	for _, mesh := range scene.Meshes {
+		if subMeshNamed(mesh.Object, mesh.Groups, name) {
+			meshes = append(meshes, mesh)
+		}
+	}
+	return meshes
+}
+
+// Reports whether the object or one of the groups has the name.
+func subMeshNamed(object string, groups []string, name string) bool {
+	if object == name {
+		return true
+	}
+	for _, group := range groups {
+		if group == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Divides the model into the Scene with a separate mesh for each non-empty sub-mesh, see Model.SubMeshes.
+// The model without sub-meshes gives the Scene with a single unnamed mesh if it has faces.
+//
+// Each mesh has a copy of the vertices used by its faces in the order of the vertices of the model
+// with the values of the vertex attributes, the faces keep their smoothing groups and materials,
+// the indices of the materials are the same in all meshes. The texture vertices, the parameter space vertices
+// and the metadata are copied to each mesh, the name of the mesh is stored by the NameKey if it is not empty.
+// The lines and the points do not belong to the sub-meshes, so they are not copied.
+func (model *Model) Split() *Scene {
+	var scene = &Scene{Metadata: make(Metadata, len(model.metadata))}
+	for key, value := range model.metadata {
+		scene.Metadata[key] = value
+	}
+	var ranges = model.SubMeshes()
+	if ranges == nil {
+		ranges = []SubMesh{{FirstFace: 0, FacesCount: len(model.faces)}}
+	}
+	for _, subMesh := range ranges {
+		if subMesh.FacesCount == 0 {
+			continue
+		}
+		var mesh = &Mesh{
+			Object: subMesh.Object,
+			Groups: subMesh.Groups,
+			Model:  model.subMeshCopy(model.faces[subMesh.FirstFace : subMesh.FirstFace+subMesh.FacesCount]),
+		}
+		if name := mesh.Name(); name != "" {
+			mesh.Model.metadata[NameKey] = name
+		}
+		scene.Meshes = append(scene.Meshes, mesh)
+	}
+	return scene
+}
+
+// Creates a new model with the faces and the vertices used by them, see Model.Split.
+func (model *Model) subMeshCopy(faces []*Face) *Model {
+	var (
+		res     = model.copyWithoutGeometry()
+		mapping = make([]int, len(model.vertices)) // The indices of the vertices in the result plus 1, 0 if not used.
+		sources []int                              // The indices of the vertices of the model in the order of the result.
+	)
+	for _, face := range faces {
+		for _, index := range face.indices {
+			mapping[index] = 1
+		}
+	}
+	for i, v := range model.vertices {
+		if mapping[i] == 0 {
+			continue
+		}
+		var copied = *v
+		res.vertices = append(res.vertices, &copied)
+		sources = append(sources, i)
+		mapping[i] = len(res.vertices)
+	}
+	for i := range mapping {
+		mapping[i]--
+	}
+	for _, face := range faces {
+		res.appendMappedFace(face, mapping)
+	}
+	for name, values := range model.vertexAttributes {
+		if len(values) != len(model.vertices) {
+			continue
+		}
+		var copied = make([]float64, len(sources))
+		for i, source := range sources {
+			copied[i] = values[source]
+		}
+		res.vertexAttributes[name] = copied
+	}
+	return res
+}
@@ -0,0 +1,456 @@
+package model
+
+import "math"
+
+// Finds the point of a set closest to the specified one.
+// Implemented by the KDTree and the SpatialHash, see Model.PointLocator.
+type PointLocator interface {
+	// Returns the index of the point closest to p and the squared distance to it.
+	// Returns -1 and the positive infinity if there are no points.
+	Nearest(p Vertex) (int, float64)
+}
+
+// The ratio of the standard deviation of the lengths of the edges of the faces to their mean
+// below which the faces are considered uniformly sized, so that the Model.PointLocator chooses the SpatialHash.
+const uniformEdgeVariation = 0.5
+
+// The ratio of the default size of the cells of the SpatialHash of a model to the mean length of the edges of its faces.
+// The cells slightly larger than the faces contain a few vertices each and keep the number of the searched cells small.
+const cellEdgeRatio = 1.5
+
+// The integer coordinates of a cell of the SpatialHash.
+type cell [3]int
+
+// The range of the non-empty cells of the SpatialHash.
+type cellRange struct {
+	min, max cell // The cells with the smallest and the largest coordinates, inclusive.
+	empty    bool // true if there are no non-empty cells.
+}
+
+// Extends the range to contain the cell.
+func (r *cellRange) add(c cell) {
+	if r.empty {
+		r.min, r.max, r.empty = c, c, false
+		return
+	}
+	for axis := 0; axis < 3; axis++ {
+		r.min[axis] = minInt(r.min[axis], c[axis])
+		r.max[axis] = maxInt(r.max[axis], c[axis])
+	}
+}
+
+// The indices of the points or the triangles of the SpatialHash lying in the cells, stored in a table of buckets
+// addressed by the hashes of the cells, so that the memory does not depend on the number of the empty cells.
+// The cells with the same hash share the bucket, so the bucket of a cell can also contain the indices of other cells.
+type buckets struct {
+	starts  []int // The start of each bucket in the indices, the last element is the number of the indices.
+	indices []int // The indices of all the buckets one after another.
+	mask    int   // The number of the buckets minus one, the number of the buckets is a power of two.
+}
+
+// Creates the buckets containing each index in the bucket of the corresponding cell.
+// The number of the buckets is the smallest power of two not less than twice the number of the indices.
+func newBuckets(cells []cell, indices []int) buckets {
+	var size = 1
+	for size < 2*len(indices) {
+		size *= 2
+	}
+	var b = buckets{starts: make([]int, size+1), indices: make([]int, len(indices)), mask: size - 1}
+	for _, c := range cells {
+		b.starts[b.bucket(c)+1]++
+	}
+	for i := 1; i <= size; i++ {
+		b.starts[i] += b.starts[i-1]
+	}
+	var next = append([]int(nil), b.starts[:size]...)
+	for i, c := range cells {
+		var bucket = b.bucket(c)
+		b.indices[next[bucket]] = indices[i]
+		next[bucket]++
+	}
+	return b
+}
+
+// Returns the number of the bucket of the cell.
+func (b *buckets) bucket(c cell) int {
+	return int(uint(c[0]*73856093^c[1]*19349663^c[2]*83492791)) & b.mask
+}
+
+// Returns the indices of the bucket of the cell.
+func (b *buckets) get(c cell) []int {
+	var bucket = b.bucket(c)
+	return b.indices[b.starts[bucket]:b.starts[bucket+1]]
+}
+
+// A uniform grid of cubic cells over a set of points and triangles, each cell lists the points and the triangles
+// overlapping it. It is a lighter alternative to the hierarchical structures like the KDTree for dense,
+// uniformly-sized triangles, whose vertices are spread evenly over the cells.
+// Finds the point closest to the specified one, see Nearest, and the triangle hit by a ray, see Pick.
+type SpatialHash struct {
+	cellSize      float64     // The length of the edge of the cells.
+	points        []Vertex    // The points of the hash.
+	triangles     [][3]Vertex // The triangles of the hash.
+	pointCells    buckets     // The indices of the points lying in the cells.
+	triangleCells buckets     // The indices of the triangles whose bounding boxes overlap the cells.
+	pointRange    cellRange   // The range of the cells containing points.
+	triangleRange cellRange   // The range of the cells overlapped by triangles.
+}
+
+// Creates a new SpatialHash over the points and the triangles with the specified length of the edge of the cells.
+// The cell size should be close to the typical length of the edges of the triangles,
+// if it is not positive, it is calculated from the volume of the bounding box of the points.
+// The points and the triangles are copied, so changing them after that does not affect the hash.
+func NewSpatialHash(points []Vertex, triangles [][3]Vertex, cellSize float64) *SpatialHash {
+	if cellSize <= 0 || math.IsInf(cellSize, 0) || math.IsNaN(cellSize) {
+		cellSize = defaultCellSize(points)
+	}
+	var hash = &SpatialHash{
+		cellSize:      cellSize,
+		points:        append([]Vertex(nil), points...),
+		triangles:     append([][3]Vertex(nil), triangles...),
+		pointRange:    cellRange{empty: true},
+		triangleRange: cellRange{empty: true},
+	}
+	var (
+		cells   = make([]cell, len(hash.points))
+		indices = make([]int, len(hash.points))
+	)
+	for i := range hash.points {
+		cells[i], indices[i] = hash.cellOf(hash.points[i]), i
+		hash.pointRange.add(cells[i])
+	}
+	hash.pointCells = newBuckets(cells, indices)
+	cells, indices = cells[:0], indices[:0]
+	for i := range hash.triangles {
+		var t = &hash.triangles[i]
+		var (
+			from = hash.cellOf(Vertex{
+				X: math.Min(t[0].X, math.Min(t[1].X, t[2].X)),
+				Y: math.Min(t[0].Y, math.Min(t[1].Y, t[2].Y)),
+				Z: math.Min(t[0].Z, math.Min(t[1].Z, t[2].Z)),
+			})
+			to = hash.cellOf(Vertex{
+				X: math.Max(t[0].X, math.Max(t[1].X, t[2].X)),
+				Y: math.Max(t[0].Y, math.Max(t[1].Y, t[2].Y)),
+				Z: math.Max(t[0].Z, math.Max(t[1].Z, t[2].Z)),
+			})
+		)
+		for x := from[0]; x <= to[0]; x++ {
+			for y := from[1]; y <= to[1]; y++ {
+				for z := from[2]; z <= to[2]; z++ {
+					cells = append(cells, cell{x, y, z})
+					indices = append(indices, i)
+				}
+			}
+		}
+		hash.triangleRange.add(from)
+		hash.triangleRange.add(to)
+	}
+	hash.triangleCells = newBuckets(cells, indices)
+	return hash
+}
+
+// Creates a new SpatialHash over the vertices and the faces of the model.
+// The indices returned by the hash are the indices of the vertices and the faces starting from 0.
+// If the cell size is not positive, it is the mean length of the edges of the faces multiplied by the cellEdgeRatio.
+func (model *Model) SpatialHash(cellSize float64) *SpatialHash {
+	var triangles = make([][3]Vertex, len(model.faces))
+	for i, face := range model.faces {
+		triangles[i] = [3]Vertex{*face.vertex1, *face.vertex2, *face.vertex3}
+	}
+	if cellSize <= 0 {
+		var mean, _ = model.edgeLengths()
+		cellSize = mean * cellEdgeRatio
+	}
+	return NewSpatialHash(model.vertexCopies(), triangles, cellSize)
+}
+
+// Creates the PointLocator over the vertices of the model that is expected to be the fastest one:
+// the SpatialHash of the vertices without the faces if the faces are uniformly sized,
+// otherwise the KDTree, which does not depend on the distribution of the vertices.
+// The indices returned by the locator are the indices of the vertices starting from 0.
+func (model *Model) PointLocator() PointLocator {
+	var mean, deviation = model.edgeLengths()
+	if mean > 0 && deviation < uniformEdgeVariation*mean {
+		return NewSpatialHash(model.vertexCopies(), nil, mean*cellEdgeRatio)
+	}
+	return model.KDTree()
+}
+
+// Returns the copies of the vertices of the model.
+func (model *Model) vertexCopies() []Vertex {
+	var points = make([]Vertex, len(model.vertices))
+	for i, v := range model.vertices {
+		points[i] = *v
+	}
+	return points
+}
+
+// Returns the mean length of the edges of the faces and its standard deviation, zeros if the model has no faces.
+// The edges shared by several faces are counted for each of them.
+func (model *Model) edgeLengths() (mean, deviation float64) {
+	if len(model.faces) == 0 {
+		return 0, 0
+	}
+	var sum, sumSquares float64
+	for _, face := range model.faces {
+		for _, length := range [...]float64{
+			math.Sqrt(distanceSquared(face.vertex1, face.vertex2)),
+			math.Sqrt(distanceSquared(face.vertex2, face.vertex3)),
+			math.Sqrt(distanceSquared(face.vertex3, face.vertex1)),
+		} {
+			sum += length
+			sumSquares += length * length
+		}
+	}
+	var count = float64(3 * len(model.faces))
+	mean = sum / count
+	return mean, math.Sqrt(math.Max(sumSquares/count-mean*mean, 0))
+}
+
+// Returns the size of the cells for which the points fill the cells of their bounding box by one on average,
+// 1 if the points do not have a bounding box of positive volume.
+func defaultCellSize(points []Vertex) float64 {
+	if len(points) == 0 {
+		return 1
+	}
+	var min, max = points[0], points[0]
+	for _, p := range points[1:] {
+		min = Vertex{X: math.Min(min.X, p.X), Y: math.Min(min.Y, p.Y), Z: math.Min(min.Z, p.Z)}
+		max = Vertex{X: math.Max(max.X, p.X), Y: math.Max(max.Y, p.Y), Z: math.Max(max.Z, p.Z)}
+	}
+	var size = math.Cbrt((max.X - min.X) * (max.Y - min.Y) * (max.Z - min.Z) / float64(len(points)))
+	if size <= 0 || math.IsInf(size, 0) || math.IsNaN(size) {
+		return 1
+	}
+	return size
+}
+
+// Returns the length of the edge of the cells.
+func (hash *SpatialHash) CellSize() float64 {
+	return hash.cellSize
+}
+
+// Returns the number of points in the hash.
+func (hash *SpatialHash) Len() int {
+	return len(hash.points)
+}
+
+// Returns the point of the hash by its index.
+func (hash *SpatialHash) Point(index int) Vertex {
+	return hash.points[index]
+}
+
+// Returns the cell containing the point.
+func (hash *SpatialHash) cellOf(p Vertex) cell {
+	return cell{
+		int(math.Floor(p.X / hash.cellSize)),
+		int(math.Floor(p.Y / hash.cellSize)),
+		int(math.Floor(p.Z / hash.cellSize)),
+	}
+}
+
+// Returns the index of the point closest to p and the squared distance to it.
+// Returns -1 and the positive infinity if the hash has no points.
+// The cells are searched in the rings of the growing size around the cell of p,
+// until the rest of the cells are farther than the closest point found.
+func (hash *SpatialHash) Nearest(p Vertex) (int, float64) {
+	var (
+		best     = -1
+		distance = math.Inf(+1)
+	)
+	if hash.pointRange.empty {
+		return best, distance
+	}
+	var (
+		center      = hash.cellOf(p)
+		first, last int // The rings of the cells that can contain points.
+	)
+	for axis := 0; axis < 3; axis++ {
+		var below, above = center[axis] - hash.pointRange.min[axis], hash.pointRange.max[axis] - center[axis]
+		first = maxInt(first, maxInt(-below, -above))
+		last = maxInt(last, maxInt(below, above))
+	}
+	for ring := first; ring <= last; ring++ {
+		hash.searchRing(p, center, ring, &best, &distance)
+		// The cells of the next rings are farther from p than the number of the ring multiplied by the cell size.
+		var bound = float64(ring) * hash.cellSize
+		if best >= 0 && distance <= bound*bound {
+			break
+		}
+	}
+	return best, distance
+}
+
+// Searches for the point closest to p in the cells whose Chebyshev distance from the center cell equals the ring,
+// updating the best index and distance if a closer point is found.
+func (hash *SpatialHash) searchRing(p Vertex, center cell, ring int, best *int, distance *float64) {
+	var (
+		from, to = hash.pointRange.min, hash.pointRange.max
+		search   = func(c cell) {
+			for _, index := range hash.pointCells.get(c) {
+				if d := distanceSquared(&p, &hash.points[index]); d < *distance {
+					*best = index
+					*distance = d
+				}
+			}
+		}
+	)
+	for x := maxInt(center[0]-ring, from[0]); x <= minInt(center[0]+ring, to[0]); x++ {
+		for y := maxInt(center[1]-ring, from[1]); y <= minInt(center[1]+ring, to[1]); y++ {
+			// The inner cells of the ring are only on its lower and upper faces.
+			if absInt(x-center[0]) == ring || absInt(y-center[1]) == ring {
+				for z := maxInt(center[2]-ring, from[2]); z <= minInt(center[2]+ring, to[2]); z++ {
+					search(cell{x, y, z})
+				}
+				continue
+			}
+			if z := center[2] - ring; z >= from[2] && z <= to[2] {
+				search(cell{x, y, z})
+			}
+			if z := center[2] + ring; ring != 0 && z >= from[2] && z <= to[2] {
+				search(cell{x, y, z})
+			}
+		}
+	}
+}
+
+// Returns the index of the triangle hit first by the ray starting at the origin and going in the direction,
+// and the distance to the hit point measured in the lengths of the direction. Both sides of the triangles are hit.
+// Returns -1 and the positive infinity if the ray does not hit any triangle or the direction is zero.
+// The cells are traversed along the ray, so only the triangles near the ray are tested.
+func (hash *SpatialHash) Pick(origin, direction Vertex) (int, float64) {
+	var (
+		best     = -1
+		distance = math.Inf(+1)
+	)
+	if hash.triangleRange.empty || direction == (Vertex{}) {
+		return best, distance
+	}
+	var (
+		o, d        = [3]float64{origin.X, origin.Y, origin.Z}, [3]float64{direction.X, direction.Y, direction.Z}
+		enter, exit = 0.0, math.Inf(+1)
+	)
+	// Clipping the ray by the box of the cells overlapped by the triangles.
+	for axis := 0; axis < 3; axis++ {
+		var (
+			low  = float64(hash.triangleRange.min[axis]) * hash.cellSize
+			high = float64(hash.triangleRange.max[axis]+1) * hash.cellSize
+		)
+		if d[axis] == 0 {
+			if o[axis] < low || o[axis] > high {
+				return best, distance
+			}
+			continue
+		}
+		var t1, t2 = (low - o[axis]) / d[axis], (high - o[axis]) / d[axis]
+		if t1 > t2 {
+			t1, t2 = t2, t1
+		}
+		enter, exit = math.Max(enter, t1), math.Min(exit, t2)
+	}
+	if enter > exit {
+		return best, distance
+	}
+	// Traversing the cells along the ray (the 3D DDA algorithm).
+	var (
+		current         = hash.cellOf(add(origin, scale(direction, enter)))
+		step            [3]int
+		next, increment [3]float64 // The parameters of the ray at the next boundaries of the cells and between them.
+	)
+	for axis := 0; axis < 3; axis++ {
+		current[axis] = maxInt(hash.triangleRange.min[axis], minInt(current[axis], hash.triangleRange.max[axis]))
+		switch {
+		case d[axis] > 0:
+			step[axis] = 1
+			next[axis] = (float64(current[axis]+1)*hash.cellSize - o[axis]) / d[axis]
+			increment[axis] = hash.cellSize / d[axis]
+		case d[axis] < 0:
+			step[axis] = -1
+			next[axis] = (float64(current[axis])*hash.cellSize - o[axis]) / d[axis]
+			increment[axis] = -hash.cellSize / d[axis]
+		default:
+			next[axis] = math.Inf(+1)
+			increment[axis] = math.Inf(+1)
+		}
+	}
+	for {
+		for _, index := range hash.triangleCells.get(current) {
+			if t, ok := intersectTriangle(origin, direction, &hash.triangles[index]); ok && t < distance {
+				best, distance = index, t
+			}
+		}
+		var axis = 0
+		if next[1] < next[axis] {
+			axis = 1
+		}
+		if next[2] < next[axis] {
+			axis = 2
+		}
+		// The hit inside the cell is closer than the triangles of the next cells.
+		if distance <= next[axis] || next[axis] > exit {
+			return best, distance
+		}
+		current[axis] += step[axis]
+		if current[axis] < hash.triangleRange.min[axis] || current[axis] > hash.triangleRange.max[axis] {
+			return best, distance
+		}
+		next[axis] += increment[axis]
+	}
+}
+
+// Returns the parameter of the point of the ray in which it intersects the triangle (the Möller–Trumbore algorithm)
+// and true if the ray intersects the triangle at a non-negative parameter.
+func intersectTriangle(origin, direction Vertex, triangle *[3]Vertex) (float64, bool) {
+	var (
+		edge1       = sub(triangle[1], triangle[0])
+		edge2       = sub(triangle[2], triangle[0])
+		h           = cross(direction, edge2)
+		determinant = dot(edge1, h)
+	)
+	// The ray is parallel to the plane of the triangle.
+	if determinant == 0 {
+		return 0, false
+	}
+	var (
+		inverse = 1 / determinant
+		s       = sub(origin, triangle[0])
+		u       = inverse * dot(s, h)
+	)
+	if u < 0 || u > 1 {
+		return 0, false
+	}
+	var (
+		q = cross(s, edge1)
+		v = inverse * dot(direction, q)
+	)
+	if v < 0 || u+v > 1 {
+		return 0, false
+	}
+	var t = inverse * dot(edge2, q)
+	return t, t >= 0
+}
+
+// Returns the smaller of the numbers.
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// Returns the greater of the numbers.
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// Returns the absolute value of the number.
+func absInt(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
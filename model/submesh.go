@@ -0,0 +1,48 @@
+package model
+
+// Describes a named part of the model: a range of faces that belong to the same object and groups.
+// In the .obj file the sub-meshes are separated by the object (o) and group (g) statements.
+type SubMesh struct {
+	Object     string   // The name of the object, empty if the faces do not belong to any object.
+	Groups     []string // The names of the groups, empty if the faces do not belong to any group.
+	FirstFace  int      // The index of the first face of the sub-mesh.
+	FacesCount int      // The number of faces of the sub-mesh.
+}
+
+// Starts a new sub-mesh: the faces added after that belong to the specified object and groups.
+// The faces added before the first sub-mesh was started form an unnamed sub-mesh.
+// If no faces were added to the previous sub-mesh, it is replaced by the new one.
+func (model *Model) StartSubMesh(object string, groups ...string) {
+	var subMesh = SubMesh{
+		Object:    object,
+		Groups:    append([]string(nil), groups...),
+		FirstFace: len(model.faces),
+	}
+	var count = len(model.subMeshes)
+	switch {
+	case count > 0 && model.subMeshes[count-1].FirstFace == len(model.faces):
+		model.subMeshes[count-1] = subMesh
+	case count == 0 && len(model.faces) > 0:
+		model.subMeshes = append(model.subMeshes, SubMesh{}, subMesh)
+	default:
+		model.subMeshes = append(model.subMeshes, subMesh)
+	}
+}
+
+// Returns the sub-meshes of the model in the order of their faces.
+// Returns nil if no sub-mesh was started, in this case all faces of the model form a single unnamed part.
+func (model *Model) SubMeshes() []SubMesh {
+	if len(model.subMeshes) == 0 {
+		return nil
+	}
+	var subMeshes = make([]SubMesh, len(model.subMeshes))
+	copy(subMeshes, model.subMeshes)
+	for i := range subMeshes {
+		var end = len(model.faces)
+		if i+1 < len(subMeshes) {
+			end = subMeshes[i+1].FirstFace
+		}
+		subMeshes[i].FacesCount = end - subMeshes[i].FirstFace
+	}
+	return subMeshes
+}
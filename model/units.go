@@ -0,0 +1,107 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// The units of length in which the coordinates of the vertices are specified.
+// The units of the model are stored in the metadata by the UnitsKey.
+type Units string
+
+const (
+	Millimeters Units = "mm" // Millimeters.
+	Centimeters Units = "cm" // Centimeters.
+	Meters      Units = "m"  // Meters.
+	Inches      Units = "in" // Inches.
+)
+
+// The length of one unit in meters.
+var unitsInMeters = map[Units]float64{
+	Millimeters: 0.001,
+	Centimeters: 0.01,
+	Meters:      1,
+	Inches:      0.0254,
+}
+
+// The names of the units used by the exporters.
+var unitsNames = map[string]Units{
+	"mm":          Millimeters,
+	"millimeter":  Millimeters,
+	"millimeters": Millimeters,
+	"millimetre":  Millimeters,
+	"millimetres": Millimeters,
+	"cm":          Centimeters,
+	"centimeter":  Centimeters,
+	"centimeters": Centimeters,
+	"centimetre":  Centimeters,
+	"centimetres": Centimeters,
+	"m":           Meters,
+	"meter":       Meters,
+	"meters":      Meters,
+	"metre":       Meters,
+	"metres":      Meters,
+	"in":          Inches,
+	"inch":        Inches,
+	"inches":      Inches,
+}
+
+// Matches the description of the units in the comments, for example, 'units = millimeters' or 'Unit: cm'.
+var unitsComment = regexp.MustCompile(`(?i)\bunits?\s*[:=]\s*([a-z]+)`)
+
+// Converts the name of the units to the Units, for example, 'millimeters' or 'MM' to Millimeters.
+// Returns false if the name is unknown.
+func ParseUnits(name string) (Units, bool) {
+	var units, ok = unitsNames[strings.ToLower(strings.TrimSpace(name))]
+	return units, ok
+}
+
+// Finds the description of the units in the text of the comment written by the exporter,
+// for example, '# units = millimeters'.
+// Returns false if the comment does not describe the units or the units are unknown.
+func DetectUnits(comment string) (Units, bool) {
+	var match = unitsComment.FindStringSubmatch(comment)
+	if match == nil {
+		return "", false
+	}
+	return ParseUnits(match[1])
+}
+
+// Returns the factor by which the coordinates in the units must be multiplied to get the coordinates in other units.
+// Returns an error if any of the units is unknown.
+func (units Units) ScaleTo(other Units) (float64, error) {
+	var from, ok = unitsInMeters[units]
+	if !ok {
+		return 0, fmt.Errorf("unknown units: %q", units)
+	}
+	to, ok := unitsInMeters[other]
+	if !ok {
+		return 0, fmt.Errorf("unknown units: %q", other)
+	}
+	return from / to, nil
+}
+
+// Returns the units of the model stored in the metadata by the UnitsKey and true if they are known.
+func (model *Model) Units() (Units, bool) {
+	return ParseUnits(model.metadata[UnitsKey])
+}
+
+// Converts the coordinates of the vertices of the model to the specified units and stores them in the metadata.
+// Returns an error if the units of the model are not known or any of the units is unknown,
+// in this case the model is not changed.
+func (model *Model) ConvertUnits(units Units) error {
+	var current, ok = model.Units()
+	if !ok {
+		return fmt.Errorf("the units of the model are not known: %q", model.metadata[UnitsKey])
+	}
+	var factor, err = current.ScaleTo(units)
+	if err != nil {
+		return err
+	}
+	model.Transform(func(x, y, z float64) (float64, float64, float64) {
+		return x * factor, y * factor, z * factor
+	})
+	model.Metadata()[UnitsKey] = string(units)
+	return nil
+}
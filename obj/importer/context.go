@@ -0,0 +1,34 @@
+package importer
+
+import (
+	"computer_graphics/model"
+	"context"
+	"io"
+)
+
+// Reads the full model.Model from io.Reader like the ImportWithReport, but stops the import when the context is done,
+// so that the servers importing the files uploaded by the users can limit the time of the import.
+// The context is checked before each element of the file and each stage of the import, so the import stops promptly,
+// but a single read of the input is not interrupted, the input should be closed to stop the blocked reading.
+// Returns the model with the elements imported before the context was done and the error of the context.
+// The error is the one that aborted the parsing if it was aborted because of the MaxErrors, nil if the whole file was imported.
+func (i *Importer) ImportContext(ctx context.Context, in io.Reader) (*model.Model, error) {
+	// The context is the state of the call, so it is set on a copy of the Importer instead of the Importer itself.
+	var call = *i
+	call.ctx = ctx
+	var m, report = call.ImportWithReport(in)
+	return m, report.Aborted
+}
+
+// Reports whether the context of the current import is done, see Importer.ImportContext.
+func (i *Importer) cancelled() bool {
+	if i.ctx == nil {
+		return false
+	}
+	select {
+	case <-i.ctx.Done():
+		return true
+	default:
+		return false
+	}
+}
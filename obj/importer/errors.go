@@ -0,0 +1,39 @@
+package importer
+
+import (
+	"computer_graphics/obj/parser"
+	"fmt"
+)
+
+// The error of a problem found by the parser or the Importer at a line of the file, see Issue.Err.
+// It wraps the cause of the problem, so that the kind of the problem can be checked by errors.Is and errors.As,
+// for example, errors.Is(issue.Err, model.ErrIndexOutOfRange) for the faces referring to no vertex
+// or errors.As(issue.Err, &diagnostic) with the diagnostic of the type parser.Diagnostic for the problems of the parser.
+type ParseError struct {
+	File        string             // The name of the file containing the problem, empty if it is not known.
+	Line        int                // The number of the line containing the problem starting from 1.
+	ElementType parser.ElementType // The type of the element containing the problem, parser.EndOfFile if it is not known.
+	Err         error              // The cause of the problem.
+}
+
+// Returns the description of the problem with its position.
+func (e *ParseError) Error() string {
+	var (
+		position = fmt.Sprintf("line %d", e.Line)
+		message  = e.Err.Error()
+	)
+	// The diagnostic of the parser describes its position itself, so only its message is used.
+	if diagnostic, ok := e.Err.(parser.Diagnostic); ok {
+		position += fmt.Sprintf(", column %d", diagnostic.Column)
+		message = diagnostic.Message
+	}
+	if e.File != "" {
+		position = e.File + ": " + position
+	}
+	return position + ": " + message
+}
+
+// Returns the cause of the problem, so that errors.Is and errors.As can check it.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
@@ -2,129 +2,996 @@ package importer
 
 import (
 	"computer_graphics/model"
+	"computer_graphics/obj/mtl"
 	"computer_graphics/obj/parser"
 	"computer_graphics/obj/parser/types"
+	"context"
+	"errors"
 	"fmt"
 	"io"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 )
 
+// One of the possible kinds of problems that the Importer can find.
+// Each kind has a default severity that can be changed using the Importer.Policy field.
+type IssueKind uint8
+
+const (
+	VertexWeightIssue      IssueKind = iota // Not reported, the weight of the vertex is reported by the parser as the parser.DeviationIssue.
+	PolygonIssue                            // The face has more than three vertices (WARNING by default).
+	FaceTextureIssue                        // The texture vertices of the face cannot be resolved, it is imported without them (WARNING by default).
+	FaceNormalIssue                         // The face refers to vertex normals that are not supported (WARNING by default).
+	InvalidFaceIssue                        // The face refers to vertices that do not exist (ERROR by default).
+	ElementOrderIssue                       // The element refers to the vertices defined after it (INFO by default).
+	ImpossibleElementIssue                  // The parser returned an element that cannot be imported (ERROR by default).
+	LineTextureIssue                        // The line refers to texture vertices that are not supported (WARNING by default).
+	InvalidLineIssue                        // The line refers to vertices that do not exist (ERROR by default).
+	InvalidPointIssue                       // The point refers to vertices that do not exist (ERROR by default).
+	DuplicateFaceIssue                      // The face has the same vertices as one of the previous faces (WARNING by default).
+	FreeFormIssue                           // The statement describes free-form geometry that is not supported (INFO by default).
+	TextureMapIssue                         // The statement refers to texture maps that are not supported (INFO by default).
+	UnitsIssue                              // The coordinates cannot be converted to the Importer.Units (WARNING by default).
+	MaterialLibraryIssue                    // The material library cannot be read, see Importer.LoadMaterials (WARNING by default).
+	UndefinedMaterialIssue                  // The material is not defined in the loaded material libraries (WARNING by default).
+)
+
+// The severities of the issue kinds that are used if the policy does not contain the kind.
+var defaultSeverities = [...]parser.Severity{
+	parser.Warning,
+	parser.Warning,
+	parser.Warning,
+	parser.Warning,
+	parser.Error,
+	parser.Info,
+	parser.Error,
+	parser.Warning,
+	parser.Error,
+	parser.Error,
+	parser.Warning,
+	parser.Info,
+	parser.Info,
+	parser.Warning,
+	parser.Warning,
+	parser.Warning,
+}
+
+// Returns the default severity of the issue kind.
+func (kind IssueKind) DefaultSeverity() parser.Severity {
+	return defaultSeverities[kind]
+}
+
+// Converts an issue kind constant to its string representation.
+var issueKindNamesMap = [...]string{
+	"vertex weight",
+	"polygon",
+	"face texture",
+	"face normal",
+	"invalid face",
+	"element order",
+	"impossible element",
+	"line texture",
+	"invalid line",
+	"invalid point",
+	"duplicate face",
+	"free-form",
+	"texture map",
+	"units",
+	"material library",
+	"undefined material",
+}
+
+// Converts an issue kind constant to its string representation.
+func (kind IssueKind) String() string {
+	return issueKindNamesMap[kind]
+}
+
+// A problem found by the parser or the Importer during the import, passed to the Importer.OnIssue,
+// so that the services embedding the Importer can log the problems in their own format.
+type Issue struct {
+	Kind        IssueKind          // The kind of the problem found by the Importer, not set for the problems of the parser.
+	Diagnostic  *parser.Diagnostic // The diagnostic of the problem found by the parser, nil for the problems of the Importer.
+	Severity    parser.Severity    // The severity of the problem according to the policy.
+	File        string             // The name of the file containing the problem, empty if it is not known.
+	Line        int                // The number of the line containing the problem starting from 1.
+	ElementType parser.ElementType // The type of the element containing the problem, parser.EndOfFile if it is not known.
+	Message     string             // The description of the problem.
+	// The problem as the *ParseError with the same position, wrapping the parser.Diagnostic for the problems of the parser
+	// and the error of the model.Model for the elements rejected by it, for example, the model.IndexError.
+	Err error
+}
+
+// A statement of the .obj file that was not imported into the model.
+type Statement struct {
+	Line int    // The number of the line containing the statement.
+	Text string // The raw text of the statement.
+}
+
+// Contains information about the import that is not stored in the model.
+type ImportReport struct {
+	// Statements of unsupported formats in the order in which they occur in the file.
+	// Filled only if the Importer.PreserveUnsupported is true,
+	// so that converters can write these statements back when exporting the model.
+	Unsupported []Statement
+	// Paths to the .mtl files listed in the material library statements in the order in which they occur in the file.
+	// Relative paths are resolved against the directory of the .obj file if it is known (see model.SourceKey).
+	MaterialLibraries []string
+	// The materials defined in the material libraries in the order of the libraries and of the materials in them.
+	// Filled only if the Importer.LoadMaterials is true.
+	Materials []*mtl.Material
+	// The materials of the faces indexed by model.Face.MaterialIndex, in the order of the model.Model.Materials,
+	// the first material with the name in the Materials. Nil for the materials that are not defined in the libraries.
+	// Filled only if the Importer.LoadMaterials is true.
+	FaceMaterials []*mtl.Material
+	// The number of faces skipped as duplicates, filled only if the Importer.RemoveDuplicateFaces is true.
+	DuplicateFaces int
+	// The numbers of the elements read by the parser, but skipped by the Importer, by their types:
+	// the faces, lines and points referring to the vertices that do not exist, counted by the rejected triangles
+	// and vertices of the points, the statements of texture maps and free-form geometry and the impossible elements.
+	Skipped map[parser.ElementType]int
+	// The error that aborted the parsing because of the Importer.MaxErrors or the error of the context
+	// of the Importer.ImportContext, nil if the whole file was read.
+	Aborted error
+	// The statistics of the elements and the problems found by the parser.
+	Stats parser.Stats
+	// The problems of the quality of the imported model, nil if the Importer.Validate is false.
+	Validation *ValidationReport
+}
+
 // Allows you to import a model from a .obj file.
 // Display information about problems that occur during importing.
 // You can disable the output by using the IgnoreInfos, IgnoreWarnings and IgnoreErrors fields.
+// You can change the severity of the problems by using the Policy and ParserPolicy fields.
 // You can also specify io.Writer to output this information to.
+// The Importer must not be used by several goroutines at the same time.
 type Importer struct {
-	Output         io.Writer // Recipient of error and warning messages.
-	IgnoreInfos    bool      // If true, no info messages will be output to the Output.
-	IgnoreWarnings bool      // If true, no warning messages will be output to the Output.
-	IgnoreErrors   bool      // If true, no error messages will be output to the Output.
+	Output         io.Writer                            // Recipient of error and warning messages.
+	IgnoreInfos    bool                                 // If true, no info messages will be output to the Output.
+	IgnoreWarnings bool                                 // If true, no warning messages will be output to the Output.
+	IgnoreErrors   bool                                 // If true, no error messages will be output to the Output.
+	Policy         map[IssueKind]parser.Severity        // Severities of the importer issue kinds that differ from the default ones.
+	ParserPolicy   map[parser.IssueKind]parser.Severity // Severities of the parser issue kinds that differ from the default ones.
+
+	PreserveUnsupported bool // If true, the raw text of unsupported statements is stored in the ImportReport.
+	// If true, the faces with the same set of vertices as one of the previous faces are skipped,
+	// regardless of the order of the vertices. Duplicate faces cause z-fighting when rendering.
+	RemoveDuplicateFaces bool
+	// The way the faces with more than three vertices are divided into triangles.
+	// By default, only the first three vertices are used and the PolygonIssue is reported.
+	Triangulation Triangulation
+	// If not empty, the coordinates of the vertices are converted to these units.
+	// The units of the file are detected from the comment header, for example, '# units = millimeters'.
+	// If the units of the file are not known, the coordinates are not converted.
+	Units model.Units
+	// The way the normals of the corners of the faces are generated after the import, see model.Model.FaceNormals.
+	// By default, the normals are not generated. The normals must be generated again after transforming the model.
+	GenerateNormals NormalMode
+	// If positive, the import stops after this number of problems of the parser with the Error severity,
+	// the rest of the file is skipped and the model contains the elements read so far, see parser.Parser.SetMaxErrors.
+	MaxErrors int
+	// If not nil, the memory for the numbers of the vertices and faces in these statistics is reserved in the model
+	// before reading it, see model.Model.Reserve. For example, the Stats of the ImportReport of the previous import
+	// of the same file can be used to import it again without growing the arrays of the model.
+	Expected *parser.Stats
+	// If true and the input is an io.Seeker, like *os.File, the statements of the input are counted
+	// by parser.CountElements before reading it and the memory for the vertices and faces is reserved in the model,
+	// so that the arrays of the model are not grown while reading. The input is read twice, so the import
+	// allocates a little less, but is not faster, see the BenchmarkImporter_PreScan of the examples.
+	// The Expected takes precedence.
+	PreScan bool
+	// If not nil, receives each problem found by the parser or the Importer,
+	// regardless of the IgnoreInfos, IgnoreWarnings and IgnoreErrors settings.
+	// The problems are still written to the Output.
+	OnIssue func(issue Issue)
+	// If not nil, receives the imported model and the ImportReport at the end of each import,
+	// so that a summary of the import can be logged.
+	OnReport func(m *model.Model, report *ImportReport)
+	// If not nil, receives the progress of each stage of the import, see ReadStage and the other stages,
+	// so that the tools can show the progress of the import of the huge models.
+	// It is called at the beginning and at the end of each stage and regularly during it
+	// with the amount of the work processed and the total amount of the work of the stage,
+	// the total is -1 if it is not known, for example, the size of the input that is not a file.
+	OnProgress func(stage string, processed, total int)
+	// If true, the material libraries listed in the material library statements are read after the model
+	// and their materials are stored in the ImportReport, together with the materials of the faces
+	// found by the names of the material name statements. The materials that are not found are reported
+	// as the UndefinedMaterialIssue at their first material name statements. The problems of the libraries are reported
+	// with the names of the libraries, the libraries that cannot be opened are reported as the MaterialLibraryIssue.
+	LoadMaterials bool
+	// If true, the imported model is checked for the problems of its quality, like the degenerate faces
+	// and the non-manifold edges, which are stored in the ImportReport.Validation.
+	Validate bool
+	// Opens the material libraries by the names written in the material library statements, if LoadMaterials is true.
+	// If nil, the libraries are opened by the parser.DirResolver of the directory of the model,
+	// that is, as the files at the paths of the ImportReport.MaterialLibraries.
+	MaterialResolver parser.FileResolver
+
+	skipped      map[parser.ElementType]bool // The element types skipped without parsing, see Importer.Only and Importer.Skip.
+	faceLines    map[string]int              // The lines of the imported faces by their sorted vertex indices, used to find duplicates.
+	importReport *ImportReport               // The report of the current import.
+	source       string                      // The name of the file of the current import, empty if it is not known.
+	// The lines of the first material name statements of the materials of the model in the order of model.Model.Materials.
+	materialLines []int
+	// The lines of the material library statements of the material libraries in the order of the ImportReport.MaterialLibraries.
+	libraryLines []int
+	// The faces, lines and points referring to the vertices defined after them, imported at the end of the file.
+	postponed []postponedElement
+	// The state of the elements being read, restored when the postponed elements are imported.
+	smoothingGroup int            // The current smoothing group.
+	material       string         // The current material.
+	subMesh        *model.SubMesh // The last started sub-mesh, nil if no sub-mesh was started.
+	// The offset of the input after which the progress of reading is passed to the OnProgress.
+	nextProgress int
+	// The context of the current import, set only on the copy of the Importer made by the ImportContext.
+	ctx context.Context
+}
+
+// A face, line or point referring to the vertices defined after it, imported at the end of the file
+// with the smoothing group, the material and the sub-mesh that were current when it was read.
+type postponedElement struct {
+	line           int                // The line of the element.
+	elementType    parser.ElementType // The type of the element.
+	element        interface{}        // The element with the absolute indices of the vertices.
+	smoothingGroup int                // The smoothing group of the element.
+	material       string             // The material of the element.
+	subMesh        *model.SubMesh     // The sub-mesh of the element, nil if no sub-mesh was started.
 }
 
 // Reads the full model.Model from io.Reader.
 // Handles errors according to the settings in the fields.
 func (i *Importer) Import(in io.Reader) *model.Model {
+	var m, _ = i.ImportWithReport(in)
+	return m
+}
+
+// Reads the full model from io.Reader as the model.Scene with a separate mesh for each part of the model
+// started by the object and group statements, named as these statements, see model.Model.Split.
+// Handles errors according to the settings in the fields.
+func (i *Importer) ImportScene(in io.Reader) *model.Scene {
+	return i.Import(in).Split()
+}
+
+// Reads the full model.Model from io.Reader and returns it with the ImportReport.
+// Handles errors according to the settings in the fields.
+func (i *Importer) ImportWithReport(in io.Reader) (*model.Model, *ImportReport) {
+	var report = &ImportReport{}
+	if i.Validate {
+		report.Validation = &ValidationReport{}
+	}
+	var expected = i.Expected
+	if expected == nil && i.PreScan {
+		expected = preScan(in)
+	}
 	// Setting up the parser.
 	var p = parser.NewParser(in)
 	p.Output(i.Output)
 	p.IgnoreErrors(i.IgnoreErrors)
 	p.IgnoreWarnings(i.IgnoreWarnings)
+	p.IgnoreInfos(i.IgnoreInfos)
+	for kind, severity := range i.ParserPolicy {
+		p.SetSeverity(kind, severity)
+	}
+	p.SetMaxErrors(i.MaxErrors)
+	p.NormalizeIndices(true)
+	p.ForwardIndices(true)
+	if i.skipped != nil {
+		p.Filter(func(elementType parser.ElementType) bool {
+			return !i.skipped[elementType]
+		})
+	}
+	if i.OnIssue != nil || i.Validate {
+		p.OnDiagnostic(i.reportDiagnostic)
+	}
+	if i.PreserveUnsupported {
+		p.OnUnsupported(func(line int, text string) {
+			report.Unsupported = append(report.Unsupported, Statement{Line: line, Text: text})
+		})
+	}
 	// Reading the model.
+	i.importReport = report
+	i.source = ""
+	if named, ok := in.(interface{ Name() string }); ok {
+		i.source = named.Name()
+	}
+	i.faceLines = nil
+	i.materialLines = nil
+	i.libraryLines = nil
+	i.postponed = nil
+	i.smoothingGroup, i.material, i.subMesh = 0, "", nil
+	if i.RemoveDuplicateFaces {
+		i.faceLines = make(map[string]int)
+	}
 	var m = model.NewModel()
-	i.importVertices(p, m)
-	i.importFaces(p, m)
-	return m
+	if expected != nil {
+		m.Reserve(expected.Elements[parser.Vertex], expected.Elements[parser.Face])
+	}
+	i.importMetadata(in, p, m)
+	i.nextProgress = progressBytes
+	i.progress(ReadStage, 0, int(p.Size()))
+	i.importElements(p, m)
+	i.progress(ReadStage, int(p.Offset()), int(p.Size()))
+	i.importPostponed(m)
+	report.MaterialLibraries = materialLibraries(m.Metadata())
+	if i.cancelled() {
+		return i.finish(m, report, p)
+	}
+	if i.Units != "" {
+		if err := m.ConvertUnits(i.Units); err != nil {
+			i.reportError(UnitsIssue, parser.EndOfFile, p.Line(), err, fmt.Sprintf("the coordinates are not converted - %s", err))
+		}
+	}
+	if i.GenerateNormals != NoNormals && !i.cancelled() {
+		i.progress(NormalsStage, 0, m.FacesCount())
+		i.GenerateNormals.generate(m)
+		i.progress(NormalsStage, m.FacesCount(), m.FacesCount())
+	}
+	if i.LoadMaterials {
+		i.loadMaterials(m.Metadata(), p)
+		i.attachMaterials(m)
+	}
+	return i.finish(m, report, p)
 }
 
-// Outputs a message in Output in the format:
-// [INFO] {msg}
-func (i *Importer) info(msg string) {
-	if i.Output != nil && !i.IgnoreInfos {
-		fmt.Fprintln(i.Output, "[INFO]", msg)
+// Completes the ImportReport of the import of the model with the results of the parser,
+// passes them to the OnReport and returns them.
+func (i *Importer) finish(m *model.Model, report *ImportReport, p parser.Parser) (*model.Model, *ImportReport) {
+	report.Aborted = p.Err()
+	if i.cancelled() {
+		report.Aborted = i.ctx.Err()
+	}
+	report.Stats = p.Stats()
+	if report.Validation != nil {
+		report.Validation.validate(m)
+	}
+	if i.OnReport != nil {
+		i.OnReport(m, report)
 	}
+	return m, report
 }
 
-// Outputs a message in Output in the format:
-// [WARNING] line: {line}, message: {msg}
-func (i *Importer) warning(line int, msg string) {
-	if i.Output != nil && !i.IgnoreWarnings {
-		fmt.Fprintf(i.Output, "[WARNING] line: %d, message: %s\n", line, msg)
+// Counts the statements of the input by parser.CountElements and rewinds it to the position before the counting.
+// Returns nil if the input is not an io.Seeker or it fails.
+func preScan(in io.Reader) *parser.Stats {
+	var seeker, ok = in.(io.Seeker)
+	if !ok {
+		return nil
+	}
+	var offset, err = seeker.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil
+	}
+	stats, err := parser.CountElements(in)
+	if _, seekErr := seeker.Seek(offset, io.SeekStart); err != nil || seekErr != nil {
+		return nil
 	}
+	return &stats
 }
 
-// Outputs a message in Output in the format:
-// [ERROR] line: {line}, message: {msg}
-func (i *Importer) error(line int, msg string) {
-	if i.Output != nil && !i.IgnoreErrors {
-		fmt.Fprintf(i.Output, "[ERROR] line: %d, message: %s\n", line, msg)
+// Makes the Importer parse only the elements of the specified types, the lines of other elements are skipped
+// without parsing and reporting. For example, only the group and material structure can be extracted without geometry.
+// Replaces the settings of the previous calls of Only and Skip, without arguments all elements are imported again.
+// Returns the Importer itself.
+func (i *Importer) Only(elementTypes ...parser.ElementType) *Importer {
+	if len(elementTypes) == 0 {
+		i.skipped = nil
+		return i
+	}
+	i.skipped = make(map[parser.ElementType]bool)
+	for elementType := parser.Vertex; elementType < parser.EndOfFile; elementType++ {
+		i.skipped[elementType] = true
+	}
+	for _, elementType := range elementTypes {
+		delete(i.skipped, elementType)
+	}
+	return i
+}
+
+// Makes the Importer skip the lines of the elements of the specified types without parsing and reporting.
+// For example, the texture vertices can be skipped to read the geometry faster.
+// Replaces the settings of the previous calls of Only and Skip, without arguments all elements are imported again.
+// Returns the Importer itself.
+func (i *Importer) Skip(elementTypes ...parser.ElementType) *Importer {
+	if len(elementTypes) == 0 {
+		i.skipped = nil
+		return i
+	}
+	i.skipped = make(map[parser.ElementType]bool)
+	for _, elementType := range elementTypes {
+		i.skipped[elementType] = true
 	}
+	return i
+}
+
+// Returns the severity with which the problems of the specified kind are reported.
+func (i *Importer) severity(kind IssueKind) parser.Severity {
+	if severity, ok := i.Policy[kind]; ok {
+		return severity
+	}
+	return kind.DefaultSeverity()
+}
+
+// Passes the problem of the element of the specified type to the OnIssue and outputs a message in Output in the format:
+// [{severity}] line: {line}, message: {msg}
+// The severity is determined by the issue kind according to the Policy.
+func (i *Importer) report(kind IssueKind, elementType parser.ElementType, line int, msg string) {
+	i.reportError(kind, elementType, line, errors.New(msg), msg)
+}
+
+// Reports the problem like the report, the cause of the problem is wrapped by the ParseError of the Issue.
+func (i *Importer) reportError(kind IssueKind, elementType parser.ElementType, line int, cause error, msg string) {
+	var (
+		severity = i.severity(kind)
+		err      = &ParseError{File: i.source, Line: line + 1, ElementType: elementType, Err: cause}
+	)
+	if i.OnIssue != nil {
+		i.OnIssue(Issue{
+			Kind:        kind,
+			Severity:    severity,
+			File:        i.source,
+			Line:        line + 1,
+			ElementType: elementType,
+			Message:     msg,
+			Err:         err,
+		})
+	}
+	switch kind {
+	case InvalidFaceIssue, InvalidLineIssue, InvalidPointIssue, FreeFormIssue, TextureMapIssue, ImpossibleElementIssue:
+		if i.importReport.Skipped == nil {
+			i.importReport.Skipped = make(map[parser.ElementType]int)
+		}
+		i.importReport.Skipped[elementType]++
+	}
+	if validation := i.importReport.Validation; validation != nil {
+		switch kind {
+		case InvalidFaceIssue, InvalidLineIssue, InvalidPointIssue:
+			validation.drop(err)
+		}
+	}
+	if i.Output == nil {
+		return
+	}
+	switch {
+	case severity == parser.Info && i.IgnoreInfos,
+		severity == parser.Warning && i.IgnoreWarnings,
+		severity == parser.Error && i.IgnoreErrors:
+		return
+	}
+	fmt.Fprintf(i.Output, "[%s] line: %d, message: %s\n", severity, line+1, msg)
+}
+
+// Passes the problem found by the parser to the OnIssue and stores it in the ValidationReport
+// if the element is dropped because of its indices.
+// The type of the element is determined by the first word of the line.
+func (i *Importer) reportDiagnostic(diagnostic parser.Diagnostic) {
+	var issue = Issue{
+		Diagnostic:  &diagnostic,
+		Severity:    diagnostic.Severity,
+		File:        diagnostic.File,
+		Line:        diagnostic.Line,
+		ElementType: parser.EndOfFile,
+		Message:     diagnostic.Message,
+	}
+	if issue.File == "" {
+		issue.File = i.source
+	}
+	if words := strings.Fields(diagnostic.LineText); len(words) != 0 {
+		if elementType, ok := parser.ElementTypeOf(words[0]); ok {
+			issue.ElementType = elementType
+		}
+	}
+	var err = &ParseError{File: issue.File, Line: issue.Line, ElementType: issue.ElementType, Err: diagnostic}
+	issue.Err = err
+	if i.OnIssue != nil {
+		i.OnIssue(issue)
+	}
+	if validation := i.importReport.Validation; validation != nil {
+		validation.drop(err)
+	}
+}
+
+// Fills the metadata of the model with the information that is known before reading the elements
+// and sets up the parser to collect the comment header and the units described in it.
+func (i *Importer) importMetadata(in io.Reader, p parser.Parser, m *model.Model) {
+	var metadata = m.Metadata()
+	// The name of the file is known if the model is read from the os.File.
+	if named, ok := in.(interface{ Name() string }); ok {
+		metadata[model.SourceKey] = named.Name()
+	}
+	// The header consists of the comment lines going in a row from the beginning of the file.
+	var header []string
+	p.OnComment(func(line int, text string) {
+		if line == len(header)+1 {
+			header = append(header, strings.TrimSpace(strings.TrimPrefix(text, "#")))
+			metadata[model.HeaderKey] = strings.Join(header, "\n")
+			// Some exporters describe the units of the coordinates in the header.
+			if units, ok := model.DetectUnits(text); ok {
+				if _, known := metadata[model.UnitsKey]; !known {
+					metadata[model.UnitsKey] = string(units)
+				}
+			}
+		}
+	})
 }
 
 // Imports a single vertex of the model.
+// The weight of the vertex is ignored, it is reported by the parser.
 func (i *Importer) importVertex(line int, v *types.Vertex, m *model.Model) {
-	if v.W != 0 {
-		i.warning(line, "vertex weights are not supported")
-	}
 	m.AppendVertex(v.X, v.Y, v.Z)
 }
 
-// Imports all vertices of the model.
-func (i *Importer) importVertices(p parser.Parser, m *model.Model) {
+// Imports a single texture vertex of the model.
+func (i *Importer) importTextureVertex(v *types.TextureVertex, m *model.Model) {
+	m.AppendTextureVertex(v.U, v.V, v.W)
+}
+
+// Imports a single parameter space vertex of the model.
+func (i *Importer) importParameterVertex(v *types.ParameterVertex, m *model.Model) {
+	m.AppendParameterVertex(v.U, v.V, v.W)
+}
+
+// Imports an object statement: starts a new sub-mesh of the model belonging to the object.
+// The name of the first object is also used as the name of the model.
+func (i *Importer) importObject(o *types.Object, m *model.Model) {
+	var metadata = m.Metadata()
+	if _, ok := metadata[model.NameKey]; !ok {
+		metadata[model.NameKey] = o.Name
+	}
+	m.StartSubMesh(o.Name)
+	i.subMesh = &model.SubMesh{Object: o.Name}
+}
+
+// Imports a group statement: starts a new sub-mesh of the model belonging to the groups of the current object.
+func (i *Importer) importGroup(g *types.Group, m *model.Model) {
+	var object string
+	if subMeshes := m.SubMeshes(); len(subMeshes) > 0 {
+		object = subMeshes[len(subMeshes)-1].Object
+	}
+	m.StartSubMesh(object, g.Names...)
+	i.subMesh = &model.SubMesh{Object: object, Groups: g.Names}
+}
+
+// Imports a smoothing group statement: the faces read after it belong to the smoothing group.
+func (i *Importer) importSmoothingGroup(g *types.SmoothingGroup, m *model.Model) {
+	m.SetSmoothingGroup(g.Number)
+	i.smoothingGroup = g.Number
+}
+
+// Imports a material name statement: the faces read after it have the material.
+func (i *Importer) importUseMaterial(line int, u *types.UseMaterial, m *model.Model) {
+	m.SetMaterial(u.Name)
+	i.material = u.Name
+	if len(m.Materials()) > len(i.materialLines) {
+		i.materialLines = append(i.materialLines, line)
+	}
+}
+
+// Imports a material library statement: adds the file names to the list of material libraries in the metadata
+// and remembers the line of the statement for each of them, to which the problems of the library are reported.
+func (i *Importer) importMaterialLibrary(line int, l *types.MaterialLibrary, m *model.Model) {
+	for range l.Files {
+		i.libraryLines = append(i.libraryLines, line)
+	}
+	var (
+		metadata  = m.Metadata()
+		libraries = l.Files
+	)
+	if known, ok := metadata[model.MaterialLibrariesKey]; ok {
+		libraries = append([]string{known}, libraries...)
+	}
+	metadata[model.MaterialLibrariesKey] = strings.Join(libraries, "\n")
+}
+
+// Imports a level of detail statement: the last level of detail is stored in the metadata.
+func (i *Importer) importLevelOfDetail(l *types.LevelOfDetail, m *model.Model) {
+	m.Metadata()[model.LevelOfDetailKey] = strconv.Itoa(l.Level)
+}
+
+// The metadata keys of the interpolation statements.
+var interpolationKeys = map[parser.ElementType]string{
+	parser.BevelInterpolation:    model.BevelInterpolationKey,
+	parser.ColorInterpolation:    model.ColorInterpolationKey,
+	parser.DissolveInterpolation: model.DissolveInterpolationKey,
+}
+
+// Imports an interpolation statement: the last value of each interpolation is stored in the metadata as 'on' or 'off'.
+func (i *Importer) importInterpolation(elementType parser.ElementType, on *types.Interpolation, m *model.Model) {
+	var value = "off"
+	if *on {
+		value = "on"
+	}
+	m.Metadata()[interpolationKeys[elementType]] = value
+}
+
+// Returns the paths to the material libraries listed in the metadata,
+// resolving the relative paths against the directory of the source file.
+func materialLibraries(metadata model.Metadata) []string {
+	var names, ok = metadata[model.MaterialLibrariesKey]
+	if !ok {
+		return nil
+	}
+	var (
+		libraries = strings.Split(names, "\n")
+		source    = metadata[model.SourceKey]
+	)
+	for j, library := range libraries {
+		if source != "" && !filepath.IsAbs(library) {
+			libraries[j] = filepath.Join(filepath.Dir(source), library)
+		}
+	}
+	return libraries
+}
+
+// Reads the materials of the material libraries listed in the metadata and stores them in the ImportReport.
+// The libraries are opened by the MaterialResolver, or relative to the directory of the model if it is nil,
+// and read up to the parser.MaxIncludedSize with the limits of the lines of the parser of the model.
+// The libraries that cannot be opened are reported to the lines of their material library statements.
+func (i *Importer) loadMaterials(metadata model.Metadata, p parser.Parser) {
+	var names, ok = metadata[model.MaterialLibrariesKey]
+	if !ok {
+		return
+	}
+	var (
+		libraries = strings.Split(names, "\n")
+		resolver  = i.MaterialResolver
+	)
+	if resolver == nil {
+		resolver = parser.DirResolver(filepath.Dir(i.source))
+	}
+	i.progress(MaterialsStage, 0, len(libraries))
+	for j, name := range libraries {
+		if i.cancelled() {
+			return
+		}
+		if j > 0 {
+			i.progress(MaterialsStage, j, len(libraries))
+		}
+		var library, err = resolver.Open(name)
+		if i.MaterialResolver == nil {
+			name = i.importReport.MaterialLibraries[j]
+		}
+		if err != nil {
+			i.reportError(MaterialLibraryIssue, parser.MaterialLibrary, i.libraryLines[j], err, fmt.Sprintf("the material library is not loaded - %s", err))
+			continue
+		}
+		var reader = mtl.NewReader(parser.LimitIncluded(library))
+		i.setUpLibraryParser(reader.Parser(), name)
+		reader.Parser().SetLimits(p.Limits())
+		var materials, _ = reader.Read()
+		library.Close()
+		i.importReport.Materials = append(i.importReport.Materials, materials...)
+	}
+	i.progress(MaterialsStage, len(libraries), len(libraries))
+}
+
+// Finds the loaded materials of the materials of the model by their names and stores them in the ImportReport.
+func (i *Importer) attachMaterials(m *model.Model) {
+	var (
+		names     = m.Materials()
+		materials = make(map[string]*mtl.Material, len(i.importReport.Materials))
+	)
+	for j := len(i.importReport.Materials) - 1; j >= 0; j-- {
+		materials[i.importReport.Materials[j].Name] = i.importReport.Materials[j]
+	}
+	i.importReport.FaceMaterials = make([]*mtl.Material, len(names))
+	for j, name := range names {
+		var material, ok = materials[name]
+		if !ok {
+			i.report(UndefinedMaterialIssue, parser.UseMaterial, i.materialLines[j], fmt.Sprintf("the material '%s' is not defined in the material libraries", name))
+		}
+		i.importReport.FaceMaterials[j] = material
+	}
+}
+
+// Sets up the parser of the material library with the name to report the problems like the parser of the model,
+// but with the name of the library instead of the name of the model.
+func (i *Importer) setUpLibraryParser(p parser.Parser, name string) {
+	p.Output(nil)
+	for kind, severity := range i.ParserPolicy {
+		p.SetSeverity(kind, severity)
+	}
+	p.OnDiagnostic(func(diagnostic parser.Diagnostic) {
+		diagnostic.File = name
+		if i.OnIssue != nil {
+			i.reportDiagnostic(diagnostic)
+		}
+		switch {
+		case i.Output == nil,
+			diagnostic.Severity == parser.Info && i.IgnoreInfos,
+			diagnostic.Severity == parser.Warning && i.IgnoreWarnings,
+			diagnostic.Severity == parser.Error && i.IgnoreErrors:
+			return
+		}
+		parser.WriteDiagnostic(i.Output, diagnostic)
+	})
+}
+
+// Imports all elements of the model in a single pass in the order in which they are defined,
+// so the vertices, faces and other elements can go in any order, as written by many exporters.
+// The faces, lines and points referring to the vertices defined after them are postponed, see Importer.postpone.
+func (i *Importer) importElements(p parser.Parser, m *model.Model) {
 	var (
 		elementType parser.ElementType
 		element     interface{}
 		line        int
 	)
-	for {
+	for !i.cancelled() {
 		elementType, element = p.Next()
 		line = p.Line()
+		i.readProgress(p)
 		switch elementType {
 		case parser.Vertex:
 			i.importVertex(line, element.(*types.Vertex), m)
-		case parser.Face, parser.EndOfFile:
+		case parser.Face:
+			if !i.postpone(line, elementType, element, m) {
+				i.importFace(line, element.(*types.Face), m)
+			}
+		case parser.Line:
+			if !i.postpone(line, elementType, element, m) {
+				i.importLine(line, element.(*types.Line), m)
+			}
+		case parser.Point:
+			if !i.postpone(line, elementType, element, m) {
+				i.importPoint(line, element.(*types.Point), m)
+			}
+		case parser.VertexTexture:
+			i.importTextureVertex(element.(*types.TextureVertex), m)
+		case parser.VertexParameter:
+			i.importParameterVertex(element.(*types.ParameterVertex), m)
+		case parser.Object:
+			i.importObject(element.(*types.Object), m)
+		case parser.Group:
+			i.importGroup(element.(*types.Group), m)
+		case parser.SmoothingGroup:
+			i.importSmoothingGroup(element.(*types.SmoothingGroup), m)
+		case parser.UseMaterial:
+			i.importUseMaterial(line, element.(*types.UseMaterial), m)
+		case parser.MaterialLibrary:
+			i.importMaterialLibrary(line, element.(*types.MaterialLibrary), m)
+		case parser.LevelOfDetail:
+			i.importLevelOfDetail(element.(*types.LevelOfDetail), m)
+		case parser.BevelInterpolation, parser.ColorInterpolation, parser.DissolveInterpolation:
+			i.importInterpolation(elementType, element.(*types.Interpolation), m)
+		case parser.UseMapping:
+			i.report(TextureMapIssue, elementType, line, "texture maps are not supported, the statement will be skipped")
+		case parser.CurveSurfaceType, parser.Degree, parser.BasisMatrix, parser.Curve, parser.Curve2D, parser.Surface, parser.Parameter, parser.End,
+			parser.MergingGroup:
+			i.report(FreeFormIssue, elementType, line, "free-form curves and surfaces are not supported, the statement will be skipped")
+		case parser.EndOfFile:
 			return
 		default:
-			i.error(line, fmt.Sprintf("An impossible element was read: %s", elementType))
+			i.report(ImpossibleElementIssue, elementType, line, fmt.Sprintf("An impossible element was read: %s", elementType))
 			return
 		}
 	}
 }
 
-// Imports a single face of the model.
-func (i *Importer) importFace(line int, f *types.Face, m *model.Model) {
-	if len(f.Vertices) > 3 {
-		i.warning(line, "only triangular faces are supported, the first three vertices will be used as a triangle")
+// Postpones the face, line or point until the end of the file if it refers to a vertex that is not defined yet.
+// Returns true if the element is postponed.
+// The indices are already absolute, since the negative ones are resolved by the parser
+// against the vertices defined before the element, see parser.Parser.NormalizeIndices.
+func (i *Importer) postpone(line int, elementType parser.ElementType, element interface{}, m *model.Model) bool {
+	switch element.(type) {
+	case *types.Face, *types.Line, *types.Point:
+		if !i.refersForward(element, m) {
+			return false
+		}
+	default:
+		return false
+	}
+	i.postponed = append(i.postponed, postponedElement{
+		line:           line,
+		elementType:    elementType,
+		element:        element,
+		smoothingGroup: i.smoothingGroup,
+		material:       i.material,
+		subMesh:        i.subMesh,
+	})
+	return true
+}
+
+// Imports the postponed elements after all vertices are defined in the order in which they were read.
+// The elements referring to the vertices defined after them are reported as the ElementOrderIssue,
+// the elements referring to the vertices that are not defined at all are reported as invalid.
+// The faces are added to the end of the model with their smoothing groups and materials,
+// in the last sub-mesh if they were read in it, otherwise in the new sub-meshes with the same objects and groups
+// as the sub-meshes in which they were read.
+func (i *Importer) importPostponed(m *model.Model) {
+	if len(i.postponed) == 0 {
+		return
+	}
+	i.progress(PostponedStage, 0, len(i.postponed))
+	defer i.progress(PostponedStage, len(i.postponed), len(i.postponed))
+	var current = i.subMesh
+	for j, e := range i.postponed {
+		if i.cancelled() {
+			return
+		}
+		if j > 0 && j%progressElements == 0 {
+			i.progress(PostponedStage, j, len(i.postponed))
+		}
+		switch {
+		case e.subMesh == current:
+		case e.subMesh != nil:
+			m.StartSubMesh(e.subMesh.Object, e.subMesh.Groups...)
+		default:
+			m.StartSubMesh("")
+		}
+		current = e.subMesh
+		m.SetSmoothingGroup(e.smoothingGroup)
+		m.SetMaterial(e.material)
+		if !i.refersForward(e.element, m) {
+			i.report(ElementOrderIssue, e.elementType, e.line, "the element refers to vertices defined after it, it is imported at the end of the file")
+		}
+		switch e.elementType {
+		case parser.Face:
+			i.importFace(e.line, e.element.(*types.Face), m)
+		case parser.Line:
+			i.importLine(e.line, e.element.(*types.Line), m)
+		case parser.Point:
+			i.importPoint(e.line, e.element.(*types.Point), m)
+		}
+	}
+}
+
+// Reports whether the face, line or point refers to a vertex or a texture vertex that is not defined yet.
+// The texture vertices are not checked if they are skipped.
+func (i *Importer) refersForward(element interface{}, m *model.Model) bool {
+	if i.maxIndex(element) > m.VerticesCount() {
+		return true
+	}
+	var f, ok = element.(*types.Face)
+	if !ok || i.skipped[parser.VertexTexture] {
+		return false
+	}
+	for _, v := range f.Vertices[:i.usedVertices(f)] {
+		if v.Texture > m.TextureVerticesCount() {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the largest index of the vertices of the face, line or point.
+func (i *Importer) maxIndex(element interface{}) int {
+	var max = 0
+	switch e := element.(type) {
+	case *types.Face:
+		for _, v := range e.Vertices[:i.usedVertices(e)] {
+			if v.Index > max {
+				max = v.Index
+			}
+		}
+	case *types.Line:
+		for _, v := range e.Vertices {
+			if v.Index > max {
+				max = v.Index
+			}
+		}
+	case *types.Point:
+		for _, index := range e.Vertices {
+			if index > max {
+				max = index
+			}
+		}
 	}
-	if f.Vertices[0].Texture != 0 {
-		i.warning(line, "vertex textures are not supported")
+	return max
+}
+
+// Imports a single face of the model with the texture vertices of its corners.
+// The polygons are divided into triangles according to the Importer.Triangulation.
+func (i *Importer) importFace(line int, f *types.Face, m *model.Model) {
+	if len(f.Vertices) > 3 && i.Triangulation == NoTriangulation {
+		i.report(PolygonIssue, parser.Face, line, "only triangular faces are supported, the first three vertices will be used as a triangle")
 	}
 	if f.Vertices[0].Normal != 0 {
-		i.warning(line, "vertex normals are not supported")
+		i.report(FaceNormalIssue, parser.Face, line, "vertex normals are not supported")
 	}
-	var err = m.AppendFace(f.Vertices[0].Index, f.Vertices[1].Index, f.Vertices[2].Index)
-	if err != nil {
-		i.error(line, err.Error())
+	var key, ok = faceKey(f, i.usedVertices(f), m.VerticesCount())
+	if ok && i.faceLines != nil {
+		if original, found := i.faceLines[key]; found {
+			i.report(DuplicateFaceIssue, parser.Face, line, fmt.Sprintf("duplicate of the face at line %d, the face will be skipped", original+1))
+			i.importReport.DuplicateFaces++
+			return
+		}
+		i.faceLines[key] = line
+	}
+	var textured = i.texturedFace(line, f, m)
+	if i.usedVertices(f) == 3 {
+		i.importTriangle(line, f, [3]int{0, 1, 2}, textured, m)
+		return
+	}
+	for _, corners := range triangulate(i.Triangulation, f, m) {
+		i.importTriangle(line, f, corners, textured, m)
+	}
+}
+
+// Reports whether the texture vertices of the face are imported: the face refers to them and they are not skipped.
+// If some of the used corners refer to no texture vertex, the FaceTextureIssue is reported
+// and the face is imported without the texture vertices.
+func (i *Importer) texturedFace(line int, f *types.Face, m *model.Model) bool {
+	if f.Vertices[0].Texture == 0 || i.skipped[parser.VertexTexture] {
+		return false
+	}
+	for _, v := range f.Vertices[:i.usedVertices(f)] {
+		if _, err := m.GetTextureVertex(v.Texture); err != nil {
+			i.reportError(FaceTextureIssue, parser.Face, line, err, fmt.Sprintf("%s, the face is imported without the texture vertices", err))
+			return false
+		}
+	}
+	return true
+}
+
+// Returns the number of the vertices of the face used by the Importer: all vertices of the polygons
+// if they are triangulated, otherwise the first three vertices.
+func (i *Importer) usedVertices(f *types.Face) int {
+	if i.Triangulation == NoTriangulation {
+		return 3
 	}
+	return len(f.Vertices)
 }
 
-// Imports all faces of the model.
-func (i *Importer) importFaces(p parser.Parser, m *model.Model) {
+// Imports a single triangle of the face with the corners at the specified positions in the face,
+// with their texture vertices if the textured is true.
+func (i *Importer) importTriangle(line int, f *types.Face, corners [3]int, textured bool, m *model.Model) {
 	var (
-		elementType parser.ElementType
-		element     interface{}
-		line        int
+		a, b, c = f.Vertices[corners[0]], f.Vertices[corners[1]], f.Vertices[corners[2]]
+		err     error
 	)
-	for {
-		elementType, element = p.Next()
-		line = p.Line()
-		switch elementType {
-		case parser.Face:
-			i.importFace(line, element.(*types.Face), m)
-		case parser.Vertex:
-			i.error(line, "incorrect order of elements (vertices must be defined before faces), the vertex will be skipped")
-		case parser.EndOfFile:
-			return
+	if textured {
+		err = m.AppendTexturedFace(a.Index, b.Index, c.Index, a.Texture, b.Texture, c.Texture)
+	} else {
+		err = m.AppendFace(a.Index, b.Index, c.Index)
+	}
+	if err != nil {
+		i.reportError(InvalidFaceIssue, parser.Face, line, err, err.Error())
+	}
+}
+
+// Returns the sorted indices of the first used vertices of the face starting from 0 joined into a string,
+// which is the same for the faces consisting of the same vertices in a different order,
+// and false if some of the indices are invalid.
+// The indices must refer to the vertices of the model with the specified number of vertices.
+func faceKey(f *types.Face, used int, verticesCount int) (string, bool) {
+	var indices = make([]int, used)
+	for j, v := range f.Vertices[:used] {
+		switch {
+		case v.Index > 0 && v.Index <= verticesCount:
+			indices[j] = v.Index - 1
+		case v.Index < 0 && -v.Index <= verticesCount:
+			indices[j] = verticesCount + v.Index
 		default:
-			i.error(line, fmt.Sprintf("An impossible element was read: %s", elementType))
-			return
+			return "", false
+		}
+	}
+	sort.Ints(indices)
+	var key = make([]byte, 0, len(indices)*8)
+	for _, index := range indices {
+		key = strconv.AppendInt(append(key, ' '), int64(index), 10)
+	}
+	return string(key), true
+}
+
+// Imports a single line of the model.
+func (i *Importer) importLine(line int, l *types.Line, m *model.Model) {
+	var indices = make([]int, len(l.Vertices))
+	for j, v := range l.Vertices {
+		indices[j] = v.Index
+	}
+	if l.Vertices[0].Texture != 0 {
+		i.report(LineTextureIssue, parser.Line, line, "vertex textures are not supported")
+	}
+	if err := m.AppendLine(indices...); err != nil {
+		i.reportError(InvalidLineIssue, parser.Line, line, err, err.Error())
+	}
+}
+
+// Imports the points of a single point element of the model.
+func (i *Importer) importPoint(line int, p *types.Point, m *model.Model) {
+	for _, vertex := range p.Vertices {
+		if err := m.AppendPoint(vertex); err != nil {
+			i.reportError(InvalidPointIssue, parser.Point, line, err, err.Error())
 		}
 	}
 }
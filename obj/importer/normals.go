@@ -0,0 +1,51 @@
+package importer
+
+import "computer_graphics/model"
+
+// The kind of the NormalMode.
+type normalKind uint8
+
+// The kinds of the NormalMode.
+const (
+	noNormals     normalKind = iota // The normals are not generated.
+	flatNormals                     // The normals of the faces, see model.Model.GenerateFlatNormals.
+	angleNormals                    // The normals smoothed by the angle, see model.Model.GenerateNormalsByAngle.
+	groupsNormals                   // The normals following the smoothing groups, see model.Model.GenerateNormals.
+)
+
+// The way the normals of the corners of the faces are generated after the import, see Importer.GenerateNormals.
+// The vertex normals of the .obj files are not imported, so the normals must be generated
+// for the materials lighting the corners, like render.GouraudMaterial.
+// The zero value does not generate the normals.
+type NormalMode struct {
+	kind      normalKind // The kind of the normals.
+	threshold float64    // The largest angle between the smoothed faces in radians for the SmoothByAngle.
+}
+
+var (
+	// The normals are not generated, the default NormalMode.
+	NoNormals = NormalMode{kind: noNormals}
+	// Each corner gets the normal of its face, so all faces look flat.
+	FlatNormals = NormalMode{kind: flatNormals}
+	// The normals follow the smoothing groups of the file, the faces without the smoothing group look flat.
+	SmoothingGroupNormals = NormalMode{kind: groupsNormals}
+)
+
+// Returns the NormalMode averaging the normals of the faces at the same vertex
+// if the angle between them is at most the threshold in radians, regardless of the smoothing groups.
+// For example, SmoothByAngle(math.Pi / 3) keeps the edges of a cube sharp and makes a sphere smooth.
+func SmoothByAngle(threshold float64) NormalMode {
+	return NormalMode{kind: angleNormals, threshold: threshold}
+}
+
+// Generates the normals of the corners of the faces of the model according to the mode.
+func (mode NormalMode) generate(m *model.Model) {
+	switch mode.kind {
+	case flatNormals:
+		m.GenerateFlatNormals()
+	case angleNormals:
+		m.GenerateNormalsByAngle(mode.threshold)
+	case groupsNormals:
+		m.GenerateNormals()
+	}
+}
@@ -0,0 +1,37 @@
+package importer
+
+import "computer_graphics/obj/parser"
+
+// The stages of the import passed to the Importer.OnProgress.
+const (
+	// Reading the elements of the file, the progress is measured in bytes of the input.
+	ReadStage = "read"
+	// Importing the elements referring to the vertices defined after them, measured in elements.
+	PostponedStage = "postponed"
+	// Generating the normals of the corners of the faces, measured in faces, see Importer.GenerateNormals.
+	NormalsStage = "normals"
+	// Reading the material libraries, measured in libraries, see Importer.LoadMaterials.
+	MaterialsStage = "materials"
+)
+
+// The amounts of the work between the calls of the Importer.OnProgress during a stage.
+const (
+	progressBytes    = 1 << 20 // The number of bytes of the input read between the calls.
+	progressElements = 1 << 12 // The number of the postponed elements imported between the calls.
+)
+
+// Passes the progress of the stage to the OnProgress if it is set.
+func (i *Importer) progress(stage string, processed, total int) {
+	if i.OnProgress != nil {
+		i.OnProgress(stage, processed, total)
+	}
+}
+
+// Passes the progress of reading the input to the OnProgress if at least progressBytes were read since the last call.
+func (i *Importer) readProgress(p parser.Parser) {
+	if i.OnProgress == nil || int(p.Offset()) < i.nextProgress {
+		return
+	}
+	i.nextProgress = int(p.Offset()) + progressBytes
+	i.progress(ReadStage, int(p.Offset()), int(p.Size()))
+}
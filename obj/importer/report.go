@@ -0,0 +1,73 @@
+package importer
+
+import (
+	"computer_graphics/model"
+	"computer_graphics/obj/parser"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// The prefix of the comments describing the ImportReport, see ImportReport.WriteComments.
+const ReportCommentPrefix = "import report: "
+
+// Returns the description of the changes of the model made by the import in the text of the comments
+// without the '#' character, each starting with the ReportCommentPrefix, for example,
+// 'import report: 2 duplicate faces removed'. The statistics that are not zero are described,
+// including the elements skipped by the Importer, see ImportReport.Skipped.
+// If the import did not change anything, the only comment is 'import report: no changes'.
+func (report *ImportReport) Comments() []string {
+	var comments []string
+	var add = func(count int, format string) {
+		if count != 0 {
+			comments = append(comments, ReportCommentPrefix+fmt.Sprintf(format, count))
+		}
+	}
+	add(report.DuplicateFaces, "%d duplicate faces removed")
+	// The skipped elements are described in the order of the element types, so that the comments do not change.
+	var skipped = make([]parser.ElementType, 0, len(report.Skipped))
+	for elementType := range report.Skipped {
+		skipped = append(skipped, elementType)
+	}
+	sort.Slice(skipped, func(a, b int) bool { return skipped[a] < skipped[b] })
+	for _, elementType := range skipped {
+		add(report.Skipped[elementType], "%d "+elementType.String()+" elements not imported")
+	}
+	add(report.Stats.Skipped-report.Stats.Unsupported, "%d lines with problems skipped")
+	add(report.Stats.Unsupported, "%d statements of unsupported formats skipped")
+	add(report.Stats.Filtered, "%d statements filtered out")
+	if report.Aborted != nil {
+		comments = append(comments, ReportCommentPrefix+"the rest of the file is not imported - "+report.Aborted.Error())
+	}
+	if comments == nil {
+		comments = append(comments, ReportCommentPrefix+"no changes")
+	}
+	return comments
+}
+
+// Writes the Comments as the comment lines of the .obj file, for example, '# import report: 2 duplicate faces removed',
+// so that the consumers of the re-exported model can see what was changed by the import.
+// The comments written before the first element are imported into the header of the model,
+// from which they can be read by ReportComments.
+func (report *ImportReport) WriteComments(w io.Writer) error {
+	for _, comment := range report.Comments() {
+		if _, err := fmt.Fprintf(w, "# %s\n", comment); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Returns the comments written by ImportReport.WriteComments found in the header of the model stored
+// in the metadata by the model.HeaderKey, in the order in which they occur in the file.
+// Returns nil if the header does not contain them.
+func ReportComments(metadata map[string]string) []string {
+	var comments []string
+	for _, line := range strings.Split(metadata[model.HeaderKey], "\n") {
+		if strings.HasPrefix(line, ReportCommentPrefix) {
+			comments = append(comments, line)
+		}
+	}
+	return comments
+}
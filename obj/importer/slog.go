@@ -0,0 +1,73 @@
+//go:build go1.21
+
+package importer
+
+import (
+	"computer_graphics/model"
+	"computer_graphics/obj/parser"
+	"context"
+	"log/slog"
+)
+
+// Converts a severity of the problem to the level of the log record.
+var slogLevels = [...]slog.Level{slog.LevelInfo, slog.LevelWarn, slog.LevelError}
+
+// Makes the Importer write the problems and the summary of each import to the logger as structured records
+// instead of writing them to the Output, which is set to nil.
+// The records of the problems have the level corresponding to their severity and the attributes
+// 'source' (parser or importer), 'kind', 'file', 'line' and 'element'.
+// The summary has the Info level, or the Error level if the import was aborted, and the attributes
+// 'file', 'vertices', 'faces', 'lines', 'points', 'unsupported', 'duplicate_faces', 'infos', 'warnings', 'errors'
+// and 'error' if the import was aborted.
+// Replaces the OnIssue and OnReport functions. Returns the Importer itself.
+func (i *Importer) LogTo(logger *slog.Logger) *Importer {
+	// The numbers of the problems of each severity found in the current import.
+	var counts [len(slogLevels)]int
+	i.Output = nil
+	i.OnIssue = func(issue Issue) {
+		counts[issue.Severity]++
+		var attrs = make([]slog.Attr, 0, 5)
+		if issue.Diagnostic != nil {
+			attrs = append(attrs, slog.String("source", "parser"), slog.String("kind", issue.Diagnostic.Kind.String()))
+		} else {
+			attrs = append(attrs, slog.String("source", "importer"), slog.String("kind", issue.Kind.String()))
+		}
+		if issue.File != "" {
+			attrs = append(attrs, slog.String("file", issue.File))
+		}
+		attrs = append(attrs, slog.Int("line", issue.Line))
+		if issue.ElementType != parser.EndOfFile {
+			attrs = append(attrs, slog.String("element", issue.ElementType.String()))
+		}
+		logger.LogAttrs(context.Background(), slogLevels[issue.Severity], issue.Message, attrs...)
+	}
+	i.OnReport = func(m *model.Model, report *ImportReport) {
+		var (
+			level = slog.LevelInfo
+			msg   = "model imported"
+			attrs = make([]slog.Attr, 0, 11)
+		)
+		if source := m.Metadata()[model.SourceKey]; source != "" {
+			attrs = append(attrs, slog.String("file", source))
+		}
+		attrs = append(attrs,
+			slog.Int("vertices", m.VerticesCount()),
+			slog.Int("faces", m.FacesCount()),
+			slog.Int("lines", m.LinesCount()),
+			slog.Int("points", m.PointsCount()),
+			slog.Int("unsupported", report.Stats.Unsupported),
+			slog.Int("duplicate_faces", report.DuplicateFaces),
+			slog.Int("infos", counts[parser.Info]),
+			slog.Int("warnings", counts[parser.Warning]),
+			slog.Int("errors", counts[parser.Error]),
+		)
+		counts = [len(slogLevels)]int{}
+		if report.Aborted != nil {
+			level = slog.LevelError
+			msg = "model import aborted"
+			attrs = append(attrs, slog.String("error", report.Aborted.Error()))
+		}
+		logger.LogAttrs(context.Background(), level, msg, attrs...)
+	}
+	return i
+}
@@ -0,0 +1,164 @@
+package importer
+
+import (
+	"computer_graphics/model"
+	"computer_graphics/obj/parser/types"
+	"math"
+)
+
+// The way the faces with more than three vertices are divided into triangles, see Importer.Triangulation.
+type Triangulation uint8
+
+const (
+	// Only the first three vertices of the polygon are used as a triangle, the PolygonIssue is reported.
+	NoTriangulation Triangulation = iota
+	// The polygon is divided into the triangles sharing its first vertex, which is correct for the convex polygons.
+	FanTriangulation
+	// The ears of the polygon are cut off one by one, which is correct for the concave polygons too,
+	// if they are flat enough and do not intersect themselves.
+	EarClippingTriangulation
+)
+
+// Divides the face with more than three vertices into triangles by the method and returns the positions
+// of the corners of the triangles in the face. The triangles have the same orientation as the face.
+// The ear clipping falls back to the fan if the face refers to the vertices that do not exist or is degenerate.
+func triangulate(method Triangulation, f *types.Face, m *model.Model) [][3]int {
+	var positions = make([]int, len(f.Vertices))
+	for j := range positions {
+		positions[j] = j
+	}
+	if method == EarClippingTriangulation {
+		var indices = make([]int, len(f.Vertices))
+		for j, v := range f.Vertices {
+			indices[j] = v.Index
+		}
+		if triangles, ok := clipEars(indices, m); ok {
+			return triangles
+		}
+	}
+	return fan(positions)
+}
+
+// Divides the polygon with the corners at the positions into the triangles sharing its first corner.
+func fan(positions []int) [][3]int {
+	var triangles = make([][3]int, 0, len(positions)-2)
+	for j := 1; j+1 < len(positions); j++ {
+		triangles = append(triangles, [3]int{positions[0], positions[j], positions[j+1]})
+	}
+	return triangles
+}
+
+// Divides the polygon with the vertices at the indices into triangles by the ear clipping in the plane of the polygon
+// and returns the positions of their corners in the polygon.
+// The polygon is projected onto the coordinate plane most parallel to it, then the ears, the convex corners
+// without other vertices inside them, are cut off one by one. If no ear is found, the rest of the polygon
+// is divided as a fan. Returns false if some of the vertices do not exist or the polygon has no area.
+func clipEars(indices []int, m *model.Model) ([][3]int, bool) {
+	var vertices = make([]model.Vertex, len(indices))
+	for j, index := range indices {
+		var v, err = m.GetVertex(index)
+		if err != nil {
+			return nil, false
+		}
+		vertices[j] = v
+	}
+	var points, ok = projectPolygon(vertices)
+	if !ok {
+		return nil, false
+	}
+	var (
+		triangles = make([][3]int, 0, len(indices)-2)
+		remaining = make([]int, len(indices)) // The positions of the vertices of the rest of the polygon.
+	)
+	for j := range remaining {
+		remaining[j] = j
+	}
+	for len(remaining) > 3 {
+		var ear = -1
+		for j := range remaining {
+			if isEar(points, remaining, j) {
+				ear = j
+				break
+			}
+		}
+		if ear < 0 {
+			break
+		}
+		var (
+			previous = remaining[(ear+len(remaining)-1)%len(remaining)]
+			next     = remaining[(ear+1)%len(remaining)]
+		)
+		triangles = append(triangles, [3]int{previous, remaining[ear], next})
+		remaining = append(remaining[:ear], remaining[ear+1:]...)
+	}
+	return append(triangles, fan(remaining)...), true
+}
+
+// Projects the vertices of the polygon onto the coordinate plane most parallel to it,
+// so that the projected polygon goes counterclockwise. Returns false if the polygon has no area.
+func projectPolygon(vertices []model.Vertex) ([][2]float64, bool) {
+	// The normal of the polygon by the Newell's method, which works for the concave polygons.
+	var normal model.Vertex
+	for j, a := range vertices {
+		var b = vertices[(j+1)%len(vertices)]
+		normal.X += (a.Y - b.Y) * (a.Z + b.Z)
+		normal.Y += (a.Z - b.Z) * (a.X + b.X)
+		normal.Z += (a.X - b.X) * (a.Y + b.Y)
+	}
+	var (
+		x, y, z = math.Abs(normal.X), math.Abs(normal.Y), math.Abs(normal.Z)
+		points  = make([][2]float64, len(vertices))
+	)
+	if x == 0 && y == 0 && z == 0 {
+		return nil, false
+	}
+	for j, v := range vertices {
+		switch {
+		case x >= y && x >= z:
+			points[j] = [2]float64{v.Y, v.Z}
+			if normal.X < 0 {
+				points[j][0] = -v.Y
+			}
+		case y >= z:
+			points[j] = [2]float64{v.Z, v.X}
+			if normal.Y < 0 {
+				points[j][0] = -v.Z
+			}
+		default:
+			points[j] = [2]float64{v.X, v.Y}
+			if normal.Z < 0 {
+				points[j][0] = -v.X
+			}
+		}
+	}
+	return points, true
+}
+
+// Reports whether the corner of the polygon at the position j of the remaining vertices is an ear:
+// it is convex and no other remaining vertex is inside the triangle cut off by it.
+func isEar(points [][2]float64, remaining []int, j int) bool {
+	var (
+		count = len(remaining)
+		a     = points[remaining[(j+count-1)%count]]
+		b     = points[remaining[j]]
+		c     = points[remaining[(j+1)%count]]
+	)
+	if orientation(a, b, c) <= 0 {
+		return false
+	}
+	for k, position := range remaining {
+		if k == j || k == (j+count-1)%count || k == (j+1)%count {
+			continue
+		}
+		var p = points[position]
+		if orientation(a, b, p) >= 0 && orientation(b, c, p) >= 0 && orientation(c, a, p) >= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns the doubled signed area of the triangle, positive if it goes counterclockwise.
+func orientation(a, b, c [2]float64) float64 {
+	return (b[0]-a[0])*(c[1]-a[1]) - (b[1]-a[1])*(c[0]-a[0])
+}
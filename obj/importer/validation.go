@@ -0,0 +1,130 @@
+package importer
+
+import (
+	"computer_graphics/model"
+	"computer_graphics/obj/parser"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// The problems of the quality of the imported model, filled if the Importer.Validate is true, see ImportReport.Validation.
+// The model is imported with these problems, so that the asset pipelines can decide themselves which of them are acceptable.
+// The indices of the vertices and the faces start from 0, like the indices returned by model.Face.Indices.
+type ValidationReport struct {
+	// The faces without area: referring to the same vertex several times or with the vertices lying on a line,
+	// see model.Face.Check.
+	DegenerateFaces []int
+	// The vertices with the same coordinates as one of the vertices before them.
+	DuplicateVertices []DuplicateVertex
+	// The faces, lines and points that are not imported because some of their indices refer to no element,
+	// in the order in which they are found. The errors wrap the parser.Diagnostic of the parser.IndexIssue
+	// for the indices rejected by the parser and the model.IndexError for the ones rejected by the model.
+	DroppedElements []*ParseError
+	// The edges shared by more than two faces as the pairs of the indices of their vertices, the smaller index first.
+	// The edges are sorted by the indices of their vertices.
+	NonManifoldEdges [][2]int
+	// The vertices that are not used by any face, line or point.
+	UnreferencedVertices []int
+}
+
+// A vertex with the same coordinates as one of the vertices before it.
+type DuplicateVertex struct {
+	Index    int // The index of the vertex.
+	Original int // The index of the first vertex with the same coordinates.
+}
+
+// Reports whether no problems were found.
+func (report *ValidationReport) Valid() bool {
+	return len(report.DegenerateFaces) == 0 &&
+		len(report.DuplicateVertices) == 0 &&
+		len(report.DroppedElements) == 0 &&
+		len(report.NonManifoldEdges) == 0 &&
+		len(report.UnreferencedVertices) == 0
+}
+
+// Returns a summary of the numbers of the problems for logging.
+func (report *ValidationReport) String() string {
+	return fmt.Sprintf(
+		"degenerate faces: %d, duplicate vertices: %d, dropped elements: %d, non-manifold edges: %d, unreferenced vertices: %d",
+		len(report.DegenerateFaces),
+		len(report.DuplicateVertices),
+		len(report.DroppedElements),
+		len(report.NonManifoldEdges),
+		len(report.UnreferencedVertices),
+	)
+}
+
+// Stores the problem of the element if it is not imported because of its indices.
+func (report *ValidationReport) drop(err *ParseError) {
+	var (
+		indexErr   *model.IndexError
+		diagnostic parser.Diagnostic
+	)
+	switch {
+	case errors.As(err.Err, &indexErr):
+	case errors.As(err.Err, &diagnostic) && diagnostic.Kind == parser.IndexIssue:
+	default:
+		return
+	}
+	report.DroppedElements = append(report.DroppedElements, err)
+}
+
+// Finds the problems of the faces and the vertices of the imported model.
+func (report *ValidationReport) validate(m *model.Model) {
+	var (
+		referenced = make([]bool, m.VerticesCount())
+		edges      = make(map[[2]int]int)
+	)
+	for j := 0; j < m.FacesCount(); j++ {
+		var face = m.GetFace(j)
+		if face.Check() != nil {
+			report.DegenerateFaces = append(report.DegenerateFaces, j)
+		}
+		var v1, v2, v3 = face.Indices()
+		referenced[v1], referenced[v2], referenced[v3] = true, true, true
+		for _, edge := range [...][2]int{{v1, v2}, {v2, v3}, {v3, v1}} {
+			if edge[0] != edge[1] {
+				edges[sortedEdge(edge)]++
+			}
+		}
+	}
+	for j := 0; j < m.LinesCount(); j++ {
+		var line = m.GetLine(j)
+		for k := 0; k < line.VerticesCount(); k++ {
+			referenced[line.Index(k)] = true
+		}
+	}
+	for j := 0; j < m.PointsCount(); j++ {
+		referenced[m.PointIndex(j)] = true
+	}
+	for edge, faces := range edges {
+		if faces > 2 {
+			report.NonManifoldEdges = append(report.NonManifoldEdges, edge)
+		}
+	}
+	sort.Slice(report.NonManifoldEdges, func(a, b int) bool {
+		var ea, eb = report.NonManifoldEdges[a], report.NonManifoldEdges[b]
+		return ea[0] < eb[0] || ea[0] == eb[0] && ea[1] < eb[1]
+	})
+	var originals = make(map[model.Vertex]int)
+	for j, used := range referenced {
+		var v, _ = m.GetVertex(j + 1)
+		if original, found := originals[v]; found {
+			report.DuplicateVertices = append(report.DuplicateVertices, DuplicateVertex{Index: j, Original: original})
+		} else {
+			originals[v] = j
+		}
+		if !used {
+			report.UnreferencedVertices = append(report.UnreferencedVertices, j)
+		}
+	}
+}
+
+// Returns the edge with the smaller index of the vertex first, so that it does not depend on the direction.
+func sortedEdge(edge [2]int) [2]int {
+	if edge[1] < edge[0] {
+		return [2]int{edge[1], edge[0]}
+	}
+	return edge
+}
@@ -0,0 +1,53 @@
+package mtl
+
+// A color of the material with the components from 0 to 1.
+type Color struct {
+	R float64 // The red component.
+	G float64 // The green component.
+	B float64 // The blue component.
+}
+
+// An image file applied to a property of the material, for example, the diffuse color or the bump.
+type TextureMap struct {
+	// The name of the image file as it is written in the library, relative to the directory of the library.
+	File string
+	// The options written before the file name by their names without the '-' character,
+	// for example, {"bm": {"0.5"}, "s": {"1", "1", "1"}}, see the specification of .mtl files for their meaning.
+	Options map[string][]string
+}
+
+// A material defined in a .mtl file by the newmtl statement and the statements following it.
+type Material struct {
+	Name               string  // The name of the material used by the usemtl statements of the .obj files.
+	Ambient            Color   // The ambient reflectivity: Ka r [g b].
+	Diffuse            Color   // The diffuse reflectivity: Kd r [g b].
+	Specular           Color   // The specular reflectivity: Ks r [g b].
+	Emissive           Color   // The emitted light: Ke r [g b].
+	TransmissionFilter Color   // The colors of the light passing through the material: Tf r [g b].
+	SpecularExponent   float64 // The focus of the specular highlight: Ns exponent.
+	Dissolve           float64 // The opacity from 0 (transparent) to 1 (opaque): d factor or Tr (1 - factor).
+	OpticalDensity     float64 // The index of refraction: Ni density.
+	Sharpness          float64 // The sharpness of the reflections: sharpness value.
+	Illumination       int     // The number of the illumination model: illum number.
+
+	AmbientMap          *TextureMap // The texture of the ambient reflectivity: map_Ka [options] file.
+	DiffuseMap          *TextureMap // The texture of the diffuse reflectivity: map_Kd [options] file.
+	SpecularMap         *TextureMap // The texture of the specular reflectivity: map_Ks [options] file.
+	EmissiveMap         *TextureMap // The texture of the emitted light: map_Ke [options] file.
+	SpecularExponentMap *TextureMap // The texture of the specular exponent: map_Ns [options] file.
+	DissolveMap         *TextureMap // The texture of the opacity: map_d [options] file.
+	BumpMap             *TextureMap // The bump texture: map_Bump [options] file or bump [options] file.
+	DisplacementMap     *TextureMap // The displacement texture: disp [options] file.
+	DecalMap            *TextureMap // The decal texture: decal [options] file.
+	ReflectionMap       *TextureMap // The reflection texture: refl [options] file.
+
+	// The statements with the keywords that are not known by the package, for example, the PBR extensions,
+	// by their keywords with the rest of the line without the leading and trailing spaces.
+	Extensions map[string]string
+}
+
+// Creates a new material with the specified name and the default properties:
+// the black colors, the opaque Dissolve and the OpticalDensity of 1.
+func NewMaterial(name string) *Material {
+	return &Material{Name: name, Dissolve: 1, OpticalDensity: 1}
+}
@@ -0,0 +1,249 @@
+package mtl
+
+import (
+	"computer_graphics/obj/parser"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// The element types of the statements of .mtl files parsed by the finite state machines of the parser package.
+// The statements of the texture maps are returned by the parser as the parser.Unrecognized elements,
+// because the file names can contain the characters that the scanner does not read as words.
+var (
+	MaterialName       = parser.NewElementType("material name")       // Material name: newmtl name.
+	AmbientColor       = parser.NewElementType("ambient color")       // Ambient reflectivity: Ka r [g b].
+	DiffuseColor       = parser.NewElementType("diffuse color")       // Diffuse reflectivity: Kd r [g b].
+	SpecularColor      = parser.NewElementType("specular color")      // Specular reflectivity: Ks r [g b].
+	EmissiveColor      = parser.NewElementType("emissive color")      // Emitted light: Ke r [g b].
+	TransmissionFilter = parser.NewElementType("transmission filter") // Transmission filter: Tf r [g b].
+	SpecularExponent   = parser.NewElementType("specular exponent")   // Specular exponent: Ns exponent.
+	Dissolve           = parser.NewElementType("dissolve")            // Dissolve: d factor.
+	Transparency       = parser.NewElementType("transparency")        // Transparency: Tr factor.
+	OpticalDensity     = parser.NewElementType("optical density")     // Optical density: Ni density.
+	Sharpness          = parser.NewElementType("sharpness")           // Sharpness of the reflections: sharpness value.
+	IlluminationModel  = parser.NewElementType("illumination model")  // Illumination model: illum number.
+)
+
+// The statement of the name of the material.
+type nameStatement struct {
+	Name string `name:"material name"` // The name of the material.
+}
+
+// The statement of a color.
+type colorStatement struct {
+	Components []float64 `name:"color component" min:"1" max:"3"` // The red, green and blue components.
+}
+
+// The statement of a single number.
+type valueStatement struct {
+	Value float64 `name:"value"` // The value of the property.
+}
+
+// The statement of the illumination model.
+type illuminationStatement struct {
+	Model int `name:"illumination model"` // The number of the illumination model.
+}
+
+// The keywords and the parsers of the statements of .mtl files.
+var grammar = newGrammar()
+
+// Creates the Grammar of the statements of .mtl files.
+// Panics if a statement cannot be registered, which means an error in the prototypes.
+func newGrammar() *parser.Grammar {
+	var (
+		g          = parser.NewGrammar()
+		statements = []struct {
+			keyword     string
+			elementType parser.ElementType
+			prototype   interface{}
+		}{
+			{"newmtl", MaterialName, &nameStatement{}},
+			{"Ka", AmbientColor, &colorStatement{}},
+			{"Kd", DiffuseColor, &colorStatement{}},
+			{"Ks", SpecularColor, &colorStatement{}},
+			{"Ke", EmissiveColor, &colorStatement{}},
+			{"Tf", TransmissionFilter, &colorStatement{}},
+			{"Ns", SpecularExponent, &valueStatement{}},
+			{"d", Dissolve, &valueStatement{}},
+			{"Tr", Transparency, &valueStatement{}},
+			{"Ni", OpticalDensity, &valueStatement{}},
+			{"sharpness", Sharpness, &valueStatement{}},
+			{"illum", IlluminationModel, &illuminationStatement{}},
+		}
+	)
+	for _, statement := range statements {
+		if err := g.Register(statement.keyword, statement.elementType, statement.prototype); err != nil {
+			panic(err)
+		}
+	}
+	return g
+}
+
+// Reads the materials from a .mtl file.
+// The problems are reported by the parser.Parser, which can be configured by the Parser method,
+// the lines with problems are skipped.
+type Reader struct {
+	parser parser.Parser // Reads the statements of the file.
+}
+
+// Creates a new Reader of the .mtl file.
+// By default, the problems are written to os.Stderr, as for the .obj files.
+func NewReader(reader io.Reader) *Reader {
+	var p = parser.NewGrammarParser(reader, grammar)
+	p.PassUnknown(true)
+	return &Reader{parser: p}
+}
+
+// Returns the Parser reading the statements, so that its output, the severities of the problems
+// and other settings can be changed before reading. The PassUnknown setting must stay enabled,
+// otherwise the texture maps and the extensions are reported as unknown statements.
+func (reader *Reader) Parser() parser.Parser {
+	return reader.parser
+}
+
+// Reads all the materials of the file in the order in which they are defined.
+// The statements before the first newmtl statement do not belong to any material and are ignored.
+// The texture map statements without the file name are ignored.
+// Returns the materials read so far and the error that aborted the parsing, see parser.Parser.Err.
+func (reader *Reader) Read() ([]*Material, error) {
+	var (
+		materials []*Material
+		current   *Material
+	)
+	for {
+		var elementType, element = reader.parser.Next()
+		switch {
+		case elementType == parser.EndOfFile:
+			return materials, reader.parser.Err()
+		case elementType == MaterialName:
+			current = NewMaterial(element.(*nameStatement).Name)
+			materials = append(materials, current)
+		case current != nil:
+			current.set(elementType, element)
+		}
+	}
+}
+
+// Writes the property read from the statement of the element type to the material.
+func (material *Material) set(elementType parser.ElementType, element interface{}) {
+	switch elementType {
+	case AmbientColor:
+		material.Ambient = element.(*colorStatement).color()
+	case DiffuseColor:
+		material.Diffuse = element.(*colorStatement).color()
+	case SpecularColor:
+		material.Specular = element.(*colorStatement).color()
+	case EmissiveColor:
+		material.Emissive = element.(*colorStatement).color()
+	case TransmissionFilter:
+		material.TransmissionFilter = element.(*colorStatement).color()
+	case SpecularExponent:
+		material.SpecularExponent = element.(*valueStatement).Value
+	case Dissolve:
+		material.Dissolve = element.(*valueStatement).Value
+	case Transparency:
+		material.Dissolve = 1 - element.(*valueStatement).Value
+	case OpticalDensity:
+		material.OpticalDensity = element.(*valueStatement).Value
+	case Sharpness:
+		material.Sharpness = element.(*valueStatement).Value
+	case IlluminationModel:
+		material.Illumination = element.(*illuminationStatement).Model
+	case parser.Unrecognized:
+		material.setUnknown(element.(*parser.UnknownElement))
+	}
+}
+
+// Returns the color of the statement. If only the red component is specified,
+// the green and blue components are equal to it, the other omitted components are zero.
+func (statement *colorStatement) color() Color {
+	var c = statement.Components
+	switch len(c) {
+	case 1:
+		return Color{R: c[0], G: c[0], B: c[0]}
+	case 2:
+		return Color{R: c[0], G: c[1]}
+	default:
+		return Color{R: c[0], G: c[1], B: c[2]}
+	}
+}
+
+// Writes the texture map or the extension read from the statement with the keyword unknown to the parser.
+func (material *Material) setUnknown(element *parser.UnknownElement) {
+	var (
+		fields = strings.Fields(element.RawLine)
+		target **TextureMap
+	)
+	switch element.Keyword {
+	case "map_Ka":
+		target = &material.AmbientMap
+	case "map_Kd":
+		target = &material.DiffuseMap
+	case "map_Ks":
+		target = &material.SpecularMap
+	case "map_Ke":
+		target = &material.EmissiveMap
+	case "map_Ns":
+		target = &material.SpecularExponentMap
+	case "map_d":
+		target = &material.DissolveMap
+	case "map_Bump", "map_bump", "bump":
+		target = &material.BumpMap
+	case "disp":
+		target = &material.DisplacementMap
+	case "decal":
+		target = &material.DecalMap
+	case "refl":
+		target = &material.ReflectionMap
+	default:
+		if material.Extensions == nil {
+			material.Extensions = make(map[string]string)
+		}
+		material.Extensions[element.Keyword] = strings.Join(fields[1:], " ")
+		return
+	}
+	if textureMap := parseTextureMap(fields[1:]); textureMap != nil {
+		*target = textureMap
+	}
+}
+
+// The maximum numbers of the arguments of the texture map options, the options not listed here have one argument.
+// The arguments of the options with several arguments are read while they are numbers.
+var optionArguments = map[string]int{"mm": 2, "o": 3, "s": 3, "t": 3}
+
+// Parses the options and the file name of the texture map statement without the keyword.
+// The file name is the rest of the statement after the options, it can contain spaces.
+// Returns nil if the file name is not specified.
+func parseTextureMap(fields []string) *TextureMap {
+	var textureMap = &TextureMap{}
+	for len(fields) > 1 && strings.HasPrefix(fields[0], "-") {
+		var (
+			name  = fields[0][1:]
+			count = 1
+		)
+		if max, ok := optionArguments[name]; ok {
+			count = 0
+			for count < max && count+1 < len(fields)-1 {
+				if _, err := strconv.ParseFloat(fields[count+1], 64); err != nil {
+					break
+				}
+				count++
+			}
+		}
+		// The last field is always the file name.
+		if count > len(fields)-2 {
+			count = len(fields) - 2
+		}
+		if textureMap.Options == nil {
+			textureMap.Options = make(map[string][]string)
+		}
+		textureMap.Options[name] = append([]string(nil), fields[1:1+count]...)
+		fields = fields[1+count:]
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	textureMap.File = strings.Join(fields, " ")
+	return textureMap
+}
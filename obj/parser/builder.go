@@ -8,6 +8,7 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 )
 
 const (
@@ -82,25 +83,45 @@ type action func(token string, element reflect.Value) error
 
 // Contains complete information about the finite state machine that implements the elementParser.
 // The transition to the next state is performed by extracting it from the state table - matrix.
+// The finiteStateMachine does not store the element being read, so it can be shared by concurrent Parsers.
 type finiteStateMachine struct {
-	element reflect.Value                    // A value containing information about the element being read.
-	matrix  [][scanner.TokensCount]stateType // The transition table.
-	actions []action                         // An array of actions that are performed when transitioning to a certain state.
-	errors  [][scanner.TokensCount]string    // Array of error messages returned when transitioning to the err state.
+	elementType reflect.Type                     // The type of the element being read.
+	matrix      [][scanner.TokensCount]stateType // The transition table.
+	words       []map[string]stateType           // The transitions by the specific words, nil for the states without them.
+	actions     []action                         // An array of actions that are performed when transitioning to a certain state.
+	setters     []setter                         // The setters performing the actions, nil for the states without a value.
+	errors      [][scanner.TokensCount]string    // Array of error messages returned when transitioning to the err state.
+	defaults    []fieldDefault                   // The values of the omitted fields other than zero, see the default tag.
 }
 
-// Clears the element of finiteStateMachine to read the new line.
-// Used in the start state.
-func (m *finiteStateMachine) clear() { m.element = reflect.New(m.element.Type().Elem()) }
+// The value of the float64 field of the element structure written when the field is omitted.
+type fieldDefault struct {
+	field int     // The number of the field in the structure.
+	value float64 // The value of the field.
+}
 
 // Implementation of the transition method in the elementParser interface.
-func (m *finiteStateMachine) transition(tokenType scanner.TokenType, state stateType) stateType {
+func (m *finiteStateMachine) transition(tokenType scanner.TokenType, token string, state stateType) stateType {
+	if tokenType == scanner.Word {
+		if next, ok := m.words[state][token]; ok {
+			return next
+		}
+	}
 	return m.matrix[state][tokenType]
 }
 
+// Implementation of the newElement method in the elementParser interface.
+func (m *finiteStateMachine) newElement() interface{} {
+	var element = reflect.New(m.elementType)
+	for _, d := range m.defaults {
+		element.Elem().Field(d.field).SetFloat(d.value)
+	}
+	return element.Interface()
+}
+
 // Implementation of the action method in the elementParser interface.
-func (m *finiteStateMachine) action(state stateType, token string) error {
-	return m.actions[state](token, m.element.Elem())
+func (m *finiteStateMachine) action(state stateType, token string, element interface{}) error {
+	return m.actions[state](token, reflect.ValueOf(element).Elem())
 }
 
 // Implementation of the message method in the elementParser interface.
@@ -108,16 +129,16 @@ func (m *finiteStateMachine) message(tokenType scanner.TokenType, state stateTyp
 	return m.errors[state][tokenType]
 }
 
-// Implementation of the result method in the elementParser interface.
-func (m *finiteStateMachine) result() interface{} { return m.element.Interface() }
-
-// Creates a new finiteStateMachine that reads the specified element and has the specified size of the transition table.
-func newMachine(element reflect.Value, size int) *finiteStateMachine {
+// Creates a new finiteStateMachine that reads the elements of the specified type
+// and has the specified size of the transition table.
+func newMachine(elementType reflect.Type, size int) *finiteStateMachine {
 	return &finiteStateMachine{
-		element: element,
-		matrix:  make([][scanner.TokensCount]stateType, size),
-		actions: make([]action, size),
-		errors:  make([][scanner.TokensCount]string, size),
+		elementType: elementType,
+		matrix:      make([][scanner.TokensCount]stateType, size),
+		words:       make([]map[string]stateType, size),
+		actions:     make([]action, size),
+		setters:     make([]setter, size),
+		errors:      make([][scanner.TokensCount]string, size),
 	}
 }
 
@@ -198,7 +219,8 @@ func newDirectionTypeSetter(name string) *directionTypeSetter {
 
 // setter for converting integer values to int and writing to reflect.Value.
 type intSetter struct {
-	error error // int parsing error message.
+	error     error // int parsing error message.
+	zeroError error // The error message of the zero value, nil if the zero value is allowed, see the nonzero tag.
 }
 
 // Implementation of the set method in the setter interface.
@@ -207,6 +229,9 @@ func (s *intSetter) set(token string, value reflect.Value) error {
 	if err != nil {
 		return s.error
 	}
+	if val == 0 && s.zeroError != nil {
+		return s.zeroError
+	}
 	value.SetInt(val)
 	return nil
 }
@@ -216,9 +241,115 @@ func (s *intSetter) expected() scanner.TokenType { return scanner.Integer }
 
 // Creates a new intSetter by the parameter name.
 func newIntSetter(name string) *intSetter {
-	return &intSetter{fmt.Errorf("failed to convert the token to an integer when reading %s", name)}
+	return &intSetter{error: fmt.Errorf("failed to convert the token to an integer when reading %s", name)}
+}
+
+// Creates a new intSetter by the parameter name and the tags of its field,
+// which does not accept the zero value if the nonzero tag is set.
+func newTaggedIntSetter(name string, tags reflect.StructTag) *intSetter {
+	var s = newIntSetter(name)
+	if readNonzero(tags) {
+		s.zeroError = fmt.Errorf("%s cannot be zero", name)
+	}
+	return s
+}
+
+// setter for the parameters that can take one of the alternative words instead of the value.
+// The alternative words are written as the zero value, other tokens are converted by the setter of the value.
+type keywordSetter struct {
+	setter   setter   // The setter of the value.
+	keywords []string // The alternative words.
+	error    error    // The parsing error message.
 }
 
+// Implementation of the set method in the setter interface.
+func (s *keywordSetter) set(token string, value reflect.Value) error {
+	for _, keyword := range s.keywords {
+		if token == keyword {
+			value.Set(reflect.Zero(value.Type()))
+			return nil
+		}
+	}
+	if s.setter.set(token, value) != nil {
+		return s.error
+	}
+	return nil
+}
+
+// Implementation of the expected method in the setter interface.
+func (s *keywordSetter) expected() scanner.TokenType { return s.setter.expected() }
+
+// Creates a new keywordSetter by the parameter name, the setter of the value and the alternative words.
+func newKeywordSetter(name string, setter setter, keywords []string) *keywordSetter {
+	var (
+		value  string
+		quoted = make([]string, len(keywords))
+	)
+	switch setter.expected() {
+	case scanner.Integer:
+		value = "an integer"
+	case scanner.Float:
+		value = "a number"
+	default:
+		value = "a word"
+	}
+	for i, keyword := range keywords {
+		quoted[i] = fmt.Sprintf("'%s'", keyword)
+	}
+	return &keywordSetter{
+		setter:   setter,
+		keywords: keywords,
+		error:    fmt.Errorf("the %s parameter must be %s or %s", name, value, strings.Join(quoted, " or ")),
+	}
+}
+
+// setter for the string parameters that take only one of the specified words, see the only tag.
+type wordSetter struct {
+	words []string // The words that the parameter can take.
+	error error    // The parsing error message.
+}
+
+// Implementation of the set method in the setter interface.
+func (s *wordSetter) set(token string, value reflect.Value) error {
+	for _, word := range s.words {
+		if token == word {
+			value.SetString(token)
+			return nil
+		}
+	}
+	return s.error
+}
+
+// Implementation of the expected method in the setter interface.
+func (s *wordSetter) expected() scanner.TokenType { return scanner.Word }
+
+// Creates a new wordSetter by the parameter name and the words that it can take.
+func newWordSetter(name string, words []string) *wordSetter {
+	var quoted = make([]string, len(words))
+	for i, word := range words {
+		quoted[i] = fmt.Sprintf("'%s'", word)
+	}
+	return &wordSetter{
+		words: words,
+		error: fmt.Errorf("the %s parameter must be %s", name, strings.Join(quoted, " or ")),
+	}
+}
+
+// setter for the bool fields that are true if the prefix word is specified, see the prefix tag.
+type flagSetter struct{}
+
+// Implementation of the set method in the setter interface.
+func (s *flagSetter) set(_ string, value reflect.Value) error {
+	value.SetBool(true)
+	return nil
+}
+
+// Implementation of the expected method in the setter interface.
+func (s *flagSetter) expected() scanner.TokenType { return scanner.Word }
+
+// Creates a new flagSetter.
+func newFlagSetter() *flagSetter { return &flagSetter{} }
+
 // setter for converting float values to float64 and writing to reflect.Value.
 type floatSetter struct {
 	error error // float64 parsing error message.
@@ -252,7 +383,7 @@ func (s *stringSetter) set(token string, value reflect.Value) error {
 }
 
 // Implementation of the expected method in the setter interface.
-func (s *stringSetter) expected() scanner.TokenType { return scanner.Float }
+func (s *stringSetter) expected() scanner.TokenType { return scanner.Word }
 
 // Creates a new stringSetter.
 func newStringSetter() *stringSetter { return &stringSetter{} }
@@ -277,6 +408,26 @@ func newStructSetter(fieldNumber int, setter setter) *structSetter {
 	}
 }
 
+// Wrapper for writing a value to the element of the array with the specified index.
+// Retrieves the desired element and delegates writing to it to the nested setter.
+type arraySetter struct {
+	index  int // The index of the element to write the value to.
+	setter     // Delegate.
+}
+
+// Implementation of the set method in the setter interface.
+func (s *arraySetter) set(token string, value reflect.Value) error {
+	return s.setter.set(token, value.Index(s.index))
+}
+
+// Creates a new arraySetter.
+func newArraySetter(index int, setter setter) *arraySetter {
+	return &arraySetter{
+		index:  index,
+		setter: setter,
+	}
+}
+
 // Wrapper for writing a value to the last element of the slice.
 // Retrieves the desired element and delegates writing to it to the nested setter.
 type sliceSetter struct {
@@ -330,8 +481,9 @@ func (n parameterName) String() string { return string(n) }
 
 // A parameter for a type that requires only one state of the finite state machine.
 type baseParameter struct {
-	parameterName        // The name of the baseParameter.
-	setter        setter // A setter that writes the value in the way required for the baseParameter.
+	parameterName          // The name of the baseParameter.
+	setter        setter   // A setter that writes the value in the way required for the baseParameter.
+	keywords      []string // The alternative words that are accepted instead of the value, the setter must be able to convert them.
 }
 
 // Updating a single state of the finite state machine.
@@ -340,10 +492,13 @@ type baseParameter struct {
 func (p *baseParameter) baseUpdate(b *rowBuilder, state stateType, unread []string) {
 	var (
 		expected = p.setter.expected()
-		act      = p.setter.set
+		act      = p.setter
 	)
 	if expected == scanner.Word {
 		b.onWord(state, act)
+	} else if len(p.keywords) > 0 {
+		// The action is recorded when processing the transition by scanner.Integer.
+		b.onWord(state, nil)
 	} else {
 		b.onWordError(invalidTokenMessage(p.String(), expected, scanner.Word))
 	}
@@ -370,7 +525,13 @@ func (p *baseParameter) baseUpdate(b *rowBuilder, state stateType, unread []stri
 
 // Implementation of the update method in the parameter interface.
 func (p *baseParameter) update(b *builder) {
-	p.baseUpdate(b.nextParameterRow(p.String(), p.setter.expected()), b.nextState(), b.getUnread())
+	var row = b.nextParameterRow(p.String(), p.setter.expected())
+	p.baseUpdate(row, b.nextState(), b.getUnread())
+	// If other parameters follow, the alternative word is an alternative form of the element that ends the line,
+	// the states for it are built after all the parameters.
+	if len(p.keywords) > 0 && p.setter.expected() != scanner.Word && b.position != len(b.params)-1 {
+		b.keywordBranches = append(b.keywordBranches, keywordBranch{row: row, param: p})
+	}
 }
 
 // Creates a new baseParameter.
@@ -381,6 +542,24 @@ func newBaseParameter(name string, setter setter) *baseParameter {
 	}
 }
 
+// Creates a new baseParameter that can also take one of the alternative words instead of the value.
+func newKeywordParameter(name string, setter setter, keywords []string) *baseParameter {
+	var p = newBaseParameter(name, newKeywordSetter(name, setter, keywords))
+	p.keywords = keywords
+	return p
+}
+
+// Creates a new baseParameter of the field of the element structure with the number fieldNumber.
+// If the alternative words are specified, the parameter can also take them instead of the value.
+func newFieldParameter(fieldNumber int, name string, setter setter, keywords []string) *baseParameter {
+	if len(keywords) > 0 {
+		var p = newKeywordParameter(name, setter, keywords)
+		p.setter = newStructSetter(fieldNumber, p.setter)
+		return p
+	}
+	return newBaseParameter(name, newStructSetter(fieldNumber, setter))
+}
+
 // A parameter that generates states for reading the fields of a nested structure.
 type structParameter struct {
 	parameterName                   // The name of the structParameter.
@@ -459,6 +638,7 @@ func newStructParameter(name string, delimiter scanner.TokenType, size int) *str
 type sliceParameter struct {
 	parameterName     // The name of the sliceParameter.
 	min           int // Minimum number of slice elements.
+	max           int // Maximum number of slice elements, 0 if the number of elements is not limited.
 }
 
 // Implementation of the String method in the parameter interface.
@@ -485,8 +665,13 @@ type baseSliceParameter struct {
 }
 
 // Implementation of the update method in the parameter interface.
-// The implementation assumes that the baseSliceParameter is the last parameter of the builder.
+// The implementation assumes that the baseSliceParameter without the maximum number of elements
+// is the last parameter of the builder.
 func (p *baseSliceParameter) update(b *builder) {
+	if p.max > 0 {
+		p.updateBounded(b)
+		return
+	}
 	var (
 		name     string                      // The name of the current parameter.
 		names    = p.names()                 // The names of all required elements of the slice.
@@ -512,12 +697,45 @@ func (p *baseSliceParameter) update(b *builder) {
 		onEnd()
 }
 
+// Complements the builder with states for reading the slice with the maximum number of elements.
+// Each element is read by a separate state, the line can end after any element starting from the required ones,
+// and the elements after the required ones can be followed by the next parameter of the builder.
+func (p *baseSliceParameter) updateBounded(b *builder) {
+	var (
+		name     string                      // The name of the current parameter.
+		names    = p.names()                 // The names of all required elements of the slice.
+		unread   = b.getUnread()[1:]         // The names of the required parameters following the slice.
+		expected = p.param.setter.expected() // The type of the slice element.
+		optional = make([]*rowBuilder, 0, p.max-p.min)
+	)
+	for i := 0; i < p.max; i++ {
+		name = p.name(i)
+		var row = b.nextParameterRow(name, expected)
+		if i < p.min {
+			p.param.baseUpdate(row, b.nextState(), append(names[i:], unread...))
+		} else {
+			// The element is optional, the line can end before it if no other parameters are required.
+			p.param.baseUpdate(row, b.nextState(), unread)
+			optional = append(optional, row)
+		}
+		switch {
+		case i < p.min-1:
+			b.waitSpace(delimiterBetween(name, p.name(i+1)), append(names[i+1:], unread...))
+		case i < p.max-1:
+			b.waitSpace(tokenAfter(name), unread)
+		}
+	}
+	b.optionalElements = append(b.optionalElements, optionalElements{rows: optional})
+}
+
 // Creates a new baseSliceParameter.
-func newBaseSliceParameter(name string, min int, param *baseParameter) *baseSliceParameter {
+// The max specifies the maximum number of elements, 0 if the number of elements is not limited.
+func newBaseSliceParameter(name string, min, max int, param *baseParameter) *baseSliceParameter {
 	return &baseSliceParameter{
 		sliceParameter: sliceParameter{
 			parameterName: parameterName(name),
 			min:           min,
+			max:           max,
 		},
 		param: param,
 	}
@@ -527,6 +745,28 @@ func newBaseSliceParameter(name string, min int, param *baseParameter) *baseSlic
 type structSliceParameter struct {
 	sliceParameter                  // Basic structure.
 	param          *structParameter // A structParameter of a single slice element.
+	// The names of the required parameters following the slice with the maximum number of elements.
+	following []string
+	// The rows reading the delimiter after the last possible element of the slice with the maximum number of elements,
+	// the space leads from them to the parameter following the slice.
+	last []*rowBuilder
+	// The read states of the optional elements of the slice with the maximum number of elements.
+	optional []*rowBuilder
+}
+
+// Returns the names of the required elements of the slice starting from the specified one
+// and of the required parameters following the slice.
+func (p *structSliceParameter) unread(from int) []string {
+	var names = p.names()
+	if from > len(names) {
+		from = len(names)
+	}
+	return append(names[from:], p.following...)
+}
+
+// Returns the names of the parameters that are not specified if the line ends after the specified number of elements.
+func (p *structSliceParameter) endError(count int) string {
+	return parametersNotSpecifiedMessage(p.unread(count))
 }
 
 // Recursively called function to account for different combinations of optional parameters of a nested structure.
@@ -554,10 +794,10 @@ func (p *structSliceParameter) updateRecursive(
 			// If a non-slash was received last, then it is necessary to read the slash before the current parameter.
 			delimiterRow = b.nextDelimiterRow(delimiterBetween(p.param.name(paramNumber-1), name)).
 				onSlash(b.nextState())
-			if p.min > 1 {
-				// If the minimum number of elements of the slice is greater than one,
-				// then the end of the line means an error (the remaining elements of the slice are not read).
-				delimiterRow.onEndError(parametersNotSpecifiedMessage(sliceNames[1:]))
+			if p.min > 1 || len(p.following) > 0 {
+				// If the minimum number of elements of the slice is greater than one or other parameters follow the slice,
+				// then the end of the line means an error (the remaining elements or parameters are not read).
+				delimiterRow.onEndError(p.endError(1))
 			} else {
 				// If the minimum number of slice elements is one,
 				// then the end of the line means that only this single slice element is specified in it.
@@ -657,13 +897,27 @@ func (p *structSliceParameter) updateRecursive(
 		}
 		// If the previous parameter was not found, then a space token in the current state is possible.
 		if !lastSlash {
-			delimiterRow.onSpace(b.nextState())
+			if p.max == 1 {
+				p.last = append(p.last, delimiterRow)
+			} else {
+				delimiterRow.onSpace(b.nextState())
+			}
 		}
 	} else {
 		// All parameters processed, exit from recursion.
-		b.waitSpace(delimiterBetween(sliceNames[0], sliceNames[1]), sliceNames[1:])
+		if p.min > 1 {
+			b.waitSpace(delimiterBetween(sliceNames[0], sliceNames[1]), p.unread(1))
+		} else {
+			// If the minimum number of slice elements is one, the line can end after the first element.
+			b.waitSpace(tokenAfter(sliceNames[0]), p.following)
+			if p.max == 1 {
+				p.last = append(p.last, b.builders[len(b.builders)-1])
+			}
+		}
 	}
-	if !lastSlash {
+	if !lastSlash && p.max > 0 {
+		p.updateBounded(update, b, paramNumber)
+	} else if !lastSlash {
 		// If the last token read was not a slash,
 		// then it is necessary to read the remaining required elements of the slice
 		// corresponding to the processed combination of fields of the structure.
@@ -690,12 +944,51 @@ func (p *structSliceParameter) updateRecursive(
 	}
 }
 
+// Complements the builder with states for reading the elements of the slice with the maximum number of elements
+// after the first one, in the format of the first element built by the update function.
+// The line can end after any element starting from the required ones, and the elements after the required ones
+// can be followed by the next parameter of the builder.
+func (p *structSliceParameter) updateBounded(
+	update func(b *builder, unread []string), // A function that updates the builder with the states of an element.
+	b *builder, // Updated builder.
+	paramNumber int, // The number of the fields of the structure in the format of the first element.
+) {
+	for i := 1; i < p.max; i++ {
+		var (
+			name  = p.name(i)
+			first = b.nextState()
+		)
+		p.param.changeName(name)
+		update(b, append(p.param.allNames(paramNumber), p.unread(i)...))
+		if i >= p.min {
+			p.optional = append(p.optional, b.builders[first])
+		}
+		switch {
+		case i < p.min-1:
+			b.waitSpace(delimiterBetween(name, p.name(i+1)), p.unread(i+1))
+		case i < p.max-1:
+			b.waitSpace(tokenAfter(name), p.following)
+		case paramNumber != p.param.requiredCount:
+			b.waitSpace(tokenAfter(name), p.following)
+			p.last = append(p.last, b.builders[len(b.builders)-1])
+		default:
+			// The format of the first element with only the required fields is built last,
+			// so its last element is followed by the delimiter state built after the slice.
+		}
+	}
+}
+
 // Implementation of the update method in the parameter interface.
-// The implementation assumes that the structSliceParameter is the last parameter of the builder.
+// The implementation assumes that the structSliceParameter without the maximum number of elements
+// is the last parameter of the builder.
 func (p *structSliceParameter) update(b *builder) {
+	p.following, p.last, p.optional = nil, nil, nil
+	if p.max > 0 {
+		p.following = b.getUnread()[1:]
+	}
 	// Starts the recursive builder update process.
 	p.param.changeName(p.name(0))
-	p.param.updateRequired(b, append(p.param.requiredNames(), p.names()[1:]...))
+	p.param.updateRequired(b, append(p.param.requiredNames(), p.unread(1)...))
 	p.updateRecursive(
 		func(b *builder, unread []string) {
 			p.param.updateRequired(b, unread)
@@ -704,51 +997,76 @@ func (p *structSliceParameter) update(b *builder) {
 		p.param.requiredCount,
 		false,
 	)
+	if p.max == 0 {
+		return
+	}
+	// The delimiter state after the slice is built next, the next parameter or the end of the element follows it.
+	var next = b.nextState() + 1
+	for _, row := range p.last {
+		row.onSpace(next)
+	}
+	b.optionalElements = append(b.optionalElements, optionalElements{rows: p.optional})
 }
 
 // Creates a new structSliceParameter.
-func newStructSliceParameter(name string, min int, param *structParameter) *structSliceParameter {
+// The max specifies the maximum number of elements, 0 if the number of elements is not limited.
+func newStructSliceParameter(name string, min, max int, param *structParameter) *structSliceParameter {
 	return &structSliceParameter{
 		sliceParameter: sliceParameter{
 			parameterName: parameterName(name),
 			min:           min,
+			max:           max,
 		},
 		param: param,
 	}
 }
 
-// Stores the state and the action to be performed when switching to this state.
+// Stores the state and the setter whose action is performed when switching to this state.
 type stateAction struct {
 	state  stateType
-	action action
+	setter setter
 }
 
 // Stores information about transitions from a single state.
 type rowBuilder struct {
 	stateActionRow [scanner.TokensCount]stateAction // A row of states and actions.
 	errorsRow      [scanner.TokensCount]string      // A row of error messages.
+	wordsRow       map[string]stateAction           // The transitions by the specific words, see the prefix tag.
+}
+
+// Updates the row of states by transitioning through the specific word,
+// which takes precedence over the transition through the scanner.Word token.
+func (b *rowBuilder) onSpecificWord(word string, s stateType, a setter) *rowBuilder {
+	if b.wordsRow == nil {
+		b.wordsRow = make(map[string]stateAction)
+	}
+	b.wordsRow[word] = stateAction{
+		state:  s,
+		setter: a,
+	}
+	return b
 }
 
 // Updates the row of states by transitioning through the token without an error.
-func (b *rowBuilder) onToken(t scanner.TokenType, s stateType, a action) *rowBuilder {
+func (b *rowBuilder) onToken(t scanner.TokenType, s stateType, a setter) *rowBuilder {
 	b.stateActionRow[t] = stateAction{
 		state:  s,
-		action: a,
+		setter: a,
 	}
 	b.errorsRow[t] = noErrorMessage
 	return b
 }
 
 // Updates the row of states by transitioning through the scanner.Word token without an error.
-func (b *rowBuilder) onWord(s stateType, a action) *rowBuilder { return b.onToken(scanner.Word, s, a) }
+func (b *rowBuilder) onWord(s stateType, a setter) *rowBuilder { return b.onToken(scanner.Word, s, a) }
 
 // Updates the row of states by transitioning through the scanner.Integer token without an error.
-func (b *rowBuilder) onInteger(s stateType, a action) *rowBuilder {
+func (b *rowBuilder) onInteger(s stateType, a setter) *rowBuilder {
 	return b.onToken(scanner.Integer, s, a)
 }
 
 // Updates the row of states by transitioning through the scanner.Float token without an error.
-func (b *rowBuilder) onFloat(s stateType, a action) *rowBuilder {
+func (b *rowBuilder) onFloat(s stateType, a setter) *rowBuilder {
 	return b.onToken(scanner.Float, s, a)
 }
 
@@ -763,11 +1081,23 @@ func (b *rowBuilder) onEnd() *rowBuilder {
 	return b.onToken(scanner.EOL, start, nil).onToken(scanner.EOF, start, nil)
 }
 
+// Updates the row of states by transitioning through the scanner.EOL and scanner.EOF tokens to the warn state.
+func (b *rowBuilder) onWarn(message string) *rowBuilder {
+	for _, t := range [...]scanner.TokenType{scanner.EOL, scanner.EOF} {
+		b.stateActionRow[t] = stateAction{
+			state:  warn,
+			setter: nil,
+		}
+		b.errorsRow[t] = message
+	}
+	return b
+}
+
 // Updates the row of states by transitioning through the token to the error state.
 func (b *rowBuilder) onTokenError(t scanner.TokenType, message string) *rowBuilder {
 	b.stateActionRow[t] = stateAction{
 		state:  err,
-		action: nil,
+		setter: nil,
 	}
 	b.errorsRow[t] = message
 	return b
@@ -816,16 +1146,44 @@ func (b *rowBuilder) onCommentError(message string) *rowBuilder {
 // Creates a new rowBuilder.
 func newRowBuilder() *rowBuilder { return &rowBuilder{} }
 
+// The read state of the parameter that can take the alternative words and is followed by other parameters.
+// The alternative word ends the element, so the following parameters are read only after a value.
+type keywordBranch struct {
+	row   *rowBuilder    // The read state of the parameter.
+	param *baseParameter // The parameter that can take the alternative words.
+}
+
+// The read states of the optional elements of the slice with the maximum number of elements.
+// The tokens that are not accepted by the elements lead to the states of the parameter following the slice.
+type optionalElements struct {
+	rows []*rowBuilder // The read states of the optional elements.
+	next *rowBuilder   // The first read state of the next parameter, nil if the slice is the last parameter.
+}
+
+// The optional word before the parameters of the element, see the prefix tag.
+type prefixWord struct {
+	name   string // The name of the field.
+	word   string // The word.
+	setter setter // The setter of the field.
+}
+
 // Contains information about the element to be read.
 // Builds a finiteStateMachine based on it, which reads this element.
 type builder struct {
-	value        reflect.Value // The value returned by the finiteStateMachine.
-	valueType    ElementType   // The type of the element to be read.
-	params       []parameter   // Element parameters that update the builder with states for reading a single field of the structure.
-	paramNames   []string      // Names of required parameters.
-	position     int           // The current parameter being processed.
-	builders     []*rowBuilder // Preliminary information about the rows of the finiteStateMachine.
-	needFinalize bool          // true if need to add end-of-line processing states to the finiteStateMachine.
+	value           reflect.Value   // A pointer to a value of the type of the element to be read.
+	valueType       ElementType     // The type of the element to be read.
+	params          []parameter     // Element parameters that update the builder with states for reading a single field of the structure.
+	paramNames      []string        // Names of required parameters.
+	position        int             // The current parameter being processed.
+	builders        []*rowBuilder   // Preliminary information about the rows of the finiteStateMachine.
+	needFinalize    bool            // true if need to add end-of-line processing states to the finiteStateMachine.
+	keywordBranches []keywordBranch // The parameters for which the states of the alternative forms must be added.
+	// The optional elements of the slices with the maximum number of elements,
+	// to which the transitions to the next parameters must be added.
+	optionalElements []optionalElements
+	warning          string         // The warning reported if the last field is specified, empty if there is none, see the warn tag.
+	prefix           *prefixWord    // The optional word before the parameters, nil if there is none, see the prefix tag.
+	defaults         []fieldDefault // The values of the omitted fields other than zero, see the default tag.
 }
 
 // Creates a single parameter that reads on/off values.
@@ -862,6 +1220,116 @@ func readOptional(tags reflect.StructTag, isFirst bool) bool {
 	}
 }
 
+// Reads the default tag (the value of the omitted optional float64 field), returns false if the tag is not specified.
+func readDefault(tags reflect.StructTag, optional bool) (float64, bool) {
+	if value, ok := tags.Lookup("default"); ok {
+		if !optional {
+			panic("the default tag can only be set for the optional field")
+		}
+		if res, err := strconv.ParseFloat(value, 64); err == nil {
+			return res, true
+		} else {
+			panic("the default tag must take a float64 value")
+		}
+	} else {
+		return 0, false
+	}
+}
+
+// Reads the off tag (whether the int field can take the 'off' value).
+func readOff(tags reflect.StructTag) bool {
+	if off, ok := tags.Lookup("off"); ok {
+		if res, err := strconv.ParseBool(off); err == nil {
+			return res
+		} else {
+			panic("the off tag must take the values 'true' or 'false'")
+		}
+	} else {
+		return false
+	}
+}
+
+// Reads the warn tag (the warning reported when the field is specified) of the field with the number fieldNumber
+// in the structure with the specified number of fields, returns an empty string if the tag is not specified.
+func readWarn(tags reflect.StructTag, fieldNumber, fieldsCount int) string {
+	if warning, ok := tags.Lookup("warn"); ok {
+		if fieldNumber != fieldsCount-1 {
+			panic("the warn tag can only be set for the last field of the structure")
+		}
+		if warning == "" {
+			panic("the warn tag cannot be empty")
+		}
+		return warning
+	}
+	return ""
+}
+
+// Panics if the warn tag is present among the tags.
+func requireNoWarn(tags reflect.StructTag, typeName string) {
+	if _, ok := tags.Lookup("warn"); ok {
+		panic(fmt.Sprintf("the warn tag cannot be set for a %s field", typeName))
+	}
+}
+
+// Reads the nonzero tag (whether the int field cannot take the zero value).
+// The zero value of the optional field still means that the field is omitted.
+func readNonzero(tags reflect.StructTag) bool {
+	if nonzero, ok := tags.Lookup("nonzero"); ok {
+		if res, err := strconv.ParseBool(nonzero); err == nil {
+			return res
+		} else {
+			panic("the nonzero tag must take the values 'true' or 'false'")
+		}
+	} else {
+		return false
+	}
+}
+
+// Reads the oneof tag (the alternative words that the field can take instead of the value).
+// The off tag adds the 'off' word to them.
+func readKeywords(tags reflect.StructTag) []string {
+	var keywords []string
+	if readOff(tags) {
+		keywords = append(keywords, "off")
+	}
+	if oneof, ok := tags.Lookup("oneof"); ok {
+		for _, keyword := range strings.Split(oneof, "|") {
+			var tokenType, token = scanner.NewScanner(strings.NewReader(keyword)).Next()
+			if tokenType != scanner.Word || token != keyword {
+				panic("the oneof tag must contain words separated by '|'")
+			}
+			keywords = append(keywords, keyword)
+		}
+	}
+	return keywords
+}
+
+// Reads the only tag (whether the string field takes only the words of the oneof tag).
+func readOnly(tags reflect.StructTag) bool {
+	if only, ok := tags.Lookup("only"); ok {
+		if res, err := strconv.ParseBool(only); err == nil {
+			return res
+		} else {
+			panic("the only tag must take the values 'true' or 'false'")
+		}
+	} else {
+		return false
+	}
+}
+
+// Reads the prefix tag (the optional word before the parameters of the element).
+func readPrefix(tags reflect.StructTag) string {
+	var prefix, ok = tags.Lookup("prefix")
+	if !ok {
+		panic("the bool field must have the prefix tag specified")
+	}
+	var tokenType, token = scanner.NewScanner(strings.NewReader(prefix)).Next()
+	if tokenType != scanner.Word || token != prefix {
+		panic("the prefix tag must contain a single word")
+	}
+	return prefix
+}
+
 // Reads the delimiter tag (delimiter between parameters of the nested structure).
 func readDelimiter(tags reflect.StructTag) scanner.TokenType {
 	if delimiter, ok := tags.Lookup("delimiter"); ok {
@@ -902,6 +1370,23 @@ func requireNoOptional(tags reflect.StructTag, typeName string) {
 	}
 }
 
+// Reads the max tag (maximum number of slice elements), returns 0 if the tag is not specified.
+func readMax(tags reflect.StructTag, min int) int {
+	if max, ok := tags.Lookup("max"); ok {
+		if res, err := strconv.ParseInt(max, 10, 8); err == nil {
+			if int(res) < min {
+				panic("the max tag cannot accept values less than the min tag")
+			} else {
+				return int(res)
+			}
+		} else {
+			panic("error reading the max tag")
+		}
+	} else {
+		return 0
+	}
+}
+
 // Panics if the delimiter tag is present among the tags.
 func requireNoDelimiter(tags reflect.StructTag, typeName string) {
 	if _, ok := tags.Lookup("delimiter"); ok {
@@ -916,6 +1401,13 @@ func requireNoMin(tags reflect.StructTag, typeName string) {
 	}
 }
 
+// Panics if the max tag is present among the tags.
+func requireNoMax(tags reflect.StructTag, typeName string) {
+	if _, ok := tags.Lookup("max"); ok {
+		panic(fmt.Sprintf("the max tag cannot be set for a %s field", typeName))
+	}
+}
+
 // Panics if wasOptional is true.
 // It is necessary for all optional fields to be the last in the structure.
 func requireWasNotOptional(wasOptional bool) {
@@ -956,14 +1448,17 @@ func createNestedStructParameter(
 			}
 			hasOptional = optional
 		}
+		requireNoWarn(tags, "nested struct")
 		switch field.Type.Kind() {
 		case reflect.Int:
 			requireNoDelimiter(tags, "int")
 			requireNoMin(tags, "int")
-			param = newBaseParameter(nestedName, wrapper(i, newIntSetter(nestedName)))
+			requireNoMax(tags, "int")
+			param = newBaseParameter(nestedName, wrapper(i, newTaggedIntSetter(nestedName, tags)))
 		case reflect.Float64:
 			requireNoDelimiter(tags, "float64")
 			requireNoMin(tags, "float64")
+			requireNoMax(tags, "float64")
 			param = newBaseParameter(nestedName, wrapper(i, newFloatSetter(nestedName)))
 		default:
 			panic(fmt.Sprintf("unsupported nested struct field type: %s", field.Type.Kind()))
@@ -988,19 +1483,30 @@ func (b *builder) createStructParameters() {
 		typeName    string
 		optional    bool
 		hasOptional = false
-		min         int
+		min, max    int
 		param       parameter
 	)
-	if t.NumField() < 1 {
-		panic("the parser cannot be built on a structure without fields")
-	}
 	// Creating parameters for each field of the structure.
 	for i := 0; i < t.NumField(); i++ {
 		field = t.Field(i)
 		name = readName(&field)
 		tags = field.Tag
 		switch field.Type.Kind() {
+		case reflect.Bool:
+			typeName = "bool"
+			if i != 0 || t.NumField() == 1 {
+				panic("the bool field must be the first in the structure and be followed by other fields")
+			}
+			requireNoOptional(tags, typeName)
+			requireNoDelimiter(tags, typeName)
+			requireNoMin(tags, typeName)
+			requireNoMax(tags, typeName)
+			requireNoWarn(tags, typeName)
+			// The prefix is not a parameter, the states for it are built after all the parameters.
+			b.prefix = &prefixWord{name: name, word: readPrefix(tags), setter: newStructSetter(i, newFlagSetter())}
+			continue
 		case reflect.Uint8:
+			typeName = field.Type.Name()
 			if typeName != "DirectionType" {
 				panic("the field with the base type uint8 must have the type DirectionType")
 			}
@@ -1010,39 +1516,68 @@ func (b *builder) createStructParameters() {
 			requireNoOptional(tags, typeName)
 			requireNoDelimiter(tags, typeName)
 			requireNoMin(tags, typeName)
+			requireNoMax(tags, typeName)
+			requireNoWarn(tags, typeName)
 			param = newBaseParameter(name, newStructSetter(i, newDirectionTypeSetter(name)))
 		case reflect.Int:
 			typeName = "int"
 			requireNoDelimiter(tags, typeName)
 			requireNoMin(tags, typeName)
+			requireNoMax(tags, typeName)
 			optional = readOptional(tags, i == 0)
 			if !optional {
 				requireWasNotOptional(hasOptional)
 			}
 			hasOptional = optional
-			param = newBaseParameter(name, newStructSetter(i, newIntSetter(name)))
+			b.warning = readWarn(tags, i, t.NumField())
+			param = newFieldParameter(i, name, newTaggedIntSetter(name, tags), readKeywords(tags))
 		case reflect.Float64:
 			typeName = "float64"
 			requireNoDelimiter(tags, typeName)
 			requireNoMin(tags, typeName)
+			requireNoMax(tags, typeName)
 			optional = readOptional(tags, i == 0)
 			if !optional {
 				requireWasNotOptional(hasOptional)
 			}
 			hasOptional = optional
-			param = newBaseParameter(name, newStructSetter(i, newFloatSetter(name)))
+			if value, ok := readDefault(tags, optional); ok {
+				b.defaults = append(b.defaults, fieldDefault{field: i, value: value})
+			}
+			b.warning = readWarn(tags, i, t.NumField())
+			param = newFieldParameter(i, name, newFloatSetter(name), readKeywords(tags))
 		case reflect.String:
 			typeName = "string"
-			requireNoOptional(tags, typeName)
 			requireNoDelimiter(tags, typeName)
 			requireNoMin(tags, typeName)
-			requireWasNotOptional(hasOptional)
-			param = newBaseParameter(name, newStringSetter())
+			requireNoMax(tags, typeName)
+			optional = readOptional(tags, i == 0)
+			if !optional {
+				requireWasNotOptional(hasOptional)
+			}
+			hasOptional = optional
+			var keywords = readKeywords(tags)
+			if readOnly(tags) {
+				if len(keywords) == 0 {
+					panic("the only tag can only be set for the string field with the oneof tag")
+				}
+				b.warning = readWarn(tags, i, t.NumField())
+				param = newBaseParameter(name, newStructSetter(i, newWordSetter(name, keywords)))
+				break
+			}
+			if len(keywords) > 0 && i != t.NumField()-1 {
+				// The alternative words cannot be distinguished from the value by the token type.
+				panic("the string field with the alternative words must be the last field of the structure")
+			}
+			b.warning = readWarn(tags, i, t.NumField())
+			param = newFieldParameter(i, name, newStringSetter(), keywords)
 		case reflect.Struct:
 			typeName = "nested struct"
 			requireNoOptional(tags, typeName)
 			requireNoDelimiter(tags, typeName)
 			requireNoMin(tags, typeName)
+			requireNoMax(tags, typeName)
+			requireNoWarn(tags, typeName)
 			requireWasNotOptional(hasOptional)
 			param = createNestedStructParameter(
 				name,
@@ -1052,39 +1587,76 @@ func (b *builder) createStructParameters() {
 					return newStructSetter(i, newStructSetter(fieldNumber, setter))
 				},
 			)
-		case reflect.Slice:
-			if i != t.NumField()-1 {
-				panic("the slice must be the last field of the structure")
+		case reflect.Array:
+			typeName = "array"
+			requireNoOptional(tags, typeName)
+			requireNoDelimiter(tags, typeName)
+			requireNoMin(tags, typeName)
+			requireNoMax(tags, typeName)
+			requireNoWarn(tags, typeName)
+			requireWasNotOptional(hasOptional)
+			var newElementSetter func(name string) setter
+			switch field.Type.Elem().Kind() {
+			case reflect.Int:
+				newElementSetter = func(name string) setter { return newIntSetter(name) }
+			case reflect.Float64:
+				newElementSetter = func(name string) setter { return newFloatSetter(name) }
+			default:
+				panic(fmt.Sprintf("unsupported array element type: %s", field.Type.Elem().Kind()))
 			}
-			// The parameters of the slices themselves fill in the last states of the finite state machine.
-			b.needFinalize = false
+			if field.Type.Len() == 0 {
+				panic("the array must contain at least one element")
+			}
+			// Each element of the array is read by a separate parameter,
+			// all of them except the last one are added here, the last one is added like the parameters of other fields.
+			for k := 0; k < field.Type.Len(); k++ {
+				var elementName = fmt.Sprintf("%s number %d", name, k+1)
+				param = newBaseParameter(elementName, newStructSetter(i, newArraySetter(k, newElementSetter(elementName))))
+				if k != field.Type.Len()-1 {
+					b.params = append(b.params, param)
+					b.paramNames = append(b.paramNames, param.String())
+				}
+			}
+		case reflect.Slice:
 			requireNoOptional(tags, "slice")
+			requireNoWarn(tags, "slice")
 			requireWasNotOptional(hasOptional)
 			min = readMin(tags)
+			max = readMax(tags, min)
+			if max == 0 {
+				if i != t.NumField()-1 {
+					panic("the slice without the max tag must be the last field of the structure")
+				}
+				// The parameters of the slices themselves fill in the last states of the finite state machine.
+				b.needFinalize = false
+			}
 			switch field.Type.Elem().Kind() {
 			case reflect.Int:
 				requireNoDelimiter(tags, "[]int")
 				param = newBaseSliceParameter(
 					name,
 					min,
-					newBaseParameter("", newSliceAppender(newSliceSetter(newIntSetter("")))),
+					max,
+					newBaseParameter(name, newStructSetter(i, newSliceAppender(newSliceSetter(newTaggedIntSetter(name, tags))))),
 				)
 			case reflect.Float64:
 				requireNoDelimiter(tags, "[]float64")
 				param = newBaseSliceParameter(
 					name,
 					min,
-					newBaseParameter("", newSliceAppender(newSliceSetter(newFloatSetter("")))),
+					max,
+					newBaseParameter(name, newStructSetter(i, newSliceAppender(newSliceSetter(newFloatSetter(name))))),
 				)
 			case reflect.String:
 				requireNoDelimiter(tags, "[]string")
 				param = newBaseSliceParameter(
 					name,
 					min,
-					newBaseParameter(name, newSliceAppender(newSliceSetter(newStringSetter()))),
+					max,
+					newBaseParameter(name, newStructSetter(i, newSliceAppender(newSliceSetter(newStringSetter())))),
 				)
 			case reflect.Struct:
-				param = newStructSliceParameter(name, min, createNestedStructParameter(
+				param = newStructSliceParameter(name, min, max, createNestedStructParameter(
 					name,
 					readDelimiter(tags),
 					field.Type.Elem(),
@@ -1173,7 +1745,7 @@ func (b *builder) waitSlash(name string, unread []string) {
 	}
 }
 
-// Initializes the builder by processing the start state and err state.
+// Initializes the builder by processing the start state, err state and warn state.
 func (b *builder) initialize() {
 	b.nextEmptyRow().
 		onWordError(impossibleTokenInStartStateMessage(scanner.Word)).
@@ -1184,6 +1756,10 @@ func (b *builder) initialize() {
 		onEndError(fmt.Sprintf("all parameters of the %s are not specified", b.valueType)).
 		onUnknownError(impossibleTokenInStartStateMessage(scanner.Unknown)).
 		onCommentError(impossibleTokenInStartStateMessage(scanner.Comment))
+	// The element without parameters consists only of its name.
+	if len(b.params) == 0 {
+		b.builders[start].onEnd()
+	}
 	const parserUsedInErrorStateMessage = "parser cannot be used in the error state"
 	b.nextEmptyRow().
 		onWordError(parserUsedInErrorStateMessage).
@@ -1194,34 +1770,118 @@ func (b *builder) initialize() {
 		onEndError(parserUsedInErrorStateMessage).
 		onUnknownError(parserUsedInErrorStateMessage).
 		onCommentError(parserUsedInErrorStateMessage)
+	const parserUsedInWarnStateMessage = "parser cannot be used in the warn state"
+	b.nextEmptyRow().
+		onWordError(parserUsedInWarnStateMessage).
+		onIntegerError(parserUsedInWarnStateMessage).
+		onFloatError(parserUsedInWarnStateMessage).
+		onSlashError(parserUsedInWarnStateMessage).
+		onSpaceError(parserUsedInWarnStateMessage).
+		onEndError(parserUsedInWarnStateMessage).
+		onUnknownError(parserUsedInWarnStateMessage).
+		onCommentError(parserUsedInWarnStateMessage)
 }
 
 // Creates and fills in the states for reading the space after the element description and the end of the line.
+// The states are only reached after the last parameter, so the end of the line leads to the warn state
+// if the warning of the last field is set.
 func (b *builder) finalize() {
-	b.nextEmptyRow().
-		onWordError(impossibleTokenAfterDescribingElementMessage(b.valueType, scanner.Word)).
-		onIntegerError(impossibleTokenAfterDescribingElementMessage(b.valueType, scanner.Integer)).
-		onFloatError(impossibleTokenAfterDescribingElementMessage(b.valueType, scanner.Float)).
-		onSlashError(unexpectedTokenAfterDescribingElementMessage(b.valueType, scanner.Slash)).
+	var (
+		space = b.nextEmptyRow().
+			onWordError(impossibleTokenAfterDescribingElementMessage(b.valueType, scanner.Word)).
+			onIntegerError(impossibleTokenAfterDescribingElementMessage(b.valueType, scanner.Integer)).
+			onFloatError(impossibleTokenAfterDescribingElementMessage(b.valueType, scanner.Float)).
+			onSlashError(unexpectedTokenAfterDescribingElementMessage(b.valueType, scanner.Slash)).
+			onSpace(b.nextState()).
+			onEnd().
+			onUnknownError(impossibleTokenAfterDescribingElementMessage(b.valueType, scanner.Unknown)).
+			onCommentError(impossibleTokenAfterDescribingElementMessage(b.valueType, scanner.Unknown))
+		trailing = b.nextEmptyRow().
+				onWordError(unexpectedTokenAfterDescribingElementMessage(b.valueType, scanner.Word)).
+				onIntegerError(unexpectedTokenAfterDescribingElementMessage(b.valueType, scanner.Integer)).
+				onFloatError(unexpectedTokenAfterDescribingElementMessage(b.valueType, scanner.Float)).
+				onSlashError(unexpectedTokenAfterDescribingElementMessage(b.valueType, scanner.Slash)).
+				onSpaceError(impossibleTokenAfterDescribingElementMessage(b.valueType, scanner.Space)).
+				onEnd().
+				onUnknownError(unexpectedTokenAfterDescribingElementMessage(b.valueType, scanner.Unknown)).
+				onCommentError(impossibleTokenAfterDescribingElementMessage(b.valueType, scanner.Unknown))
+	)
+	if b.warning != "" {
+		space.onWarn(b.warning)
+		trailing.onWarn(b.warning)
+	}
+}
+
+// Creates the states for reading the end of the line after the alternative words of the parameters in builder.keywordBranches.
+// Must be called after the finalize method, the space after the alternative word leads to its last state.
+func (b *builder) buildKeywordBranches() {
+	var trailing = b.nextState() - 1
+	for _, branch := range b.keywordBranches {
+		var name = tokenAfter(fmt.Sprintf("alternative word of the %s", branch.param))
+		branch.row.onWord(b.nextState(), branch.param.setter)
+		var rb = b.nextDelimiterRow(name).
+			onSlashError(impossibleTokenMessage(name, scanner.Slash)).
+			onEnd()
+		if b.needFinalize {
+			rb.onSpace(trailing)
+		} else {
+			rb.onSpaceError(impossibleTokenMessage(name, scanner.Space))
+		}
+	}
+}
+
+// Adds the transitions to the next parameters to the read states of the optional elements in builder.optionalElements.
+// Must be called after all the states are built, the transitions are copied from the first read state of the next parameter.
+func (b *builder) linkOptionalElements() {
+	for _, elements := range b.optionalElements {
+		if elements.next == nil {
+			continue
+		}
+		for _, row := range elements.rows {
+			for _, t := range []scanner.TokenType{scanner.Word, scanner.Integer, scanner.Float} {
+				var next = elements.next.stateActionRow[t].state
+				if next == err {
+					continue
+				}
+				if row.stateActionRow[t].state != err {
+					// The token could be read both as the slice element and as the next parameter.
+					panic("the parameter following the slice with the max tag must not accept the tokens of the slice elements")
+				}
+				// The action is recorded when processing the transition from the first state of the next parameter.
+				row.onToken(t, next, nil)
+			}
+		}
+	}
+}
+
+// Creates the states for reading the prefix word in builder.prefix before the first parameter.
+// Must be called after all the states are built, the space after the word leads to the copy of the first read state,
+// so that the parameters are read after the word in the same way as without it.
+func (b *builder) buildPrefix() {
+	if b.prefix == nil {
+		return
+	}
+	var (
+		name     = tokenAfter(b.prefix.name)
+		firstRow = b.builders[first]
+	)
+	firstRow.onSpecificWord(b.prefix.word, b.nextState(), b.prefix.setter)
+	b.nextDelimiterRow(name).
+		onSlashError(invalidTokenMessage(name, scanner.Space, scanner.Slash)).
 		onSpace(b.nextState()).
-		onEnd().
-		onUnknownError(impossibleTokenAfterDescribingElementMessage(b.valueType, scanner.Unknown)).
-		onCommentError(impossibleTokenAfterDescribingElementMessage(b.valueType, scanner.Unknown))
-	b.nextEmptyRow().
-		onWordError(unexpectedTokenAfterDescribingElementMessage(b.valueType, scanner.Word)).
-		onIntegerError(unexpectedTokenAfterDescribingElementMessage(b.valueType, scanner.Integer)).
-		onFloatError(unexpectedTokenAfterDescribingElementMessage(b.valueType, scanner.Float)).
-		onSlashError(unexpectedTokenAfterDescribingElementMessage(b.valueType, scanner.Slash)).
-		onSpaceError(impossibleTokenAfterDescribingElementMessage(b.valueType, scanner.Space)).
-		onEnd().
-		onUnknownError(unexpectedTokenAfterDescribingElementMessage(b.valueType, scanner.Unknown)).
-		onCommentError(impossibleTokenAfterDescribingElementMessage(b.valueType, scanner.Unknown))
+		onEndError(parametersNotSpecifiedMessage(b.paramNames))
+	var copied = b.nextEmptyRow()
+	// The actions are recorded when processing the transitions from the first read state.
+	for t, sa := range firstRow.stateActionRow {
+		copied.stateActionRow[t] = stateAction{state: sa.state}
+	}
+	copied.errorsRow = firstRow.errorsRow
 }
 
 // Builds a state machine based on the information contained in builder.builders.
 func (b *builder) buildMachine() *finiteStateMachine {
 	var (
-		m         = newMachine(b.value, len(b.builders))
+		m         = newMachine(b.value.Type().Elem(), len(b.builders))
 		matrixRow [scanner.TokensCount]stateType
 	)
 	m.actions[start] = func(token string, element reflect.Value) error {
@@ -1230,26 +1890,37 @@ func (b *builder) buildMachine() *finiteStateMachine {
 	m.actions[err] = func(token string, element reflect.Value) error {
 		return errors.New("the action method is called in the err state")
 	}
-	// When transitioning to the first unreserved state,
-	// it is necessary to clear the value of the element that was read during the previous use of the finiteStateMachine.
-	m.actions[first] = func(token string, element reflect.Value) error {
-		m.clear()
-		return nil
+	m.actions[warn] = func(token string, element reflect.Value) error {
+		return errors.New("the action method is called in the warn state")
+	}
+	var record = func(sa stateAction) {
+		if m.actions[sa.state] == nil {
+			if sa.setter != nil {
+				m.setters[sa.state] = sa.setter
+				m.actions[sa.state] = sa.setter.set
+			}
+		} else if sa.setter != nil {
+			// The action performed during the transition to the state must be defined unambiguously.
+			panic(fmt.Sprintf("two actions are specified when transitioning to the same state: %d", sa.state))
+		}
 	}
 	// Filling in each row of the transition matrix based on elements from builder.builders.
 	for i, rb := range b.builders {
 		for j, sa := range rb.stateActionRow {
 			matrixRow[j] = sa.state
-			if m.actions[sa.state] == nil {
-				m.actions[sa.state] = sa.action
-			} else if sa.action != nil {
-				// The action performed during the transition to the state must be defined unambiguously.
-				panic(fmt.Sprintf("two actions are specified when transitioning to the same state: %d", sa.state))
+			record(sa)
+		}
+		for word, sa := range rb.wordsRow {
+			if m.words[i] == nil {
+				m.words[i] = make(map[string]stateType)
 			}
+			m.words[i][word] = sa.state
+			record(sa)
 		}
 		m.matrix[i] = matrixRow
 		m.errors[i] = rb.errorsRow
 	}
+	m.defaults = b.defaults
 	// Filling the remaining states with actions that do nothing.
 	for i := 0; i < len(m.actions); i++ {
 		if m.actions[i] == nil {
@@ -1265,7 +1936,15 @@ func (b *builder) build() *finiteStateMachine {
 	var param parameter
 	// Building preliminary information about the states of a finite state machine using parameters.
 	for b.position, param = range b.params {
+		var (
+			first    = b.nextState()
+			previous = len(b.optionalElements) - 1
+		)
 		param.update(b)
+		// The optional elements of the previous slice can be followed by this parameter.
+		if previous >= 0 && b.optionalElements[previous].next == nil {
+			b.optionalElements[previous].next = b.builders[first]
+		}
 		if b.position != len(b.params)-1 {
 			b.position++
 			b.waitSpace(delimiterBetween(param.String(), b.params[b.position].String()), b.getUnread())
@@ -1274,6 +1953,13 @@ func (b *builder) build() *finiteStateMachine {
 	if b.needFinalize {
 		b.finalize()
 	}
+	// The alternative words lead to the states built by the finalize method, which report the warning.
+	if b.warning != "" && len(b.keywordBranches) > 0 {
+		panic("the warn tag cannot be set in the structure with the alternative words")
+	}
+	b.buildKeywordBranches()
+	b.linkOptionalElements()
+	b.buildPrefix()
 	return b.buildMachine()
 }
 
@@ -1307,10 +1993,13 @@ func newBuilder(elementType ElementType, t reflect.Type) *builder {
 // The following limitations apply to the structure:
 // 	* The structure fields are extracted from the line in the order in which they are specified in the structure.
 // 	* Only public fields will be parsed.
-// 	* Structure fields must have one of the following basic types: uint8, int, float64, string, struct, []int, []float64, []string, []struct.
-// 	* If a field is of the slice type, it must be the last one in the structure.
+// 	* The structure can have no fields, then the element consists only of its name (like the end statement).
+// 	* Structure fields must have one of the following basic types: bool, uint8, int, float64, string, struct, [N]int, [N]float64, []int, []float64, []string, []struct.
+// 	* Each element of the array field is read as a separate required field, the elements are named like '{name} number {k}'.
+// 	* If a field is of the slice type without the max tag, it must be the last one in the structure.
 // 	* If a field is of the struct or []struct type, its fields must be of the base type int or float64.
 // 	* If a field is of the uint8 base type, it must be of the type DirectionType.
+// 	* If a field is of the bool type, it must be the first one in the structure and have the prefix tag.
 //
 // To specify additional information about the fields, use the following tags:
 //
@@ -1325,7 +2014,7 @@ func newBuilder(elementType ElementType, t reflect.Type) *builder {
 //	Used to specify optional fields.
 //	Optional fields must be the last fields of the structure.
 // 	All fields in the structure cannot be optional.
-// 	This tag can only be specified for fields of type int and float64.
+// 	This tag can only be specified for fields of type int, float64 and string.
 // 	If the tag value is not specified, the field is processed as required (like optional="false").
 //	These rules also apply to nested structures (fields of the struct type).
 //
@@ -1343,10 +2032,94 @@ func newBuilder(elementType ElementType, t reflect.Type) *builder {
 // 	It can only accept integer values that are greater than zero.
 // 	This tag must be specified for slices and cannot be specified for other types.
 // 	Used to specify the minimum number of slice elements.
+//
+// 	max
+//
+// 	It can only accept integer values that are not less than the value of the min tag.
+// 	This tag can be specified for the slice fields and cannot be specified for other types.
+// 	Used to specify the maximum number of slice elements.
+// 	The slice with the max tag can be followed by other fields,
+//	but the first of them must not accept the tokens of the slice elements,
+//	for example, the []float64 field can be followed by a string field, but not by an int field.
+//
+// 	off
+//
+//	It can take the values 'true' or 'false'.
+//	Used for the int fields of the element structure that can take the 'off' value instead of a number,
+//	for example, the smoothing group number. The same as the oneof tag with the 'off' word.
+//
+// 	nonzero
+//
+//	It can take the values 'true' or 'false'.
+//	Used for the int fields that cannot take the zero value, for example, the indices of the vertices,
+//	the zero value is reported as an error. The zero value of the omitted optional field means that it is omitted.
+//	This tag can only be specified for the int and []int fields, including the fields of nested structures.
+//
+// 	oneof
+//
+//	Contains the alternative words separated by '|' that the field can take instead of the value, for example, 'off|none'.
+//	Used to declare the alternative forms of the elements.
+//	This tag can only be specified for the int, float64 and string fields.
+//	The alternative words are read as the zero value of the field.
+//	If other fields follow the int or float64 field, the alternative word ends the element
+//	and the following fields remain zero, for example, the merging group statement 'mg off'.
+//	The string field with the alternative words must be the last one, because they cannot be distinguished from its value.
+// 	The tag is ignored for the fields of nested structures and slices.
+//
+// 	only
+//
+//	It can take the values 'true' or 'false'.
+//	Used for the string fields with the oneof tag that take only the words of the oneof tag,
+//	for example, the type of the curves and surfaces. The words are written to the field as they are,
+//	other words are reported as an error, so the field can be followed by other fields.
+//
+// 	prefix
+//
+//	Contains the word that can precede the parameters of the element, for example, 'rat' in the 'cstype rat bspline'.
+//	This tag must be specified for the bool field, which is true if the word is specified.
+//	The word takes precedence over the value of the first parameter, so the first parameter cannot take it.
+//
+// 	default
+//
+//	Contains the value of the optional float64 field written when the field is omitted, for example, '1'
+//	for the weight of the vertex, which is 1 by the specification. If the tag is not specified, the omitted field is zero.
+//	This tag can only be specified for the optional float64 fields of the element structure.
+//
+// 	warn
+//
+//	Contains the warning reported when the field is specified, for example, 'the weight parameter is ignored'.
+//	Used for the fields that are accepted, but deviate from the specification or are not used,
+//	the element is returned and the warning is reported as the DeviationIssue.
+//	This tag can only be specified for the last int, float64 or string field of the structure,
+//	and the structure must not have the fields with the alternative words.
+//
+// The built finite state machines are cached by the element type and the type of the element,
+// so that the same element is not built twice.
 func buildParser(elementType ElementType, element interface{}) elementParser {
 	var t = reflect.TypeOf(element)
 	if t.Kind() != reflect.Ptr {
 		panic("the element must be a pointer to a struct or bool")
 	}
-	return newBuilder(elementType, t.Elem()).build()
+	machines.Lock()
+	defer machines.Unlock()
+	var key = machineKey{elementType: elementType, t: t.Elem()}
+	if m, ok := machines.cache[key]; ok {
+		return m
+	}
+	var m = newBuilder(elementType, t.Elem()).build()
+	machines.cache[key] = m
+	return m
+}
+
+// The key of the cache of the finite state machines.
+type machineKey struct {
+	elementType ElementType  // The type of the element to be read.
+	t           reflect.Type // The type of the structure or bool describing the element.
 }
+
+// The cache of the finite state machines built by buildParser.
+// The finite state machines do not store the elements being read, so they can be shared.
+var machines = struct {
+	sync.Mutex
+	cache map[machineKey]*finiteStateMachine
+}{cache: make(map[machineKey]*finiteStateMachine)}
@@ -1,8 +1,13 @@
 package parser
 
 import (
+	"bytes"
 	"computer_graphics/obj/parser/types"
 	"computer_graphics/obj/scanner"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -18,14 +23,14 @@ func testParser(parser elementParser, want [][scanner.TokensCount]stateType, t *
 	}
 	var correct = true
 	for i := 0; i < gotDim; i++ {
-		for j := 0; j < scanner.TokensCount; j++ {
-			if got[i][j] != want[i][j] {
+		for _, tokenType := range scanner.AllTokenTypes() {
+			if got[i][tokenType] != want[i][tokenType] {
 				t.Errorf(
 					"Invalid matrix element (%d, %s), got: %d, want: %d",
 					i,
-					scanner.TokenType(j),
-					got[i][j],
-					want[i][j],
+					tokenType,
+					got[i][tokenType],
+					want[i][tokenType],
 				)
 				correct = false
 			}
@@ -42,89 +47,441 @@ func TestBuildParser_vertex(t *testing.T) {
 	var (
 		parser = buildParser(Vertex, types.NewVertex())
 		want   = [][scanner.TokensCount]stateType{
-			{1, 1, 1, 1, 2, 1, 1, 1, 1},
+			{1, 1, 1, 1, 3, 1, 1, 1, 1},
 			{1, 1, 1, 1, 1, 1, 1, 1, 1},
-			{1, 3, 3, 1, 1, 1, 1, 1, 1},
-			{1, 1, 1, 1, 4, 1, 1, 1, 1},
-			{1, 5, 5, 1, 1, 1, 1, 1, 1},
-			{1, 1, 1, 1, 6, 1, 1, 1, 1},
-			{1, 7, 7, 1, 1, 1, 1, 1, 1},
-			{1, 1, 1, 1, 8, 0, 0, 1, 1},
-			{1, 9, 9, 1, 1, 0, 0, 1, 1},
-			{1, 1, 1, 1, 10, 0, 0, 1, 1},
+			{1, 1, 1, 1, 1, 1, 1, 1, 1},
+			{1, 4, 4, 1, 1, 1, 1, 1, 1},
+			{1, 1, 1, 1, 5, 1, 1, 1, 1},
+			{1, 6, 6, 1, 1, 1, 1, 1, 1},
+			{1, 1, 1, 1, 7, 1, 1, 1, 1},
+			{1, 8, 8, 1, 1, 1, 1, 1, 1},
+			{1, 1, 1, 1, 9, 0, 0, 1, 1},
+			{1, 10, 10, 1, 1, 0, 0, 1, 1},
+			{1, 1, 1, 1, 11, 2, 2, 1, 1},
+			{1, 1, 1, 1, 1, 2, 2, 1, 1},
+		}
+	)
+	testParser(parser, want, t)
+}
+
+// Testing the elementParser of the element consisting of an on/off value.
+func TestBuildParser_bool(t *testing.T) {
+	var (
+		parser = buildParser(BevelInterpolation, types.NewInterpolation())
+		want   = [][scanner.TokensCount]stateType{
+			{1, 1, 1, 1, 3, 1, 1, 1, 1},
+			{1, 1, 1, 1, 1, 1, 1, 1, 1},
+			{1, 1, 1, 1, 1, 1, 1, 1, 1},
+			{4, 1, 1, 1, 1, 1, 1, 1, 1},
+			{1, 1, 1, 1, 5, 0, 0, 1, 1},
+			{1, 1, 1, 1, 1, 0, 0, 1, 1},
+		}
+	)
+	testParser(parser, want, t)
+}
+
+// Testing the elementParser of the element with an optional string field at the end.
+func TestBuildParser_optionalString(t *testing.T) {
+	var (
+		parser = buildParser(ShadowObject, &struct {
+			Number int
+			Name   string `optional:"true"`
+		}{})
+		want = [][scanner.TokensCount]stateType{
+			{1, 1, 1, 1, 3, 1, 1, 1, 1},
+			{1, 1, 1, 1, 1, 1, 1, 1, 1},
+			{1, 1, 1, 1, 1, 1, 1, 1, 1},
+			{1, 4, 1, 1, 1, 1, 1, 1, 1},
+			{1, 1, 1, 1, 5, 0, 0, 1, 1},
+			{6, 1, 1, 1, 1, 0, 0, 1, 1},
+			{1, 1, 1, 1, 7, 0, 0, 1, 1},
 			{1, 1, 1, 1, 1, 0, 0, 1, 1},
 		}
 	)
 	testParser(parser, want, t)
 }
 
+// Testing the elementParser of the element with an optional field reported by the warn tag
+// and the structures in which the warn tag is not allowed, the lines are tested by TestBuildParser_lines.
+func TestBuildParser_warn(t *testing.T) {
+	var (
+		parser = buildParser(ShadowObject, &struct {
+			Number int
+			Name   string `optional:"true" warn:"the name is ignored"`
+		}{})
+		want = [][scanner.TokensCount]stateType{
+			{1, 1, 1, 1, 3, 1, 1, 1, 1},
+			{1, 1, 1, 1, 1, 1, 1, 1, 1},
+			{1, 1, 1, 1, 1, 1, 1, 1, 1},
+			{1, 4, 1, 1, 1, 1, 1, 1, 1},
+			{1, 1, 1, 1, 5, 0, 0, 1, 1},
+			{6, 1, 1, 1, 1, 0, 0, 1, 1},
+			{1, 1, 1, 1, 7, 2, 2, 1, 1},
+			{1, 1, 1, 1, 1, 2, 2, 1, 1},
+		}
+	)
+	testParser(parser, want, t)
+	var invalid = []interface{}{
+		&struct {
+			Name   string `warn:"the name is ignored"`
+			Number int
+		}{},
+		&struct {
+			Number int    `off:"true"`
+			Name   string `optional:"true" warn:"the name is ignored"`
+		}{},
+		&struct {
+			Values []int `min:"1" warn:"the values are ignored"`
+		}{},
+	}
+	for _, prototype := range invalid {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("the warn tag of %T was accepted", prototype)
+				}
+			}()
+			buildParser(ShadowObject, prototype)
+		}()
+	}
+}
+
 // Testing the face elementParser.
 func TestBuildParser_face(t *testing.T) {
 	var (
 		parser = buildParser(Face, types.NewFace())
 		want   = [][scanner.TokensCount]stateType{
-			{1, 1, 1, 1, 2, 1, 1, 1, 1},
+			{1, 1, 1, 1, 3, 1, 1, 1, 1},
+			{1, 1, 1, 1, 1, 1, 1, 1, 1},
 			{1, 1, 1, 1, 1, 1, 1, 1, 1},
-			{1, 3, 1, 1, 1, 1, 1, 1, 1},
-			{1, 1, 1, 4, 55, 1, 1, 1, 1},
-			{1, 5, 1, 38, 1, 1, 1, 1, 1},
-			{1, 1, 1, 6, 26, 1, 1, 1, 1},
-			{1, 7, 1, 1, 1, 1, 1, 1, 1},
-			{1, 1, 1, 1, 8, 1, 1, 1, 1},
-			{1, 9, 1, 1, 1, 1, 1, 1, 1},
-			{1, 1, 1, 10, 1, 1, 1, 1, 1},
-			{1, 11, 1, 1, 1, 1, 1, 1, 1},
-			{1, 1, 1, 12, 1, 1, 1, 1, 1},
-			{1, 13, 1, 1, 1, 1, 1, 1, 1},
-			{1, 1, 1, 1, 14, 1, 1, 1, 1},
-			{1, 15, 1, 1, 1, 1, 1, 1, 1},
-			{1, 1, 1, 16, 1, 1, 1, 1, 1},
-			{1, 17, 1, 1, 1, 1, 1, 1, 1},
-			{1, 1, 1, 18, 1, 1, 1, 1, 1},
-			{1, 19, 1, 1, 1, 1, 1, 1, 1},
-			{1, 1, 1, 1, 20, 0, 0, 1, 1},
-			{1, 21, 1, 1, 1, 1, 1, 1, 1},
-			{1, 1, 1, 22, 1, 1, 1, 1, 1},
-			{1, 23, 1, 1, 1, 1, 1, 1, 1},
-			{1, 1, 1, 24, 1, 1, 1, 1, 1},
-			{1, 25, 1, 1, 1, 1, 1, 1, 1},
-			{1, 1, 1, 1, 20, 0, 0, 1, 1},
-			{1, 27, 1, 1, 1, 1, 1, 1, 1},
-			{1, 1, 1, 28, 1, 1, 1, 1, 1},
-			{1, 29, 1, 1, 1, 1, 1, 1, 1},
-			{1, 1, 1, 1, 30, 1, 1, 1, 1},
-			{1, 31, 1, 1, 1, 1, 1, 1, 1},
-			{1, 1, 1, 32, 1, 1, 1, 1, 1},
-			{1, 33, 1, 1, 1, 1, 1, 1, 1},
-			{1, 1, 1, 1, 34, 0, 0, 1, 1},
-			{1, 35, 1, 1, 1, 1, 1, 1, 1},
-			{1, 1, 1, 36, 1, 1, 1, 1, 1},
-			{1, 37, 1, 1, 1, 1, 1, 1, 1},
-			{1, 1, 1, 1, 34, 0, 0, 1, 1},
-			{1, 39, 1, 1, 1, 1, 1, 1, 1},
-			{1, 1, 1, 1, 40, 1, 1, 1, 1},
-			{1, 41, 1, 1, 1, 1, 1, 1, 1},
-			{1, 1, 1, 42, 1, 1, 1, 1, 1},
+			{1, 4, 1, 1, 1, 1, 1, 1, 1},
+			{1, 1, 1, 5, 56, 1, 1, 1, 1},
+			{1, 6, 1, 39, 1, 1, 1, 1, 1},
+			{1, 1, 1, 7, 27, 1, 1, 1, 1},
+			{1, 8, 1, 1, 1, 1, 1, 1, 1},
+			{1, 1, 1, 1, 9, 1, 1, 1, 1},
+			{1, 10, 1, 1, 1, 1, 1, 1, 1},
+			{1, 1, 1, 11, 1, 1, 1, 1, 1},
+			{1, 12, 1, 1, 1, 1, 1, 1, 1},
+			{1, 1, 1, 13, 1, 1, 1, 1, 1},
+			{1, 14, 1, 1, 1, 1, 1, 1, 1},
+			{1, 1, 1, 1, 15, 1, 1, 1, 1},
+			{1, 16, 1, 1, 1, 1, 1, 1, 1},
+			{1, 1, 1, 17, 1, 1, 1, 1, 1},
+			{1, 18, 1, 1, 1, 1, 1, 1, 1},
+			{1, 1, 1, 19, 1, 1, 1, 1, 1},
+			{1, 20, 1, 1, 1, 1, 1, 1, 1},
+			{1, 1, 1, 1, 21, 0, 0, 1, 1},
+			{1, 22, 1, 1, 1, 1, 1, 1, 1},
+			{1, 1, 1, 23, 1, 1, 1, 1, 1},
+			{1, 24, 1, 1, 1, 1, 1, 1, 1},
+			{1, 1, 1, 25, 1, 1, 1, 1, 1},
+			{1, 26, 1, 1, 1, 1, 1, 1, 1},
+			{1, 1, 1, 1, 21, 0, 0, 1, 1},
+			{1, 28, 1, 1, 1, 1, 1, 1, 1},
+			{1, 1, 1, 29, 1, 1, 1, 1, 1},
+			{1, 30, 1, 1, 1, 1, 1, 1, 1},
+			{1, 1, 1, 1, 31, 1, 1, 1, 1},
+			{1, 32, 1, 1, 1, 1, 1, 1, 1},
+			{1, 1, 1, 33, 1, 1, 1, 1, 1},
+			{1, 34, 1, 1, 1, 1, 1, 1, 1},
+			{1, 1, 1, 1, 35, 0, 0, 1, 1},
+			{1, 36, 1, 1, 1, 1, 1, 1, 1},
+			{1, 1, 1, 37, 1, 1, 1, 1, 1},
+			{1, 38, 1, 1, 1, 1, 1, 1, 1},
+			{1, 1, 1, 1, 35, 0, 0, 1, 1},
+			{1, 40, 1, 1, 1, 1, 1, 1, 1},
+			{1, 1, 1, 1, 41, 1, 1, 1, 1},
+			{1, 42, 1, 1, 1, 1, 1, 1, 1},
 			{1, 1, 1, 43, 1, 1, 1, 1, 1},
-			{1, 44, 1, 1, 1, 1, 1, 1, 1},
-			{1, 1, 1, 1, 45, 1, 1, 1, 1},
-			{1, 46, 1, 1, 1, 1, 1, 1, 1},
-			{1, 1, 1, 47, 1, 1, 1, 1, 1},
+			{1, 1, 1, 44, 1, 1, 1, 1, 1},
+			{1, 45, 1, 1, 1, 1, 1, 1, 1},
+			{1, 1, 1, 1, 46, 1, 1, 1, 1},
+			{1, 47, 1, 1, 1, 1, 1, 1, 1},
 			{1, 1, 1, 48, 1, 1, 1, 1, 1},
-			{1, 49, 1, 1, 1, 1, 1, 1, 1},
-			{1, 1, 1, 1, 50, 0, 0, 1, 1},
-			{1, 51, 1, 1, 1, 1, 1, 1, 1},
-			{1, 1, 1, 52, 1, 1, 1, 1, 1},
+			{1, 1, 1, 49, 1, 1, 1, 1, 1},
+			{1, 50, 1, 1, 1, 1, 1, 1, 1},
+			{1, 1, 1, 1, 51, 0, 0, 1, 1},
+			{1, 52, 1, 1, 1, 1, 1, 1, 1},
 			{1, 1, 1, 53, 1, 1, 1, 1, 1},
-			{1, 54, 1, 1, 1, 1, 1, 1, 1},
-			{1, 1, 1, 1, 50, 0, 0, 1, 1},
-			{1, 56, 1, 1, 1, 1, 1, 1, 1},
-			{1, 1, 1, 1, 57, 1, 1, 1, 1},
-			{1, 58, 1, 1, 1, 1, 1, 1, 1},
-			{1, 1, 1, 1, 59, 0, 0, 1, 1},
-			{1, 60, 1, 1, 1, 1, 1, 1, 1},
-			{1, 1, 1, 1, 59, 0, 0, 1, 1},
+			{1, 1, 1, 54, 1, 1, 1, 1, 1},
+			{1, 55, 1, 1, 1, 1, 1, 1, 1},
+			{1, 1, 1, 1, 51, 0, 0, 1, 1},
+			{1, 57, 1, 1, 1, 1, 1, 1, 1},
+			{1, 1, 1, 1, 58, 1, 1, 1, 1},
+			{1, 59, 1, 1, 1, 1, 1, 1, 1},
+			{1, 1, 1, 1, 60, 0, 0, 1, 1},
+			{1, 61, 1, 1, 1, 1, 1, 1, 1},
+			{1, 1, 1, 1, 60, 0, 0, 1, 1},
 		}
 	)
 	testParser(parser, want, t)
 }
+
+// Parses the line with the elementParser like the Parser does, starting with the space after the element name.
+// Returns the read element or the error message, the element is returned with the warning if the line ends in the warn state.
+func parseLine(parser elementParser, line string) (interface{}, string) {
+	var (
+		s                = scanner.NewScanner(strings.NewReader(line))
+		prevState, state stateType
+		element          = parser.newElement()
+	)
+	s.Next()
+	for {
+		var tokenType, token = s.Next()
+		prevState, state = state, parser.transition(tokenType, token, state)
+		switch state {
+		case start:
+			return element, ""
+		case warn:
+			return element, parser.message(tokenType, prevState)
+		case err:
+			return nil, parser.message(tokenType, prevState)
+		default:
+			if e := parser.action(state, token, element); e != nil {
+				return nil, e.Error()
+			}
+		}
+	}
+}
+
+// Testing the lines read by the element parsers: each line is read by each of the parsers of the test
+// and gives either the element with the warning, if any, or the error message.
+func TestBuildParser_lines(t *testing.T) {
+	var (
+		// The element with an optional field reported by the warn tag.
+		warned = []elementParser{buildParser(ShadowObject, &struct {
+			Number int
+			Name   string `optional:"true" warn:"the name is ignored"`
+		}{})}
+		// The element with an array field.
+		array = []elementParser{buildParser(ShadowObject, &struct {
+			Values [3]float64 `name:"value"`
+			Count  int        `name:"count"`
+		}{})}
+		// The element with the prefix word and the field taking only the specified words.
+		prefix = []elementParser{buildParser(ShadowObject, &struct {
+			Flag  bool   `name:"flag" prefix:"flag"`
+			Kind  string `name:"kind" oneof:"a|b" only:"true"`
+			Count int    `name:"count" optional:"true"`
+		}{})}
+		// The element with a slice field with the max tag followed by other fields.
+		bounded = []elementParser{buildParser(ShadowObject, &struct {
+			Values []float64 `name:"value" min:"1" max:"3"`
+			Name   string    `name:"name"`
+			Count  int       `name:"count" optional:"true"`
+		}{})}
+		// The element with a slice field with the max tag as the last field.
+		boundedLast = []elementParser{buildParser(ShadowObject, &struct {
+			Values []int `name:"value" min:"2" max:"3"`
+		}{})}
+		// The element with a slice of structures with the max tag followed by other fields.
+		boundedStructs = []elementParser{buildParser(ShadowObject, &struct {
+			Vertices []struct {
+				Index   int `name:"index"`
+				Texture int `name:"texture" optional:"true"`
+				Normal  int `name:"normal" optional:"true"`
+			} `name:"vertex" delimiter:"slash" min:"2" max:"3"`
+			Name  string `name:"name"`
+			Count int    `name:"count" optional:"true"`
+		}{})}
+		// The element with a slice of structures with the max tag as the last field.
+		boundedLastStructs = []elementParser{buildParser(ShadowObject, &struct {
+			Vertices []struct {
+				Index   int `name:"index"`
+				Texture int `name:"texture" optional:"true"`
+			} `name:"vertex" delimiter:"slash" min:"1" max:"2"`
+		}{})}
+		// The forms of the references of the face vertices: v, v/vt, v//vn and v/vt/vn,
+		// the texture and the normal are zero only if they are omitted.
+		face = []elementParser{parsersRegistry[Face], buildParser(Face, types.NewFace())}
+		// The omitted weights of the vertices take the default value of the specification, 1.
+		vertex    = []elementParser{parsersRegistry[Vertex], buildParser(Vertex, types.NewVertex())}
+		parameter = []elementParser{parsersRegistry[VertexParameter], buildParser(VertexParameter, types.NewParameterVertex())}
+	)
+	for _, test := range []struct {
+		parsers []elementParser
+		line    string
+		want    string // The read element or the error message.
+		warning string // The warning reported with the read element.
+	}{
+		{warned, "x 1", "&{1 }", ""},
+		{warned, "x 1 ", "&{1 }", ""},
+		{warned, "x 1 cube", "&{1 cube}", "the name is ignored"},
+		{warned, "x 1 cube ", "&{1 cube}", "the name is ignored"},
+		{warned, "x 1 cube 2", "unexpected token received after describing a shadow object - INTEGER", ""},
+		{array, "x 1 2.5 3 4", "&{[1 2.5 3] 4}", ""},
+		{array, "x 1 2.5 3 4 ", "&{[1 2.5 3] 4}", ""},
+		{array, "x 1 2", "parameters value number 3, count are not specified", ""},
+		{array, "x 1 a 3 4", "invalid value number 2, expected: FLOAT, received: WORD", ""},
+		{array, "x 1 2 3", "parameter count is not specified", ""},
+		{array, "x 1 2 3 4 5", "unexpected token received after describing a shadow object - INTEGER", ""},
+		{prefix, "x a", "&{false a 0}", ""},
+		{prefix, "x flag b 3", "&{true b 3}", ""},
+		{prefix, "x flag a ", "&{true a 0}", ""},
+		{prefix, "x c", "the kind parameter must be 'a' or 'b'", ""},
+		{prefix, "x flag", "parameter kind is not specified", ""},
+		{prefix, "x flag flag a", "the kind parameter must be 'a' or 'b'", ""},
+		{prefix, "x a flag", "invalid count, expected: INTEGER, received: WORD", ""},
+		{prefix, "x flag/a", "invalid token after flag, expected: SPACE, received: SLASH", ""},
+		{bounded, "x 1 a", "&{[1] a 0}", ""},
+		{bounded, "x 1 2.5 3 a 4", "&{[1 2.5 3] a 4}", ""},
+		{bounded, "x 1 2 a ", "&{[1 2] a 0}", ""},
+		{bounded, "x a", "invalid value, expected: FLOAT, received: WORD", ""},
+		{bounded, "x 1 2", "parameter name is not specified", ""},
+		{bounded, "x 1 2 ", "parameter name is not specified", ""},
+		{bounded, "x 1 2 3 4 a", "invalid name, expected: WORD, received: INTEGER", ""},
+		{bounded, "x 1 2/3 a", "invalid token after value number 2, expected: SPACE, received: SLASH", ""},
+		{boundedLast, "x 1 2", "&{[1 2]}", ""},
+		{boundedLast, "x 1 2 3 ", "&{[1 2 3]}", ""},
+		{boundedLast, "x 1", "parameter value number 2 is not specified", ""},
+		{boundedLast, "x 1 2 3 4", "unexpected token received after describing a shadow object - INTEGER", ""},
+		{boundedStructs, "x 1 2 a", "&{[{1 0 0} {2 0 0}] a 0}", ""},
+		{boundedStructs, "x 1 2 3 a 4", "&{[{1 0 0} {2 0 0} {3 0 0}] a 4}", ""},
+		{boundedStructs, "x 1/2 3/4 a", "&{[{1 2 0} {3 4 0}] a 0}", ""},
+		{boundedStructs, "x 1//3 4//6 7//9 a ", "&{[{1 0 3} {4 0 6} {7 0 9}] a 0}", ""},
+		{boundedStructs, "x 1/2/3 4/5/6 7/8/9 a 5", "&{[{1 2 3} {4 5 6} {7 8 9}] a 5}", ""},
+		{boundedStructs, "x 1", "parameters vertex number 2, name are not specified", ""},
+		{boundedStructs, "x 1 2", "parameter name is not specified", ""},
+		{boundedStructs, "x 1/2 3/4 5/6", "parameter name is not specified", ""},
+		{boundedStructs, "x 1 2 3 4 a", "invalid name, expected: WORD, received: INTEGER", ""},
+		{boundedStructs, "x 1/2/3 4/5/6 7/8/9 10/11/12 a", "invalid name, expected: WORD, received: INTEGER", ""},
+		{boundedLastStructs, "x 1", "&{[{1 0}]}", ""},
+		{boundedLastStructs, "x 1/2 3/4 ", "&{[{1 2} {3 4}]}", ""},
+		{boundedLastStructs, "x 1/2 3", "parameter texture of the vertex number 2 is not specified", ""},
+		{boundedLastStructs, "x 1 2 3", "unexpected token received after describing a shadow object - INTEGER", ""},
+		{boundedLastStructs, "x 1/2 3/4 5/6", "unexpected token received after describing a shadow object - INTEGER", ""},
+		{face, "f 1 2 3", "&{[{1 0 0} {2 0 0} {3 0 0}]}", ""},
+		{face, "f 1/4 2/5 3/6", "&{[{1 4 0} {2 5 0} {3 6 0}]}", ""},
+		{face, "f 1//4 2//5 3//6", "&{[{1 0 4} {2 0 5} {3 0 6}]}", ""},
+		{face, "f 1/4/7 2/5/8 3/6/9", "&{[{1 4 7} {2 5 8} {3 6 9}]}", ""},
+		{face, "f -1//-1 -2//-2 -3//-3", "&{[{-1 0 -1} {-2 0 -2} {-3 0 -3}]}", ""},
+		{face, "f 1//4 2/5 3//6", "the texture is specified for the vertex number 2, but is not specified for the first vertex", ""},
+		{face, "f 1/4 2//5 3/6", "the texture is not specified for the vertex number 2, but is specified for the first vertex", ""},
+		{face, "f 1/0 2/5 3/6", "texture cannot be zero", ""},
+		{face, "f 1//0 2//5 3//6", "normal cannot be zero", ""},
+		{face, "f 1// 2// 3//", "invalid normal of the vertex number 1, expected: INTEGER, received: SPACE", ""},
+		{vertex, "v 1 2 3", "&{1 2 3 1}", ""},
+		{vertex, "v 1 2 3 0.5", "&{1 2 3 0.5}", "the weight parameter is only used by the rational curves and surfaces"},
+		{vertex, "v 1 2 3 1 ", "&{1 2 3 1}", "the weight parameter is only used by the rational curves and surfaces"},
+		{parameter, "vp 0.5", "&{0.5 0 1}", ""},
+		{parameter, "vp 0.5 0.2", "&{0.5 0.2 1}", ""},
+		{parameter, "vp 0.5 0.2 2", "&{0.5 0.2 2}", ""},
+	} {
+		for _, p := range test.parsers {
+			var (
+				element, message = parseLine(p, test.line)
+				got, warning     = message, ""
+			)
+			if element != nil {
+				got, warning = fmt.Sprint(element), message
+			}
+			if got != test.want || warning != test.warning {
+				t.Errorf("%T %q: got: %s (%q), want: %s (%q)", p, test.line, got, warning, test.want, test.warning)
+			}
+		}
+	}
+}
+
+// Testing that the slice with the max tag cannot be followed by a field accepting the same tokens.
+func TestBuildParser_ambiguousBoundedSlice(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("the ambiguous structure was accepted")
+		}
+	}()
+	buildParser(ShadowObject, &struct {
+		Values []float64 `name:"value" min:"1" max:"3"`
+		Count  int       `name:"count"`
+	}{})
+}
+
+// Testing that the finite state machines are built once for each element type and type of the element.
+func TestBuildParser_cache(t *testing.T) {
+	if buildParser(Vertex, types.NewVertex()) != parsersRegistry[Vertex].(*generatedParser).finiteStateMachine {
+		t.Error("the finite state machine of the vertex was built again")
+	}
+	if buildParser(BevelInterpolation, types.NewInterpolation()) == buildParser(ColorInterpolation, types.NewInterpolation()) {
+		t.Error("the finite state machines of different element types are shared")
+	}
+}
+
+// Testing that the Parsers sharing the finite state machines can be used concurrently.
+func TestParser_concurrent(t *testing.T) {
+	const (
+		parsersCount = 8
+		linesCount   = 100
+	)
+	var results = make(chan []interface{}, parsersCount)
+	for i := 0; i < parsersCount; i++ {
+		go func(i int) {
+			var text strings.Builder
+			for j := 0; j < linesCount; j++ {
+				fmt.Fprintf(&text, "v %d %d 0\ns %d\n", i, j, j)
+			}
+			var (
+				parser   = NewParser(strings.NewReader(text.String()))
+				elements []interface{}
+			)
+			for elementType, element := parser.Next(); elementType != EndOfFile; elementType, element = parser.Next() {
+				elements = append(elements, element)
+			}
+			results <- elements
+		}(i)
+	}
+	for i := 0; i < parsersCount; i++ {
+		var (
+			elements = <-results
+			x        = elements[0].(*types.Vertex).X
+		)
+		if len(elements) != 2*linesCount {
+			t.Fatalf("got %d elements, want %d", len(elements), 2*linesCount)
+		}
+		for j := 0; j < linesCount; j++ {
+			var v = elements[2*j].(*types.Vertex)
+			if v.X != x || v.Y != float64(j) {
+				t.Errorf("vertex %d of the parser %g: got: %v", j, x, *v)
+			}
+			if group := elements[2*j+1].(*types.SmoothingGroup); group.Number != j {
+				t.Errorf("smoothing group %d of the parser %g: got: %v", j, x, *group)
+			}
+		}
+	}
+}
+
+// Testing that the generated parsers are up to date and read the same elements as the finite state machines.
+func TestGenerateParsers(t *testing.T) {
+	var source bytes.Buffer
+	if err := GenerateParsers(&source); err != nil {
+		t.Fatal(err)
+	}
+	var generated, err = os.ReadFile("parsers_generated.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(source.Bytes(), generated) {
+		t.Error("parsers_generated.go is out of date, run go generate")
+	}
+	var lines = []string{
+		"v 1 2 3", "v 1 x 3", "v 1 2 3 0.5", "vt 0.5", "vp 1 2 3", "f 1/2/3 4//6 7/8", "f 1 2", "f 1 2 3 x",
+		"l 1/1 2/2 3", "p 1 2 3", "p", "s off", "s 4", "s x", "mg 1 0.5", "mg off", "bevel on", "c_interp maybe",
+		"usemap off", "usemap wood", "usemtl steel", "mtllib a.mtl b.mtl", "o name", "g a b", "lod 3", "lod 1.5",
+		"cstype rat bspline", "cstype cube", "cstype rat", "deg 3", "deg 3 x", "bmat u 1 2 3 4", "bmat w 1", "curv 0 1 1 2",
+		"curv2 1 2", "surf 0 1 0 1 1/1 2/2", "parm u 0 0.5 1", "parm x 1", "end",
+	}
+	for _, line := range lines {
+		var elementType, _ = ElementTypeOf(strings.Fields(line)[0])
+		var p, ok = parsersRegistry[elementType].(*generatedParser)
+		if !ok {
+			t.Errorf("the parser of the %s is not generated", elementType)
+			continue
+		}
+		var (
+			gotElement, gotMessage   = parseLine(p, line)
+			wantElement, wantMessage = parseLine(p.finiteStateMachine, line)
+		)
+		if !reflect.DeepEqual(gotElement, wantElement) || gotMessage != wantMessage {
+			t.Errorf("%q: got: %v %q, want: %v %q", line, gotElement, gotMessage, wantElement, wantMessage)
+		}
+	}
+}
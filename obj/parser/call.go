@@ -0,0 +1,176 @@
+package parser
+
+import (
+	"computer_graphics/obj/scanner"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Opens the files referenced by the .obj files, for example, by the call statements, see Parser.Resolve.
+type FileResolver interface {
+	// Opens the file by the name written in the referencing file.
+	Open(name string) (io.ReadCloser, error)
+}
+
+// FileResolver that opens the files relative to the directory, usually the directory of the main .obj file.
+// The absolute names are opened as they are.
+type DirResolver string
+
+// Implementation of the Open method in the FileResolver interface.
+func (dir DirResolver) Open(name string) (io.ReadCloser, error) {
+	if !filepath.IsAbs(name) {
+		name = filepath.Join(string(dir), name)
+	}
+	return os.Open(name)
+}
+
+// The maximum number of the nested call statements, so that the files calling each other are not read forever.
+const maxCallDepth = 16
+
+// The maximum size of a file included by the .obj file, like the called file or the material library, in bytes,
+// so that a huge or endless included file is not read into the memory, see LimitIncluded.
+const MaxIncludedSize = 64 << 20
+
+// The error of the included file larger than MaxIncludedSize.
+var ErrIncludedTooLarge = fmt.Errorf("the included file is larger than %d bytes", MaxIncludedSize)
+
+// Returns the reader of the included file opened by the FileResolver, that fails with the ErrIncludedTooLarge
+// when more than MaxIncludedSize bytes are read, so that the included files are read with the same limit.
+func LimitIncluded(reader io.Reader) io.Reader {
+	return &includedReader{reader: reader, left: MaxIncludedSize}
+}
+
+// Reader of an included file that fails when more than the limit of the bytes are read.
+type includedReader struct {
+	reader io.Reader
+	left   int64 // The number of the bytes that can still be read.
+}
+
+// Implementation of the Read method in the io.Reader interface.
+func (r *includedReader) Read(p []byte) (int, error) {
+	if r.left <= 0 {
+		// The reader is checked for more bytes, so that the file of exactly the limit size is read.
+		var n, err = r.reader.Read(make([]byte, 1))
+		if n > 0 {
+			return 0, ErrIncludedTooLarge
+		}
+		return 0, err
+	}
+	if int64(len(p)) > r.left {
+		p = p[:r.left]
+	}
+	var n, err = r.reader.Read(p)
+	r.left -= int64(n)
+	return n, err
+}
+
+// Implementation of the Resolve method in the Parser interface.
+func (parser *parser) Resolve(resolver FileResolver) {
+	parser.resolver = resolver
+}
+
+// Reads the rest of the call statement, whose keyword is the token, and opens the called file,
+// so that its elements are returned by the next calls of the Next method.
+// The problems with the called file are reported as the CallIssue and the call statement is skipped.
+func (parser *parser) call(token string) {
+	var (
+		read       = parser.scanner.LineString()
+		column     = parser.scanner.Column() - len(token) + 2
+		rest, _    = parser.scanner.SkipLine()
+		arguments  = strings.Fields(rest)
+		diagnostic = Diagnostic{
+			Kind:     CallIssue,
+			Severity: parser.Severity(CallIssue),
+			File:     parser.file,
+			Line:     parser.scanner.Line() + 1,
+			Column:   column + len(token),
+			Token:    "eol",
+			LineText: read + rest,
+		}
+	)
+	if len(arguments) == 0 {
+		diagnostic.Message = "the name of the called file is not specified"
+		parser.report(diagnostic)
+		return
+	}
+	diagnostic.Token = arguments[0]
+	diagnostic.Column += strings.Index(rest, arguments[0])
+	if parser.depth >= maxCallDepth {
+		diagnostic.Message = "too deeply nested call statements"
+		parser.report(diagnostic)
+		return
+	}
+	var text, err = parser.readCalled(arguments[0])
+	if err != nil {
+		diagnostic.Message = "failed to read the called file - " + err.Error()
+		parser.report(diagnostic)
+		return
+	}
+	// The arguments replace the placeholders starting from the greatest number, so that $1 does not replace the part of $12.
+	var placeholders []string
+	for i := len(arguments) - 1; i > 0; i-- {
+		placeholders = append(placeholders, "$"+strconv.Itoa(i), arguments[i])
+	}
+	parser.called = newCalledParser(parser, arguments[0], strings.NewReplacer(placeholders...).Replace(text))
+}
+
+// Creates the Parser of the called file with the specified name and text, inheriting the settings of the caller.
+func newCalledParser(caller *parser, name, text string) *parser {
+	var called = &parser{
+		scanner:            scanner.NewScanner(strings.NewReader(text)),
+		size:               -1,
+		policy:             caller.policy,
+		ignoreInfos:        caller.ignoreInfos,
+		ignoreWarnings:     caller.ignoreWarnings,
+		ignoreErrors:       caller.ignoreErrors,
+		unsupportedHandler: caller.unsupportedHandler,
+		filter:             caller.filter,
+		resolver:           caller.resolver,
+		file:               name,
+		depth:              caller.depth + 1,
+		normalizeIndices:   caller.normalizeIndices,
+		forwardIndices:     caller.forwardIndices,
+		counts:             caller.counts,
+		caseInsensitive:    caller.caseInsensitive,
+		passUnknown:        caller.passUnknown,
+		grammar:            caller.grammar,
+		// The diagnostics are written, collected and counted by the caller,
+		// so that the limits of the errors and the strict mode apply to all the files together.
+		diagnosticHandler: caller.report,
+	}
+	called.OnComment(caller.commentHandler)
+	called.SetLimits(caller.Limits())
+	return called
+}
+
+// Reads the whole called file, so that the placeholders of the arguments can be replaced.
+// The file is read up to the MaxIncludedSize, see LimitIncluded.
+func (parser *parser) readCalled(name string) (string, error) {
+	var file, err = parser.resolver.Open(name)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	data, err := io.ReadAll(LimitIncluded(file))
+	return string(data), err
+}
+
+// Returns the next element of the called file.
+// Returns (EndOfFile, nil) and forgets the called file when its end is reached or the parsing is aborted.
+func (parser *parser) nextCalled() (ElementType, interface{}) {
+	var elementType, element = parser.called.Next()
+	if elementType == EndOfFile || parser.failure != nil {
+		parser.stats.Filtered += parser.called.stats.Filtered
+		parser.called = nil
+		return EndOfFile, nil
+	}
+	if parser.stats.Elements == nil {
+		parser.stats.Elements = make(map[ElementType]int)
+	}
+	parser.stats.Elements[elementType]++
+	return elementType, element
+}
@@ -0,0 +1,270 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// The kind of the statement form in the conformance corpus.
+type conformanceForm uint8
+
+const (
+	validForm    conformanceForm = iota // The form is allowed by the specification and is parsed.
+	invalidForm                         // The form is not allowed by the specification and is rejected.
+	rejectedForm                        // The form is allowed by the specification, but is rejected by the partial support.
+)
+
+// A statement of the conformance corpus.
+type conformanceCase struct {
+	statement   string          // The text of the statement.
+	elementType ElementType     // The element type of the statement.
+	form        conformanceForm // The kind of the form of the statement.
+}
+
+// The conformance corpus covering every statement of the .obj specification.
+var conformanceCorpus = []conformanceCase{
+	{"v 1 2 3", Vertex, validForm},
+	{"v -1.5 2.0 0.5 1.0", Vertex, validForm},
+	{"v 1 2", Vertex, invalidForm},
+	{"v 1 2 3 4 5", Vertex, invalidForm},
+	{"v 1 two 3", Vertex, invalidForm},
+	{"vt 0.5", VertexTexture, validForm},
+	{"vt 0.5 0.25 1", VertexTexture, validForm},
+	{"vt", VertexTexture, invalidForm},
+	{"vt 0.5 0.25 1 1", VertexTexture, invalidForm},
+	{"vn 0 0 1", VertexNormal, validForm},
+	{"vn 0 1", VertexNormal, invalidForm},
+	{"vp 0.5", VertexParameter, validForm},
+	{"vp 0.5 0.25 1", VertexParameter, validForm},
+	{"vp", VertexParameter, invalidForm},
+	{"cstype bspline", CurveSurfaceType, validForm},
+	{"cstype rat bezier", CurveSurfaceType, validForm},
+	{"cstype", CurveSurfaceType, invalidForm},
+	{"cstype 1", CurveSurfaceType, invalidForm},
+	{"cstype rat", CurveSurfaceType, invalidForm},
+	{"cstype banana split pie", CurveSurfaceType, invalidForm},
+	{"cstype bezier bspline", CurveSurfaceType, invalidForm},
+	{"deg 3", Degree, validForm},
+	{"deg 3 3", Degree, validForm},
+	{"deg", Degree, invalidForm},
+	{"deg 3 3 3", Degree, invalidForm},
+	{"bmat u 1 0 0 1", BasisMatrix, validForm},
+	{"bmat v 1 0 0 0 1 0 0 0 1", BasisMatrix, validForm},
+	{"bmat", BasisMatrix, invalidForm},
+	{"bmat w 1 0 0 1", BasisMatrix, invalidForm},
+	{"bmat u", BasisMatrix, invalidForm},
+	{"step 1", Step, validForm},
+	{"step 1 2", Step, validForm},
+	{"step", Step, invalidForm},
+	{"p 1", Point, validForm},
+	{"p 1 2 -3", Point, validForm},
+	{"p", Point, invalidForm},
+	{"p 1.5", Point, invalidForm},
+	{"l 1 2", Line, validForm},
+	{"l 1/1 2/2 3/3", Line, validForm},
+	{"l 1", Line, invalidForm},
+	{"l 1/1 2", Line, invalidForm},
+	{"f 1 2 3", Face, validForm},
+	{"f 1/1 2/2 3/3 4/4", Face, validForm},
+	{"f 1/1/1 2/2/2 3/3/3", Face, validForm},
+	{"f 1//1 2//2 3//3", Face, validForm},
+	{"f -3 -2 -1", Face, validForm},
+	{"f 1 2", Face, invalidForm},
+	{"f 1/1 2 3", Face, invalidForm},
+	{"f 1 2 3.5", Face, invalidForm},
+	{"f 0 1 2", Face, invalidForm},
+	{"f 1/0/1 2/0/2 3/0/3", Face, invalidForm},
+	{"f 1//1 2/2 3//3", Face, invalidForm},
+	{"f 1// 2// 3//", Face, invalidForm},
+	{"l 1//1 2//2", Line, invalidForm},
+	{"curv 0.0 1.0 1 2", Curve, validForm},
+	{"curv 0.0 1.0 1", Curve, invalidForm},
+	{"curv 1 2", Curve, invalidForm},
+	{"curv2 1 2", Curve2D, validForm},
+	{"curv2 1", Curve2D, invalidForm},
+	{"surf 0.0 1.0 0.0 1.0 1 2 3 4", Surface, validForm},
+	{"surf 0.0 1.0 0.0 1.0 1/1/1 2/2/2", Surface, validForm},
+	{"surf 0.0 1.0 0.0 1.0", Surface, invalidForm},
+	{"parm u 0.0 1.0", Parameter, validForm},
+	{"parm v 0.0 0.5 1.0", Parameter, validForm},
+	{"parm w 0.0 1.0", Parameter, invalidForm},
+	{"parm u 0.0", Parameter, invalidForm},
+	{"trim 0.0 1.0 1", Trim, validForm},
+	{"trim", Trim, invalidForm},
+	{"hole 0.0 1.0 1", Hole, validForm},
+	{"hole", Hole, invalidForm},
+	{"scrv 0.0 1.0 1", SpecialCurve, validForm},
+	{"scrv", SpecialCurve, invalidForm},
+	{"sp 1 2", SpecialPoint, validForm},
+	{"sp", SpecialPoint, invalidForm},
+	{"end", End, validForm},
+	{"end 1", End, invalidForm},
+	{"con 1 0.0 1.0 1 2 0.0 1.0 2", Connect, validForm},
+	{"con", Connect, invalidForm},
+	{"g cube", Group, validForm},
+	{"g cube front", Group, validForm},
+	{"g 1st", Group, rejectedForm},
+	{"g", Group, rejectedForm},
+	{"g cube 1", Group, invalidForm},
+	{"s 1", SmoothingGroup, validForm},
+	{"s off", SmoothingGroup, validForm},
+	{"s", SmoothingGroup, invalidForm},
+	{"s 1.5", SmoothingGroup, invalidForm},
+	{"mg 1 0.5", MergingGroup, validForm},
+	{"mg off", MergingGroup, validForm},
+	{"mg", MergingGroup, invalidForm},
+	{"mg 1", MergingGroup, invalidForm},
+	{"mg off 0.5", MergingGroup, invalidForm},
+	{"mg on", MergingGroup, invalidForm},
+	{"o cube", Object, validForm},
+	{"o 1st", Object, rejectedForm},
+	{"o", Object, invalidForm},
+	{"o cube sphere", Object, invalidForm},
+	{"bevel on", BevelInterpolation, validForm},
+	{"bevel off", BevelInterpolation, validForm},
+	{"bevel 1", BevelInterpolation, invalidForm},
+	{"c_interp on", ColorInterpolation, validForm},
+	{"c_interp 1", ColorInterpolation, invalidForm},
+	{"d_interp off", DissolveInterpolation, validForm},
+	{"d_interp 1", DissolveInterpolation, invalidForm},
+	{"lod 10", LevelOfDetail, validForm},
+	{"lod", LevelOfDetail, invalidForm},
+	{"lod 1.5", LevelOfDetail, invalidForm},
+	{"maplib textures.map", MapLibrary, validForm},
+	{"maplib", MapLibrary, invalidForm},
+	{"usemap wood", UseMapping, validForm},
+	{"usemap off", UseMapping, validForm},
+	{"usemap", UseMapping, invalidForm},
+	{"usemap wood off", UseMapping, invalidForm},
+	{"usemtl wood", UseMaterial, validForm},
+	{"usemtl Material.001", UseMaterial, validForm},
+	{"usemtl 1st", UseMaterial, rejectedForm},
+	{"usemtl", UseMaterial, invalidForm},
+	{"mtllib cube.mtl", MaterialLibrary, validForm},
+	{"mtllib cube.mtl common.mtl", MaterialLibrary, validForm},
+	{"mtllib ../materials/cube.mtl", MaterialLibrary, rejectedForm},
+	{"mtllib", MaterialLibrary, invalidForm},
+	{"shadow_obj shadow.obj", ShadowObject, validForm},
+	{"shadow_obj", ShadowObject, invalidForm},
+	{"trace_obj trace.obj", TraceObject, validForm},
+	{"trace_obj", TraceObject, invalidForm},
+	{"ctech cparm 1.0", CurveApproximation, validForm},
+	{"ctech", CurveApproximation, invalidForm},
+	{"stech cparma 1.0 1.0", SurfaceApproximation, validForm},
+	{"stech", SurfaceApproximation, invalidForm},
+	{"call cube.mod 1 2", Call, validForm},
+	{"call", Call, invalidForm},
+	{"scmp cube.mod 1 2", Scmp, validForm},
+	{"scmp", Scmp, invalidForm},
+	{"csh ls", Csh, validForm},
+	{"csh -ls", Csh, validForm},
+	{"csh", Csh, invalidForm},
+}
+
+// Checks every statement of the conformance corpus against the support level of its element type.
+func TestParser_conformance(t *testing.T) {
+	for _, c := range conformanceCorpus {
+		var (
+			parser      = NewParser(strings.NewReader(c.statement))
+			unsupported bool
+			level       = SupportLevel(c.elementType)
+		)
+		parser.Output(nil)
+		parser.OnUnsupported(func(line int, text string) {
+			unsupported = true
+		})
+		var elementType, _ = parser.Next()
+		switch {
+		case level == NotSupported:
+			if !unsupported {
+				t.Errorf("%q: the %s is %s, but the statement was not reported as unsupported", c.statement, c.elementType, level)
+			}
+		case c.form == validForm:
+			if elementType != c.elementType {
+				t.Errorf("%q: the valid statement was not parsed, got: %s, want: %s", c.statement, elementType, c.elementType)
+			}
+		case c.form == rejectedForm:
+			if elementType != EndOfFile || unsupported {
+				t.Errorf("%q: the statement was expected to be rejected by the partial support, got: %s", c.statement, elementType)
+			}
+		default:
+			if elementType != EndOfFile || unsupported {
+				t.Errorf("%q: the invalid statement was not rejected, got: %s", c.statement, elementType)
+			}
+		}
+	}
+}
+
+// Checks that the corpus covers every element type and that the rejected forms match the support levels.
+func TestSupportLevel_corpus(t *testing.T) {
+	var valid, rejected = map[ElementType]bool{}, map[ElementType]bool{}
+	for _, c := range conformanceCorpus {
+		switch c.form {
+		case validForm:
+			valid[c.elementType] = true
+		case rejectedForm:
+			rejected[c.elementType] = true
+		}
+	}
+	for elementType := Vertex; elementType < EndOfFile; elementType++ {
+		var level = SupportLevel(elementType)
+		if !valid[elementType] {
+			t.Errorf("the corpus does not contain valid statements of the %s", elementType)
+		}
+		if level == PartiallySupported && !rejected[elementType] {
+			t.Errorf("the %s is %s, but the corpus does not contain rejected statements of it", elementType, level)
+		}
+		if level == FullySupported && rejected[elementType] {
+			t.Errorf("the %s is %s, but the corpus contains rejected statements of it", elementType, level)
+		}
+	}
+}
+
+// Generates the report of the support of the element types.
+func ExampleSupportLevel() {
+	for elementType := Vertex; elementType < EndOfFile; elementType++ {
+		fmt.Printf("%s: %s\n", elementType, SupportLevel(elementType))
+	}
+	// Output:
+	//vertex: fully supported
+	//vertex texture: fully supported
+	//vertex normal: not supported
+	//vertex parameter: fully supported
+	//curve surface type: fully supported
+	//degree: fully supported
+	//basis matrix: fully supported
+	//step: not supported
+	//point: fully supported
+	//line: fully supported
+	//face: fully supported
+	//curve: fully supported
+	//curve 2D: fully supported
+	//surface: fully supported
+	//parameter: fully supported
+	//trim: not supported
+	//hole: not supported
+	//special curve: not supported
+	//special point: not supported
+	//end: fully supported
+	//connect: not supported
+	//group: partially supported
+	//smoothing group: fully supported
+	//merging group: fully supported
+	//object: partially supported
+	//bevel interpolation: fully supported
+	//color interpolation: fully supported
+	//dissolve interpolation: fully supported
+	//level of detail: fully supported
+	//map library: not supported
+	//use mapping: fully supported
+	//use material: partially supported
+	//material library: partially supported
+	//shadow object: not supported
+	//trace object: not supported
+	//curve approximation technique: not supported
+	//surface approximation technique: not supported
+	//call command: not supported
+	//scmp command: not supported
+	//csh command: not supported
+}
@@ -0,0 +1,49 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+)
+
+// Counts the statements of each element type in the .obj file without parsing them:
+// only the first word of each line is read, so it is much faster than reading the elements by the Parser.
+// The counts are returned in the Elements of the Stats, the other fields are zero.
+// The statements are counted regardless of whether they are correct, so the counts are the upper bounds
+// of the numbers of the elements returned by the Parser, which is enough to preallocate the memory for them,
+// see model.Model.Reserve. The call statements are counted, but the called files are not read.
+// Returns the error of the reader, the statements read before it are counted.
+func CountElements(reader io.Reader) (Stats, error) {
+	var (
+		buffered = bufio.NewReaderSize(reader, 64*1024)
+		stats    = Stats{Elements: make(map[ElementType]int)}
+		counts   [EndOfFile]int
+	)
+	for {
+		var (
+			line, err = buffered.ReadSlice('\n')
+			keyword   = bytes.TrimLeft(line, " \t")
+		)
+		if end := bytes.IndexAny(keyword, " \t\r\n"); end >= 0 {
+			keyword = keyword[:end]
+		}
+		if elementType, ok := elementDeclarationsMap[string(keyword)]; ok {
+			counts[elementType]++
+		}
+		// The rest of the line that does not fit into the buffer is skipped.
+		for err == bufio.ErrBufferFull {
+			_, err = buffered.ReadSlice('\n')
+		}
+		if err != nil {
+			for elementType, count := range counts {
+				if count != 0 {
+					stats.Elements[ElementType(elementType)] = count
+				}
+			}
+			if err == io.EOF {
+				err = nil
+			}
+			return stats, err
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The elements and the problems of a single line of the Document.
+type documentLine struct {
+	elements    []Element    // The elements of the line, the Line of the elements is 0.
+	diagnostics []Diagnostic // The problems of the line, the Line of the problems is 1.
+}
+
+// Keeps the elements and the problems of each line of a .obj file, so that after an edit of the file
+// only the edited lines are parsed again, for example, on every keystroke in an editor.
+// Each element of a .obj file is written on a single line, so an edit does not affect other lines.
+//
+// The lines are parsed with the default severities of the problems and without the output of messages,
+// the problems are returned by the Diagnostics method.
+type Document struct {
+	lines []documentLine
+}
+
+// Parses the text of a .obj file and creates a Document with its elements and problems.
+func NewDocument(text string) *Document {
+	var d = &Document{}
+	d.lines = parseLines(splitLines(text))
+	return d
+}
+
+// Returns the number of lines of the Document.
+func (d *Document) LinesCount() int {
+	return len(d.lines)
+}
+
+// Replaces count lines starting from the line first (the lines are numbered from 1) by the lines of the text
+// and parses only the new lines. The numbers of the following lines are shifted.
+// An empty text removes the lines, zero count inserts the lines before the line first.
+// Returns an error if the lines are out of the Document, in this case the Document is not changed.
+func (d *Document) Edit(first, count int, text string) error {
+	if first < 1 || count < 0 || first-1+count > len(d.lines) {
+		return fmt.Errorf("the lines %d-%d are out of the document of %d lines", first, first+count-1, len(d.lines))
+	}
+	first--
+	var (
+		parsed = parseLines(splitLines(text))
+		lines  = make([]documentLine, 0, len(d.lines)-count+len(parsed))
+	)
+	lines = append(lines, d.lines[:first]...)
+	lines = append(lines, parsed...)
+	lines = append(lines, d.lines[first+count:]...)
+	d.lines = lines
+	return nil
+}
+
+// Returns the elements of all lines in the order in which they occur in the file.
+// The Line of each element is the number of its line starting from 1, as the Line of its problems.
+func (d *Document) Elements() []Element {
+	var elements []Element
+	for i, line := range d.lines {
+		for _, element := range line.elements {
+			element.Line = i + 1
+			elements = append(elements, element)
+		}
+	}
+	return elements
+}
+
+// Returns the problems of all lines in the order in which they occur in the file.
+// The Line of each problem is the number of its line starting from 1, as in the messages of the Parser.
+func (d *Document) Diagnostics() []Diagnostic {
+	var diagnostics []Diagnostic
+	for i, line := range d.lines {
+		for _, diagnostic := range line.diagnostics {
+			diagnostic.Line = i + 1
+			diagnostics = append(diagnostics, diagnostic)
+		}
+	}
+	return diagnostics
+}
+
+// Splits the text into lines, the line feed at the end of the text does not start a new line.
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// Parses each line separately.
+func parseLines(texts []string) []documentLine {
+	var lines = make([]documentLine, len(texts))
+	for i, text := range texts {
+		var (
+			line = &lines[i]
+			p    = NewParser(strings.NewReader(text))
+		)
+		p.Output(nil)
+		for elementType, element := p.Next(); elementType != EndOfFile; elementType, element = p.Next() {
+			line.elements = append(line.elements, Element{Type: elementType, Value: element})
+		}
+		line.diagnostics = p.Diagnostics()
+	}
+	return lines
+}
@@ -0,0 +1,58 @@
+//go:build go1.18
+
+package parser
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Fuzzing the scanner and the parser through ParseBytes, the testdata files are used as the seed corpus.
+// The parser must not panic on any input, must return the elements in the order of the lines
+// and must return the same elements as the Parser reading the same data.
+func FuzzParseBytes(f *testing.F) {
+	var files, err = filepath.Glob("testdata/*.obj")
+	if err != nil {
+		f.Fatal(err)
+	}
+	for _, file := range files {
+		var data, err = os.ReadFile(file)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(data)
+	}
+	f.Add([]byte("v 1 2 3\nvt 0.5\nf 1/1 2/1 3/1\ncall missing.obj\nv 1 2\n"))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var (
+			elements, diagnostics = ParseBytes(data)
+			lines                 = bytes.Count(data, []byte{'\n'}) + 1
+		)
+		for i, element := range elements {
+			if element.Line < 1 || element.Line > lines || i > 0 && element.Line <= elements[i-1].Line {
+				t.Fatalf("the element %d is read from the line %d", i, element.Line)
+			}
+		}
+		for _, diagnostic := range diagnostics {
+			if diagnostic.Line < 1 || diagnostic.Line > lines+1 {
+				t.Fatalf("the diagnostic %q is reported on the line %d", diagnostic.Message, diagnostic.Line)
+			}
+		}
+		var p = NewParser(bytes.NewReader(data))
+		p.Output(nil)
+		for i := 0; ; i++ {
+			var elementType, _ = p.Next()
+			if elementType == EndOfFile {
+				if i != len(elements) {
+					t.Fatalf("the Parser has read %d elements, ParseBytes: %d", i, len(elements))
+				}
+				break
+			}
+			if i >= len(elements) || elements[i].Type != elementType {
+				t.Fatalf("the element %d read by the Parser is %s", i, elementType)
+			}
+		}
+	})
+}
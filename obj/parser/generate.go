@@ -0,0 +1,297 @@
+package parser
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"hash/fnv"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// The import path of the package with the structures of the elements.
+const typesPackagePath = "computer_graphics/obj/parser/types"
+
+// The function performing the action of the element parser, generated by the GenerateParsers.
+type generatedAction func(state stateType, token string, element interface{}) error
+
+// elementParser that uses the transition table and the error messages of the finiteStateMachine
+// with the element constructor and the actions generated by the GenerateParsers, which do not use reflection.
+type generatedParser struct {
+	*finiteStateMachine
+	create func() interface{} // Creates a new element.
+	act    generatedAction    // Performs the actions.
+}
+
+// Implementation of the newElement method in the elementParser interface.
+func (p *generatedParser) newElement() interface{} { return p.create() }
+
+// Implementation of the action method in the elementParser interface.
+func (p *generatedParser) action(state stateType, token string, element interface{}) error {
+	return p.act(state, token, element)
+}
+
+// Replaces the finiteStateMachine of the element type in the registry with the generatedParser
+// using the generated constructor and actions, if they were generated for the same finiteStateMachine.
+// The hash is the hash of the source of the actions calculated by the GenerateParsers.
+// The finiteStateMachine stays in the registry if the structure of the element was changed after the generation,
+// so the stale generated code is never used.
+func preferGenerated(elementType ElementType, hash uint64, create func() interface{}, act generatedAction) {
+	var m, ok = parsersRegistry[elementType].(*finiteStateMachine)
+	if !ok {
+		return
+	}
+	var source, err = actionSource(elementType, m)
+	if err != nil || sourceHash(source) != hash {
+		return
+	}
+	parsersRegistry[elementType] = &generatedParser{finiteStateMachine: m, create: create, act: act}
+}
+
+// Writes the Go source of the element constructors and the actions of the element parsers from the registry,
+// that do not use reflection, so that the registry prefers them to the finite state machines
+// built from the structures of the package types.
+// The source is written to the parsers_generated.go file by the cmd/genparsers, see the go:generate directive in registry.go.
+// Only the standard element types are generated, the parsers installed by Register always use reflection.
+func GenerateParsers(w io.Writer) error {
+	var (
+		functions bytes.Buffer
+		inits     bytes.Buffer
+	)
+	for i, p := range parsersRegistry {
+		var m *finiteStateMachine
+		switch p := p.(type) {
+		case *finiteStateMachine:
+			m = p
+		case *generatedParser:
+			m = p.finiteStateMachine
+		default:
+			continue
+		}
+		var elementType = ElementType(i)
+		var source, err = actionSource(elementType, m)
+		if err != nil {
+			return err
+		}
+		var (
+			name      = generatedName(elementType)
+			construct = fmt.Sprintf("func() interface{} { return new(%s) }", elementTypeName(m.elementType))
+		)
+		if len(m.defaults) > 0 {
+			construct = constructorName(elementType)
+		}
+		fmt.Fprintf(&inits, "\tpreferGenerated(%s, %#x, %s, %s)\n", upperFirst(name), sourceHash(source), construct, name+"Action")
+		functions.WriteString("\n")
+		functions.WriteString(source)
+	}
+	var source bytes.Buffer
+	source.WriteString("// Code generated by cmd/genparsers; DO NOT EDIT.\n\n")
+	source.WriteString("//go:build !reflectparsers\n\n")
+	source.WriteString("package parser\n\n")
+	source.WriteString("import (\n\t\"" + typesPackagePath + "\"\n\t\"errors\"\n\t\"strconv\"\n)\n\n")
+	source.WriteString("// Makes the registry use the generated parsers, see GenerateParsers.\n")
+	source.WriteString("func init() {\n")
+	source.Write(inits.Bytes())
+	source.WriteString("}\n")
+	source.Write(functions.Bytes())
+	// The imports are used by the generated code regardless of the element structures.
+	source.WriteString("\n// Makes the imports used regardless of the structures of the elements.\n")
+	source.WriteString("var _, _ = errors.New, strconv.ParseInt\n")
+	var formatted, err = format.Source(source.Bytes())
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(formatted)
+	return err
+}
+
+// Returns the hash of the source of the actions, which identifies the finiteStateMachine they were generated for.
+func sourceHash(source string) uint64 {
+	var h = fnv.New64a()
+	io.WriteString(h, source)
+	return h.Sum64()
+}
+
+// Returns the name of the generated functions of the element type, for example, 'vertexTexture' for the VertexTexture.
+func generatedName(elementType ElementType) string {
+	var words = strings.Fields(elementType.String())
+	for i := 1; i < len(words); i++ {
+		words[i] = upperFirst(words[i])
+	}
+	return strings.Join(words, "")
+}
+
+// Returns the word with the first letter in upper case.
+func upperFirst(word string) string {
+	return strings.ToUpper(word[:1]) + word[1:]
+}
+
+// Returns the name of the type in the generated source.
+func elementTypeName(t reflect.Type) string {
+	return t.String()
+}
+
+// Returns the Go source of the function performing the actions of the finiteStateMachine of the element type.
+func actionSource(elementType ElementType, m *finiteStateMachine) (string, error) {
+	var (
+		source bytes.Buffer
+		name   = generatedName(elementType)
+		target = "e"
+	)
+	if m.elementType.PkgPath() != typesPackagePath {
+		return "", fmt.Errorf("the %s is not described by a type from the package types", elementType)
+	}
+	// The elements that are not structures are written through the pointer.
+	if m.elementType.Kind() != reflect.Struct {
+		target = "*e"
+	}
+	fmt.Fprintf(&source, "// Performs the actions of the parser of the %s without reflection.\n", elementType)
+	fmt.Fprintf(&source, "func %sAction(state stateType, token string, element interface{}) error {\n", name)
+	fmt.Fprintf(&source, "var e = element.(*%s)\n", elementTypeName(m.elementType))
+	source.WriteString("switch state {\n")
+	source.WriteString("case start:\nreturn errors.New(\"the action method is called in the start state\")\n")
+	source.WriteString("case err:\nreturn errors.New(\"the action method is called in the err state\")\n")
+	source.WriteString("case warn:\nreturn errors.New(\"the action method is called in the warn state\")\n")
+	var used bool
+	for state, s := range m.setters {
+		if s == nil {
+			continue
+		}
+		used = true
+		fmt.Fprintf(&source, "case %d:\n", state)
+		if err := writeSetter(&source, s, target, m.elementType, nil); err != nil {
+			return "", fmt.Errorf("the action of the state %d of the %s: %w", state, elementType, err)
+		}
+	}
+	source.WriteString("}\n")
+	// The elements without parameters are not written.
+	if !used {
+		source.WriteString("_ = e\n")
+	}
+	source.WriteString("return nil\n}\n")
+	// The elements with the default values of the omitted fields are created by the generated constructor.
+	if len(m.defaults) > 0 {
+		fmt.Fprintf(&source, "\n// Creates a new element of the %s with the default values of the omitted fields.\n", elementType)
+		fmt.Fprintf(&source, "func %s() interface{} {\n", constructorName(elementType))
+		fmt.Fprintf(&source, "return &%s{", elementTypeName(m.elementType))
+		for i, d := range m.defaults {
+			if i > 0 {
+				source.WriteString(", ")
+			}
+			fmt.Fprintf(&source, "%s: %s", m.elementType.Field(d.field).Name, strconv.FormatFloat(d.value, 'g', -1, 64))
+		}
+		source.WriteString("}\n}\n")
+	}
+	return source.String(), nil
+}
+
+// Returns the name of the generated constructor of the element type, for example, 'newVertexParameter'.
+func constructorName(elementType ElementType) string {
+	return "new" + upperFirst(generatedName(elementType))
+}
+
+// Writes the statements that convert the token and write it to the target expression of the specified type
+// in the same way as the setter does.
+// If the failure is not nil, it is returned instead of the error of the setter.
+func writeSetter(w io.Writer, s setter, target string, t reflect.Type, failure error) error {
+	var fail = func(e error) string {
+		if failure != nil {
+			e = failure
+		}
+		return fmt.Sprintf("return errors.New(%q)\n", e.Error())
+	}
+	switch s := s.(type) {
+	case *structSetter:
+		var field = t.Field(s.fieldNumber)
+		return writeSetter(w, s.setter, target+"."+field.Name, field.Type, failure)
+	case *arraySetter:
+		return writeSetter(w, s.setter, fmt.Sprintf("%s[%d]", target, s.index), t.Elem(), failure)
+	case *sliceSetter:
+		return writeSetter(w, s.setter, fmt.Sprintf("%s[len(%s)-1]", target, target), t.Elem(), failure)
+	case *sliceAppender:
+		var zero, err = zeroValue(t.Elem())
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "%s = append(%s, %s)\n", target, target, zero)
+		return writeSetter(w, s.setter, target, t, failure)
+	case *keywordSetter:
+		var zero, err = zeroValue(t)
+		if err != nil {
+			return err
+		}
+		var quoted = make([]string, len(s.keywords))
+		for i, keyword := range s.keywords {
+			quoted[i] = fmt.Sprintf("%q", keyword)
+		}
+		fmt.Fprintf(w, "switch token {\ncase %s:\n%s = %s\ndefault:\n", strings.Join(quoted, ", "), target, zero)
+		if err = writeSetter(w, s.setter, target, t, s.error); err != nil {
+			return err
+		}
+		fmt.Fprint(w, "}\n")
+	case *intSetter:
+		fmt.Fprintf(w, "var value, failure = strconv.ParseInt(token, 10, 64)\nif failure != nil {\n%s}\n", fail(s.error))
+		if s.zeroError != nil {
+			fmt.Fprintf(w, "if value == 0 {\nreturn errors.New(%q)\n}\n", s.zeroError.Error())
+		}
+		fmt.Fprintf(w, "%s = %s\n", target, conversion(t, "value"))
+	case *floatSetter:
+		fmt.Fprintf(w, "var value, failure = strconv.ParseFloat(token, 64)\nif failure != nil {\n%s}\n%s = %s\n",
+			fail(s.error), target, conversion(t, "value"))
+	case *stringSetter:
+		fmt.Fprintf(w, "%s = %s\n", target, conversion(t, "token"))
+	case *wordSetter:
+		var quoted = make([]string, len(s.words))
+		for i, word := range s.words {
+			quoted[i] = fmt.Sprintf("%q", word)
+		}
+		fmt.Fprintf(w, "switch token {\ncase %s:\n%s = %s\ndefault:\n%s}\n",
+			strings.Join(quoted, ", "), target, conversion(t, "token"), fail(s.error))
+	case *flagSetter:
+		fmt.Fprintf(w, "%s = true\n", target)
+	case *boolSetter:
+		fmt.Fprintf(w, "switch token {\ncase \"on\":\n%s = true\ncase \"off\":\n%s = false\ndefault:\n%s}\n",
+			target, target, fail(s.error))
+	case *directionTypeSetter:
+		fmt.Fprintf(w, "switch token {\ncase \"v\":\n%s = types.V\ncase \"u\":\n%s = types.U\ndefault:\n%s}\n",
+			target, target, fail(s.error))
+	default:
+		return errors.New("the setter cannot be generated")
+	}
+	return nil
+}
+
+// Returns the expression converting the value to the type, if the type differs from the type of the value.
+func conversion(t reflect.Type, value string) string {
+	switch {
+	case value == "value" && t.Kind() == reflect.Int64 && t.PkgPath() == "",
+		value == "value" && t.Kind() == reflect.Float64 && t.PkgPath() == "",
+		value == "token" && t.Kind() == reflect.String && t.PkgPath() == "":
+		return value
+	default:
+		return fmt.Sprintf("%s(%s)", t, value)
+	}
+}
+
+// Returns the expression of the zero value of the type.
+func zeroValue(t reflect.Type) (string, error) {
+	switch t.Kind() {
+	case reflect.Struct, reflect.Array:
+		return t.String() + "{}", nil
+	case reflect.Slice:
+		return "nil", nil
+	case reflect.String:
+		return `""`, nil
+	case reflect.Bool:
+		return "false", nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "0", nil
+	default:
+		return "", fmt.Errorf("the zero value of the %s cannot be generated", t)
+	}
+}
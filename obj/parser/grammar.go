@@ -0,0 +1,90 @@
+package parser
+
+import (
+	"computer_graphics/obj/scanner"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// The keywords and the parsers of the statements of a format written like the .obj files,
+// each line starting with a keyword followed by the parameters, for example, the .mtl material libraries.
+// The Parser created by NewGrammarParser recognizes only the keywords of the Grammar instead of the .obj keywords,
+// so that the formats can be read with the same diagnostics, options and finite state machines.
+// The call statements are not spliced in the files read with a Grammar.
+type Grammar struct {
+	keywords map[string]ElementType        // The element types of the statements by their keywords.
+	parsers  map[ElementType]elementParser // The parsers of the element types.
+}
+
+// Creates a new Grammar without keywords.
+func NewGrammar() *Grammar {
+	return &Grammar{keywords: make(map[string]ElementType), parsers: make(map[ElementType]elementParser)}
+}
+
+// Builds the parser of the elements described by the prototype and adds it to the Grammar,
+// so that the lines starting with the keyword are parsed as the elements of the type.
+// The element type is usually created by NewElementType, the prototype follows the same rules as for Register.
+// Several keywords can be registered for the same element type and the same prototype,
+// for example, the keywords of the colors of different kinds.
+//
+// Returns an error if the keyword is not a word, the keyword is already used by another element type,
+// the element type already has a parser of another prototype or the prototype cannot be parsed.
+// Register must not be called concurrently with the parsing.
+func (grammar *Grammar) Register(keyword string, elementType ElementType, prototype interface{}) (e error) {
+	var tokenType, token = scanner.NewScanner(strings.NewReader(keyword)).Next()
+	if tokenType != scanner.Word || token != keyword {
+		return fmt.Errorf("the keyword %q is not a word", keyword)
+	}
+	if elementType == EndOfFile || elementType > EndOfFile && int(elementType-EndOfFile) > len(extensionParsers) {
+		return fmt.Errorf("the element type %d is not a standard or extension element type", elementType)
+	}
+	if used, ok := grammar.keywords[keyword]; ok {
+		return fmt.Errorf("the keyword %q is already used by the %s", keyword, used)
+	}
+	// The builder panics if the prototype does not follow the rules.
+	defer func() {
+		if r := recover(); r != nil {
+			e = fmt.Errorf("invalid prototype of the %s: %v", elementType, r)
+		}
+	}()
+	var p = buildParser(elementType, prototype)
+	if registered, ok := grammar.parsers[elementType]; ok && registered != p {
+		return fmt.Errorf("the %s already has a parser", elementType)
+	}
+	grammar.parsers[elementType] = p
+	grammar.keywords[keyword] = elementType
+	return nil
+}
+
+// Returns the type of the element declared by the keyword in the Grammar and false if the keyword is unknown.
+func (grammar *Grammar) ElementTypeOf(keyword string) (ElementType, bool) {
+	var elementType, ok = grammar.keywords[keyword]
+	return elementType, ok
+}
+
+// Creates a new Parser of the statements of the Grammar, which is configured like the Parser of the .obj files.
+// The Grammar must not be changed while it is used by the Parser.
+func NewGrammarParser(reader io.Reader, grammar *Grammar) Parser {
+	var p = NewParser(reader).(*parser)
+	p.grammar = grammar
+	return p
+}
+
+// Returns the type of the element declared by the keyword in the grammar of the parser,
+// in the .obj keywords if the parser has no grammar, and false if the keyword is unknown.
+func (parser *parser) elementTypeOf(keyword string) (ElementType, bool) {
+	if parser.grammar != nil {
+		return parser.grammar.ElementTypeOf(keyword)
+	}
+	return ElementTypeOf(keyword)
+}
+
+// Returns the parser of the element type in the grammar of the parser,
+// in the registry if the parser has no grammar, and nil if the element type is not supported.
+func (parser *parser) parserOf(elementType ElementType) elementParser {
+	if parser.grammar != nil {
+		return parser.grammar.parsers[elementType]
+	}
+	return parserOf(elementType)
+}
@@ -0,0 +1,96 @@
+package parser
+
+import (
+	"computer_graphics/obj/parser/types"
+	"fmt"
+	"strconv"
+)
+
+// The positions of the references in the vertices of the points, lines and faces separated by slashes: v/vt/vn.
+const (
+	vertexSlot  = iota // The reference to the vertex.
+	textureSlot        // The reference to the texture vertex.
+	normalSlot         // The reference to the vertex normal.
+)
+
+// The numbers of the vertices, texture vertices and vertex normals defined so far by the positions of their references.
+// Shared by the Parser with the parsers of the called files, because the elements of the called files
+// refer to the same vertices.
+type indexCounts [3]int
+
+// The names of the elements referred to by the positions of the references.
+var indexNames = [...]string{"vertex", "texture vertex", "vertex normal"}
+
+// The element types whose statements define the elements referred to by the positions of the references.
+var indexedTypes = map[ElementType]int{
+	Vertex:        vertexSlot,
+	VertexTexture: textureSlot,
+	VertexNormal:  normalSlot,
+}
+
+// Counts the statement of the element type, if it defines an element that can be referred to by the index.
+// The statements of the unsupported and filtered out vertices are counted too,
+// since they still shift the indices of the following vertices.
+func (parser *parser) countIndexed(elementType ElementType) {
+	if slot, ok := indexedTypes[elementType]; ok {
+		parser.counts[slot]++
+	}
+}
+
+// Returns an error if the integer token at the position of the reference in the element of the type
+// refers to an element that is not defined before the line, see Parser.ForwardIndices.
+// Only the references of the points, lines and faces are checked.
+// The zero indices are not checked, they are already rejected by the element parsers, see the nonzero tag.
+func (parser *parser) checkIndex(elementType ElementType, slot int, token string) error {
+	switch {
+	case elementType != Point && elementType != Line && elementType != Face,
+		elementType == Point && slot > vertexSlot,
+		elementType == Line && slot > textureSlot,
+		slot > normalSlot:
+		return nil
+	}
+	var index, e = strconv.Atoi(token)
+	if e != nil {
+		return nil
+	}
+	var count = parser.counts[slot]
+	switch {
+	case index > count && !parser.forwardIndices, -index > count:
+		return fmt.Errorf("unresolved %s index: %d, the number of the elements defined before it is %d", indexNames[slot], index, count)
+	default:
+		return nil
+	}
+}
+
+// Converts the negative indices of the point, line or face to the absolute ones starting from 1.
+// The indices must be checked by the checkIndex method before.
+func (parser *parser) normalize(element interface{}) {
+	switch element := element.(type) {
+	case *types.Point:
+		for i, index := range element.Vertices {
+			element.Vertices[i] = absoluteIndex(index, parser.counts[vertexSlot])
+		}
+	case *types.Line:
+		for i := range element.Vertices {
+			var v = &element.Vertices[i]
+			v.Index = absoluteIndex(v.Index, parser.counts[vertexSlot])
+			v.Texture = absoluteIndex(v.Texture, parser.counts[textureSlot])
+		}
+	case *types.Face:
+		for i := range element.Vertices {
+			var v = &element.Vertices[i]
+			v.Index = absoluteIndex(v.Index, parser.counts[vertexSlot])
+			v.Texture = absoluteIndex(v.Texture, parser.counts[textureSlot])
+			v.Normal = absoluteIndex(v.Normal, parser.counts[normalSlot])
+		}
+	}
+}
+
+// Converts the negative index relative to the end of the list with the specified number of elements
+// to the absolute index starting from 1, other indices are returned unchanged.
+func absoluteIndex(index, count int) int {
+	if index < 0 {
+		return count + index + 1
+	}
+	return index
+}
@@ -0,0 +1,189 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"os"
+	"runtime"
+	"sync"
+)
+
+// The number of bytes of the input after which a chunk of lines is ended at the next line ending.
+const parallelChunkSize = 256 * 1024
+
+// A chunk of lines of the input parsed by one worker of the ParallelParser.
+type parsedChunk struct {
+	elements    []Element    // The elements of the chunk in the order of the lines.
+	diagnostics []Diagnostic // The diagnostics of the problems found in the chunk, with the numbers of the lines in the input.
+}
+
+// A chunk of lines of the input waiting for a worker of the ParallelParser.
+type chunkJob struct {
+	data      []byte            // The text of the lines, ending with the line ending except for the last chunk.
+	firstLine int               // The number of lines of the input before the chunk.
+	result    chan *parsedChunk // Receives the parsed chunk.
+}
+
+// Reads the elements from the .obj file on several goroutines, so that huge models are read faster.
+// The input is split into chunks of lines, which are lexed and parsed by a pool of workers,
+// and the elements of the chunks are returned in the order of the input.
+// The elements of different lines do not depend on each other, so the result is the same as the result of the Parser
+// with the default settings, except that the call statements are not supported.
+// The problems are written to the output in the format of WriteDiagnostic when the chunk containing them is reached.
+type ParallelParser struct {
+	results      chan chan *parsedChunk // The results of the chunks in the order of the input.
+	stop         chan struct{}          // Closed by the Close method to stop the goroutines.
+	stopOnce     sync.Once              // Closes the stop channel once.
+	current      *parsedChunk           // The chunk whose elements are being returned.
+	index        int                    // The index of the next element in the current chunk.
+	line         int                    // The number of the line of the last returned element starting from 0.
+	outputWriter io.Writer              // Recipient of error and warning messages.
+	diagnostics  []Diagnostic           // The diagnostics of the problems found in the chunks reached so far.
+}
+
+// Creates a new ParallelParser that reads from the reader using the specified number of workers.
+// If the number of workers is zero or negative, runtime.NumCPU workers are used.
+// By default, it outputs all errors and warnings in os.Stderr.
+// The goroutines stop when the end of the file is reached or the Close method is called.
+func NewParallelParser(reader io.Reader, workers int) *ParallelParser {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	var (
+		parallel = &ParallelParser{
+			results:      make(chan chan *parsedChunk, 2*workers),
+			stop:         make(chan struct{}),
+			outputWriter: os.Stderr,
+		}
+		jobs = make(chan chunkJob, workers)
+	)
+	for i := 0; i < workers; i++ {
+		go func() {
+			for job := range jobs {
+				job.result <- parseChunk(job.data, job.firstLine)
+			}
+		}()
+	}
+	go parallel.split(reader, jobs)
+	return parallel
+}
+
+// Reads the input by chunks of lines and passes them to the workers,
+// queuing the channels of their results in the order of the input.
+func (parallel *ParallelParser) split(reader io.Reader, jobs chan<- chunkJob) {
+	defer close(parallel.results)
+	defer close(jobs)
+	var (
+		buffered  = bufio.NewReaderSize(reader, parallelChunkSize)
+		firstLine int
+	)
+	for {
+		var (
+			data   = make([]byte, parallelChunkSize)
+			n, err = io.ReadFull(buffered, data)
+		)
+		data = data[:n]
+		// The chunk is extended to the end of the line, so that the lines are not split between the workers.
+		if err == nil && data[n-1] != '\n' {
+			var rest []byte
+			rest, err = buffered.ReadBytes('\n')
+			data = append(data, rest...)
+		}
+		if len(data) != 0 || err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			var job = chunkJob{data: data, firstLine: firstLine, result: make(chan *parsedChunk, 1)}
+			select {
+			case parallel.results <- job.result:
+			case <-parallel.stop:
+				return
+			}
+			if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+				// The error of the reader is reported in the chunk read before it, like the Parser does.
+				var chunk = parseChunk(data, firstLine)
+				chunk.diagnostics = append(chunk.diagnostics, Diagnostic{
+					Kind:     ReadIssue,
+					Severity: ReadIssue.DefaultSeverity(),
+					Line:     firstLine + bytes.Count(data, []byte{'\n'}) + 1,
+					Column:   1,
+					Token:    "eof",
+					Message:  "failed to read the input - " + err.Error(),
+				})
+				job.result <- chunk
+				return
+			}
+			select {
+			case jobs <- job:
+			case <-parallel.stop:
+				return
+			}
+		}
+		if err != nil {
+			return
+		}
+		firstLine += bytes.Count(data, []byte{'\n'})
+	}
+}
+
+// Parses the chunk of lines starting after the specified number of lines of the input.
+func parseChunk(data []byte, firstLine int) *parsedChunk {
+	var (
+		p     = NewParser(bytes.NewReader(data))
+		chunk = new(parsedChunk)
+	)
+	p.Output(nil)
+	for elementType, element := p.Next(); elementType != EndOfFile; elementType, element = p.Next() {
+		chunk.elements = append(chunk.elements, Element{Type: elementType, Value: element, Line: firstLine + p.Line() + 1})
+	}
+	chunk.diagnostics = p.Diagnostics()
+	for i := range chunk.diagnostics {
+		chunk.diagnostics[i].Line += firstLine
+	}
+	return chunk
+}
+
+// Returns the next element read from the reader, like the Parser.Next method.
+// When the end of the file is reached, it always returns (EndOfFile, nil).
+func (parallel *ParallelParser) Next() (ElementType, interface{}) {
+	for parallel.current == nil || parallel.index == len(parallel.current.elements) {
+		var result, ok = <-parallel.results
+		if !ok {
+			return EndOfFile, nil
+		}
+		parallel.current = <-result
+		parallel.index = 0
+		for _, diagnostic := range parallel.current.diagnostics {
+			if parallel.outputWriter != nil {
+				WriteDiagnostic(parallel.outputWriter, diagnostic)
+			}
+		}
+		parallel.diagnostics = append(parallel.diagnostics, parallel.current.diagnostics...)
+	}
+	var element = parallel.current.elements[parallel.index]
+	parallel.index++
+	parallel.line = element.Line - 1
+	return element.Type, element.Value
+}
+
+// Returns the number of the line of the last returned element starting from 0.
+func (parallel *ParallelParser) Line() int {
+	return parallel.line
+}
+
+// Sets a new io.Writer for displaying error and warning messages in the format of WriteDiagnostic.
+// If nil is set, no messages will be output, the diagnostics are still collected.
+func (parallel *ParallelParser) Output(w io.Writer) {
+	parallel.outputWriter = w
+}
+
+// Returns the diagnostics of the problems found in the lines reached so far in the order of the lines.
+func (parallel *ParallelParser) Diagnostics() []Diagnostic {
+	return parallel.diagnostics
+}
+
+// Stops the goroutines reading the input if the end of the file has not been reached yet.
+// The ParallelParser must not be used after that.
+func (parallel *ParallelParser) Close() {
+	parallel.stopOnce.Do(func() {
+		close(parallel.stop)
+	})
+}
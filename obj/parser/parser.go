@@ -2,8 +2,11 @@ package parser
 
 import (
 	"computer_graphics/obj/scanner"
+	"context"
+	"errors"
 	"fmt"
 	"io"
+	"math"
 	"os"
 	"strings"
 )
@@ -101,24 +104,170 @@ var elementsMap = [...]string{
 }
 
 // Converts a element type constant to its string representation.
+// The extension element types are converted to the names passed to NewElementType.
 func (elementType ElementType) String() string {
+	if elementType > EndOfFile {
+		return extensionNames[elementType-EndOfFile-1]
+	}
 	return elementsMap[elementType]
 }
 
+// The severity of the problem found during parsing.
+type Severity uint8
+
+const (
+	Info    Severity = iota // The problem does not affect the result.
+	Warning                 // The problem may affect the result.
+	Error                   // The problem affects the result.
+)
+
+// Converts a severity constant to its string representation.
+var severitiesMap = [...]string{"INFO", "WARNING", "ERROR"}
+
+// Converts a severity constant to its string representation.
+func (severity Severity) String() string {
+	return severitiesMap[severity]
+}
+
+// One of the possible kinds of problems that the Parser can find.
+// Each kind has a default severity that can be changed using the Parser.SetSeverity method.
+type IssueKind uint8
+
+const (
+	UnknownElementIssue     IssueKind = iota // The first word of the line is not a name of an element type (ERROR by default).
+	UnsupportedElementIssue                  // The element type is known, but is not supported (WARNING by default).
+	InvalidElementIssue                      // The element is described incorrectly (ERROR by default).
+	ReadIssue                                // Reading from the reader failed, the rest of the file is lost (ERROR by default).
+	CallIssue                                // The file of the call statement cannot be spliced (ERROR by default).
+	LimitIssue                               // The line or the token is longer than the limit (ERROR by default).
+	IndexIssue                               // The index is zero or refers to an undefined vertex, see Parser.NormalizeIndices (ERROR by default).
+	DeviationIssue                           // The element is accepted, but deviates from the specification, the line is not skipped (WARNING by default).
+)
+
+// The severities of the issue kinds that are used if the policy does not contain the kind.
+var defaultSeverities = [...]Severity{Error, Warning, Error, Error, Error, Error, Error, Warning}
+
+// Returns the default severity of the issue kind.
+func (kind IssueKind) DefaultSeverity() Severity {
+	return defaultSeverities[kind]
+}
+
+// Converts an issue kind constant to its string representation.
+var issueKindNamesMap = [...]string{"unknown element", "unsupported element", "invalid element", "read", "call", "limit", "index", "deviation"}
+
+// Converts an issue kind constant to its string representation.
+func (kind IssueKind) String() string {
+	return issueKindNamesMap[kind]
+}
+
+// The error that aborts the parsing after the number of errors set by Parser.SetMaxErrors.
+var ErrTooManyErrors = errors.New("too many errors")
+
+// A problem found during parsing.
+// The Parser collects the diagnostics of all problems, see Parser.Diagnostics,
+// and writes them to its output in the text format, see WriteDiagnostic.
+type Diagnostic struct {
+	Kind     IssueKind // The kind of the problem.
+	Severity Severity  // The severity of the problem according to the policy of the Parser.
+	File     string    // The name of the called file containing the problem, empty for the main input, see Parser.Resolve.
+	Line     int       // The number of the line containing the problem starting from 1.
+	Column   int       // The number of the column of the token that caused the problem starting from 1.
+	Token    string    // The token that caused the problem, 'eol' or 'eof' for the end of the line or the file.
+	Message  string    // The description of the problem.
+	LineText string    // The full text of the line containing the problem, the line is skipped by the Parser unless the problem is a DeviationIssue.
+}
+
+// Returns the description of the problem with its position, so that the Diagnostic can be used as an error.
+func (diagnostic Diagnostic) Error() string {
+	if diagnostic.File != "" {
+		return fmt.Sprintf("%s: line %d, column %d: %s", diagnostic.File, diagnostic.Line, diagnostic.Column, diagnostic.Message)
+	}
+	return fmt.Sprintf("line %d, column %d: %s", diagnostic.Line, diagnostic.Column, diagnostic.Message)
+}
+
+// Writes the diagnostic in the text format used by the Parser output:
+// [{severity}] line: {line number}, column: {column number}, token: '{token string}', message: {message}
+// The line number is preceded by 'file: {file name}, ' for the problems found in the called files.
+// The message is followed by ', the line will be skipped' for all the problems except the DeviationIssue.
+// After that, it writes the line where the token occurred, highlighting the token.
+func WriteDiagnostic(w io.Writer, diagnostic Diagnostic) {
+	var consequence = ", the line will be skipped"
+	if diagnostic.Kind == DeviationIssue {
+		consequence = ""
+	}
+	writeDiagnostic(w, diagnostic, consequence)
+}
+
+// Writes the diagnostic like the WriteDiagnostic, but with the specified consequence after the message.
+func writeDiagnostic(w io.Writer, diagnostic Diagnostic, consequence string) {
+	var (
+		severityString = diagnostic.Severity.String()
+		tokenLength    = len(diagnostic.Token)
+	)
+	if diagnostic.Token == "eol" || diagnostic.Token == "eof" {
+		tokenLength = 1
+	}
+	var file string
+	if diagnostic.File != "" {
+		file = "file: " + diagnostic.File + ", "
+	}
+	fmt.Fprintf(
+		w,
+		"[%s] %sline: %d, column: %d, token: '%s', message: %s%s\n",
+		severityString,
+		file,
+		diagnostic.Line,
+		diagnostic.Column,
+		diagnostic.Token,
+		diagnostic.Message,
+		consequence,
+	)
+	fmt.Fprintln(
+		w,
+		strings.Repeat(" ", len(severityString)+2),
+		"->",
+		diagnostic.LineText,
+		"\n",
+		strings.Repeat(" ", diagnostic.Column+len(severityString)+3),
+		strings.Repeat("^", tokenLength),
+	)
+}
+
 // Allows you to call the Next method sequentially to get elements from the .obj file.
 // Display information about problems that occur during parsing.
-// You can disable the output by using the IgnoreWarnings and IgnoreErrors methods.
+// You can disable the output by using the IgnoreInfos, IgnoreWarnings and IgnoreErrors methods.
+// The severity of each kind of problem can be changed by using the SetSeverity method.
 // You can also specify io.Writer to output this information to.
 type Parser interface {
 	// Returns the next element read from the reader.
 	// Lines of unsupported format and lines containing an error are skipped and searched for matches further.
 	// Ensures that the returned object can be safely cast to the structure from the package types
-	// corresponding to the constant ElementType.
+	// corresponding to the constant ElementType, or to *UnknownElement for the Unrecognized, see PassUnknown.
+	// Each returned element is allocated for its line and is not changed by the next calls,
+	// so the elements can be retained without copying.
 	// When the end of the file is reached, it always returns (EndOfFile, nil).
+	// If the parsing is aborted, it always returns (EndOfFile, err), where err is the error returned by the Err method,
+	// see Parser.Strict and Parser.SetMaxErrors.
 	Next() (ElementType, interface{})
-	// Sets a new io.Writer for displaying error and warning messages.
-	// If nil is set, no messages will be output.
+	// Sets a new io.Writer for displaying error and warning messages in the format of WriteDiagnostic.
+	// If nil is set, no messages will be output, the diagnostics are still collected.
 	Output(w io.Writer)
+	// Returns the diagnostics of all problems found so far in the order in which they were found,
+	// regardless of the IgnoreInfos, IgnoreWarnings and IgnoreErrors settings.
+	Diagnostics() []Diagnostic
+	// Sets a function that receives the diagnostic of each problem as soon as it is found,
+	// regardless of the IgnoreInfos, IgnoreWarnings and IgnoreErrors settings.
+	// The diagnostics are still collected and written to the output.
+	// If nil is set, the diagnostics are only collected and written to the output.
+	OnDiagnostic(handler func(diagnostic Diagnostic))
+	// Changes the severity with which the problems of the specified kind are reported.
+	SetSeverity(kind IssueKind, severity Severity)
+	// Returns the severity with which the problems of the specified kind are reported.
+	Severity(kind IssueKind) Severity
+	// Enables or disables the info output.
+	IgnoreInfos(ii bool)
+	// Returns true if Parser does not output infos.
+	IsIgnoreInfos() bool
 	// Enables or disables the warning output.
 	IgnoreWarnings(iw bool)
 	// Returns true if Parser does not output warnings.
@@ -127,15 +276,143 @@ type Parser interface {
 	IgnoreErrors(ie bool)
 	// Returns true if Parser does not output errors.
 	IsIgnoreErrors() bool
-	// Returns the number of the line that was last processed by the Parser.
+	// Enables or disables the strict mode: instead of skipping the line, the first problem with the Error severity
+	// aborts the parsing, so that the tools validating the files can fail fast.
+	// The severities of the problems can be changed by the SetSeverity method.
+	Strict(strict bool)
+	// Returns true if the Parser is in the strict mode.
+	IsStrict() bool
+	// Sets the maximum number of problems with the Error severity, after which the parsing is aborted,
+	// so that a corrupt file does not produce millions of messages.
+	// The error wrapping the ErrTooManyErrors is returned by the Err method and written to the output.
+	// Zero or negative limit means no limit, which is the default.
+	SetMaxErrors(n int)
+	// Returns the maximum number of problems with the Error severity, zero if there is no limit.
+	MaxErrors() int
+	// Returns the error that aborted the parsing: the Diagnostic of the problem in the strict mode
+	// or the error wrapping the ErrTooManyErrors. Returns nil if the parsing was not aborted.
+	Err() error
+	// Sets a function that receives the number and the raw text of each line
+	// containing an element of an unsupported format, so that the line is not lost.
+	// If nil is set, such lines are only reported and skipped.
+	OnUnsupported(handler func(line int, text string))
+	// Sets a function that receives the number of the line and the full text of each comment,
+	// starting with the '#' character, so that tooling can preserve metadata comments.
+	// If nil is set, comments are skipped.
+	OnComment(handler func(line int, text string))
+	// Sets a function that decides whether the elements of the type are parsed.
+	// The lines of the elements for which the function returns false are skipped together with their comments
+	// without parsing and reporting, so that the elements that are not needed do not slow down the reading.
+	// If nil is set, all elements are parsed.
+	Filter(filter func(elementType ElementType) bool)
+	// Returns the number of the line that was last processed by the Parser starting from 0.
 	Line() int
+	// Returns the position in the line that was last processed by the Parser.
+	Column() int
+	// Returns the number of bytes of the input processed by the Parser so far.
+	Offset() int64
+	// Sets the size of the input in bytes, so that the Progress can be calculated.
+	// The size is known without it if the reader passed to NewParser is a *os.File
+	// or has the Len method like *strings.Reader, *bytes.Reader and *bytes.Buffer.
+	SetSize(size int64)
+	// Returns the size of the input in bytes, -1 if it is not known.
+	Size() int64
+	// Returns the statistics of the elements and the problems found so far,
+	// so that the tools can print a summary of the file.
+	Stats() Stats
+	// Returns the part of the input processed by the Parser from 0 to 1, for example, for the progress bars.
+	// Returns -1 if the size of the input is not known.
+	Progress() float64
+	// Reads all the remaining elements and passes each of them to the method of the handler
+	// corresponding to its type, see ElementHandler.
+	// Stops and returns the error if the handler returns an error or the parsing is aborted in the strict mode,
+	// returns nil when the end of the file is reached.
+	Walk(handler ElementHandler) error
+	// Reads the remaining elements in a new goroutine and delivers them over the returned channel,
+	// so that the elements can be processed by a pipeline of goroutines.
+	// The channel is closed when the end of the file is reached, the parsing is aborted in the strict mode
+	// or the context is canceled, the EndOfFile marker is not delivered, see the Err method.
+	// The Parser must not be used until the channel is closed.
+	Stream(ctx context.Context) <-chan Element
+	// Sets the FileResolver that opens the files referenced by the call statements,
+	// so that the elements of the called file are returned in place of the call statement.
+	// The arguments of the call statement replace the $1, $2, ... placeholders in the called file.
+	// The called files inherit the settings of the Parser, their problems are reported with the name of the file.
+	// If nil is set, which is the default, the call statements are reported as unsupported.
+	Resolve(resolver FileResolver)
+	// Sets the maximum length of a line and of a token in bytes, zero or negative values mean no limit.
+	// The lines exceeding the limits are reported as the LimitIssue and skipped without being stored,
+	// which protects the services parsing untrusted files from the unbounded memory usage.
+	// The limits are scanner.DefaultMaxLineLength and scanner.DefaultMaxTokenLength by default.
+	SetLimits(maxLineLength, maxTokenLength int)
+	// Returns the maximum length of a line and of a token in bytes, zero if there is no limit.
+	Limits() (maxLineLength, maxTokenLength int)
+	// Enables or disables the checking and normalization of the indices of the points, lines and faces.
+	// The Parser counts the vertices, texture vertices and vertex normals defined so far, including the filtered out
+	// and unsupported ones, the indices of the elements not defined before the line are reported as the IndexIssue
+	// and the line is skipped. The zero indices are always reported as the InvalidElementIssue.
+	// The negative indices relative to the end of the lists are converted to the absolute ones starting from 1,
+	// so that the consumers of the elements do not need to resolve them.
+	NormalizeIndices(normalize bool)
+	// Returns true if the Parser checks and normalizes the indices.
+	IsNormalizeIndices() bool
+	// Enables or disables the positive indices of the elements defined after the line when the indices are normalized,
+	// for example, for the consumers that resolve such references at the end of the file.
+	// The zero indices and the negative indices referring to no element are reported anyway,
+	// since the negative indices are relative to the elements defined before the line, as required by the specification.
+	// By default, the forward references are reported as the IndexIssue.
+	ForwardIndices(allow bool)
+	// Returns true if the Parser accepts the positive indices of the elements defined after the line.
+	IsForwardIndices() bool
+	// Enables or disables the matching of the keywords regardless of their case, for example, 'V', 'F' or 'Usemtl',
+	// written by some exporters. The first word of the line that is not a keyword is converted to lower case,
+	// and if it becomes a keyword, the line is parsed as the element of the keyword.
+	// The normalization is reported as the DeviationIssue for each returned element.
+	// By default, the keywords are case-sensitive, as required by the specification.
+	CaseInsensitiveKeywords(caseInsensitive bool)
+	// Returns true if the Parser matches the keywords regardless of their case.
+	IsCaseInsensitiveKeywords() bool
+	// Enables or disables the passing of the statements with unknown keywords, for example, the extensions written
+	// by specific exporters. If enabled, instead of reporting the UnknownElementIssue and skipping the line,
+	// the Parser returns the statement as the Unrecognized element of the type *UnknownElement.
+	// The lines that do not start with a word are still reported. The Filter does not apply to the Unrecognized elements.
+	PassUnknown(pass bool)
+	// Returns true if the Parser returns the statements with unknown keywords.
+	IsPassUnknown() bool
 }
 
 // Creates a new .obj file parser.
 // By default, it outputs all errors and warnings in os.Stderr.
 // This can be changed by using the Parser.Output, Parser.IgnoreWarnings, Parser.IgnoreErrors methods.
 func NewParser(reader io.Reader) Parser {
-	return &parser{scanner: scanner.NewScanner(reader), outputWriter: os.Stderr}
+	// The size is measured before the scanner buffers the beginning of the input.
+	var size = readerSize(reader)
+	var p = NewParserFromScanner(scanner.NewScanner(reader))
+	p.SetSize(size)
+	return p
+}
+
+// Returns the number of bytes that can be read from the reader, -1 if it is not known.
+func readerSize(reader io.Reader) int64 {
+	switch r := reader.(type) {
+	case interface{ Len() int }:
+		return int64(r.Len())
+	case *os.File:
+		var info, err = r.Stat()
+		if err != nil || !info.Mode().IsRegular() {
+			return -1
+		}
+		var offset, _ = r.Seek(0, io.SeekCurrent)
+		return info.Size() - offset
+	default:
+		return -1
+	}
+}
+
+// Creates a new .obj file parser that reads the tokens from the scanner.
+// Allows to parse the same tokens several times using the scanner.Recorder.
+func NewParserFromScanner(s scanner.Scanner) Parser {
+	return &parser{scanner: s, outputWriter: os.Stderr, policy: map[IssueKind]Severity{}, size: -1, counts: new(indexCounts)}
 }
 
 // Sets the match between the first word in the line in .obj file and the type of the element that is written in this line.
@@ -182,12 +459,20 @@ var elementDeclarationsMap = map[string]ElementType{
 	"csh":        Csh,
 }
 
+// Returns the type of the element declared by the keyword, the first word of the line in .obj file,
+// and false if the keyword is unknown.
+func ElementTypeOf(keyword string) (ElementType, bool) {
+	var elementType, ok = elementDeclarationsMap[keyword]
+	return elementType, ok
+}
+
 // One of the possible states of a elementParser.
 type stateType uint8
 
 const (
 	start stateType = iota // The initial state and the state of successful completion of parsing.
 	err                    // The state of the error found during the parsing process.
+	warn                   // The state of successful completion of parsing with a deviation from the specification.
 	first                  // First available state.
 )
 
@@ -198,11 +483,14 @@ const (
 //
 // Parser sequentially gives the transition method input tokens from the .obj file,
 // starting with a space after the name of the element format supported by the elementParser.
+// Before that, the Parser creates a new element by the newElement method, the actions write the read data to it.
 // After that, the received state is checked: if the elementParser has moved to the start state,
-// the result method is called to get the read data; if the elementParser has moved to the err state,
+// the element is returned by the Parser; if the elementParser has moved to the err state,
 // the message method is called to get the error information.
+// If the elementParser has moved to the warn state, the element is returned too,
+// and the message method is called to get the warning, which is reported as the DeviationIssue.
 //
-// Two state values are reserved:
+// Three state values are reserved:
 //
 // 0 - start:
 //
@@ -218,145 +506,317 @@ const (
 // 	elementParser should go into an err state if an invalid token is received.
 // 	In this case, you don't need to worry about reaching the end of the line.
 //
-// The implementation of the new elementParser must be registered in the parsersRegistry.
+// 2 - warn:
+//
+// 	elementParser can go into the warn state instead of the start state if the element is read successfully,
+// 	but deviates from the specification, for example, contains a parameter that is ignored.
+// 	As for the start state, the elementParser must only go to the warn state on the scanner.EOL token or the scanner.EOF token.
+//
+// The elementParser must not store the element being read, because it is shared by all Parsers,
+// which can be used concurrently.
+//
+// The implementation of the new elementParser must be registered in the parsersRegistry or installed by Register.
 // See the parsersRegistry documentation for more information.
 type elementParser interface {
-	// Returns the next state of the state machine based on the previous state and the received token type.
-	transition(tokenType scanner.TokenType, state stateType) stateType
-	// Performs the necessary actions on the received token when transitioning from the state.
+	// Returns the next state of the state machine based on the previous state and the received token type,
+	// the token itself is used for the words with their own transitions.
+	transition(tokenType scanner.TokenType, token string, state stateType) stateType
+	// Creates a new element to which the data read from the string is written.
+	// The elementParser must ensure that the return value can be safely cast
+	// to the appropriate structure from the package types.
+	newElement() interface{}
+	// Performs the necessary actions on the received token when transitioning from the state,
+	// writing the read data to the element created by the newElement method.
 	// May return error information.
-	action(state stateType, token string) error
+	action(state stateType, token string, element interface{}) error
 	// Returns information about the error by the state from which the elementParser went to the err state
 	// and the type of token that was received when going to the err state.
 	message(tokenType scanner.TokenType, state stateType) string
-	// Returns a structure containing the read data from the string.
-	// The elementParser must ensure that the return value can be safely cast
-	// to the appropriate structure from the package types.
-	result() interface{}
 }
 
 // Implements the Parser interface.
 type parser struct {
-	scanner        scanner.Scanner // A scanner that splits the input file into tokens.
-	outputWriter   io.Writer       // Recipient of error and warning messages.
-	ignoreWarnings bool            // If true, no error messages will be output to the outputWriter.
-	ignoreErrors   bool            // If true, no warning messages will be output to the outputWriter.
-}
+	scanner        scanner.Scanner        // A scanner that splits the input file into tokens.
+	size           int64                  // The size of the input in bytes, -1 if it is not known.
+	outputWriter   io.Writer              // Recipient of error and warning messages.
+	policy         map[IssueKind]Severity // Severities of the issue kinds that differ from the default ones.
+	ignoreInfos    bool                   // If true, no info messages will be output to the outputWriter.
+	ignoreWarnings bool                   // If true, no warning messages will be output to the outputWriter.
+	ignoreErrors   bool                   // If true, no error messages will be output to the outputWriter.
 
-// Type of output message.
-type logType uint8
+	unsupportedHandler func(line int, text string) // Receives the lines containing elements of an unsupported format.
+	commentHandler     func(line int, text string) // Receives the comments.
+	filter             func(ElementType) bool      // Decides whether the elements of the type are parsed.
+	readErrorReported  bool                        // true if the error of the reader has already been reported.
+	diagnostics        []Diagnostic                // The diagnostics of the problems found so far.
+	diagnosticHandler  func(Diagnostic)            // Receives the diagnostics of the problems.
+	strict             bool                        // If true, the first problem with the Error severity aborts the parsing.
+	maxErrors          int                         // The number of errors after which the parsing is aborted, 0 if there is no limit.
+	stats              Stats                       // The statistics of the elements and the problems found so far.
+	failure            error                       // The error that aborted the parsing.
+	resolver           FileResolver                // Opens the files of the call statements, nil if they are not spliced.
+	called             *parser                     // Parses the called file whose elements are being returned, nil if there is none.
+	file               string                      // The name of the called file being parsed, empty for the main input.
+	depth              int                         // The number of the call statements through which the file was called.
+	limitError         *scanner.ScanError          // The exceeded limit of the Unknown token that has just been read, nil if there is none.
+	normalizeIndices   bool                        // If true, the indices of the points, lines and faces are checked and normalized.
+	forwardIndices     bool                        // If true, the positive indices of the elements defined after the line are accepted.
+	counts             *indexCounts                // The numbers of the elements that can be referred to by the indices.
+	caseInsensitive    bool                        // If true, the keywords are matched regardless of their case.
+	passUnknown        bool                        // If true, the statements with unknown keywords are returned as the Unrecognized elements.
+	grammar            *Grammar                    // The keywords and the parsers of the statements of another format, nil for the .obj files.
+}
 
-const (
-	e logType = iota // Error type.
-	w                // Warning type.
-)
+// Returns the next token from the scanner.
+// Comments are passed to the commentHandler and are not returned,
+// so that the elementParsers do not need to process them.
+func (parser *parser) nextToken() (scanner.TokenType, string) {
+	var tokenType, token = parser.scanner.Next()
+	for tokenType == scanner.Comment {
+		if parser.commentHandler != nil {
+			parser.commentHandler(parser.scanner.Line()+1, token)
+		}
+		tokenType, token = parser.scanner.Next()
+	}
+	// The Unknown token of the exceeded limit is reported as the LimitIssue instead of the problem found by the element parser.
+	if tokenType == scanner.Unknown {
+		if scanError := parser.scanner.LastError(); scanError != nil && scanError.Kind == scanner.LimitError {
+			parser.limitError = scanError
+		}
+	}
+	// The scanner returns EOF tokens after the error of the reader, it must be reported once.
+	if tokenType == scanner.EOF && !parser.readErrorReported {
+		if scanError := parser.scanner.LastError(); scanError != nil && scanError.Kind == scanner.IOError {
+			parser.readErrorReported = true
+			parser.log("failed to read the input - "+scanError.Text, token, ReadIssue)
+		}
+	}
+	return tokenType, token
+}
 
-// Converts the output message type to its string representation.
-func (t logType) String() string {
-	switch t {
-	case e:
-		return "ERROR"
-	case w:
-		return "WARNING"
+// Returns true if messages of the specified severity are not output.
+func (parser *parser) ignored(severity Severity) bool {
+	switch severity {
+	case Info:
+		return parser.ignoreInfos
+	case Warning:
+		return parser.ignoreWarnings
 	default:
-		panic("unknown log type")
+		return parser.ignoreErrors
 	}
 }
 
-// Outputs a message in outputWriter in the format:
-// [{log type}] line: {line number}, column: {column number}, token: '{token string}', message: {log message}
-// After that, it outputs the line where the token occurred, highlighting the token.
+// Reports a problem: collects its diagnostic, passes it to the diagnosticHandler
+// and outputs it in outputWriter in the format of WriteDiagnostic.
+// The severity is determined by the issue kind according to the policy of the parser.
 // Note that the method skips a line and adds information about it to the msg.
-func (parser *parser) log(msg, token string, t logType) {
-	if !(t == e && parser.ignoreErrors || t == w && parser.ignoreWarnings) && parser.outputWriter != nil {
-		var (
-			tokenLength   int
-			logTypeString = t.String()
-		)
-		switch token {
-		case "\n":
-			token = "eol"
-			tokenLength = 1
-		case "":
-			token = "eof"
-			tokenLength = 1
-		default:
-			tokenLength = len(token)
+// Returns the full text of the skipped line.
+func (parser *parser) log(msg, token string, kind IssueKind) string {
+	var (
+		read        = parser.scanner.LineString()
+		tokenLength int
+	)
+	switch token {
+	case "\n":
+		token = "eol"
+		tokenLength = 1
+	case "":
+		token = "eof"
+		tokenLength = 1
+	default:
+		tokenLength = len(token)
+	}
+	var (
+		column     = parser.scanner.Column() - tokenLength + 2
+		skipped, _ = parser.scanner.SkipLine()
+		diagnostic = Diagnostic{
+			Kind:     kind,
+			Severity: parser.Severity(kind),
+			File:     parser.file,
+			Line:     parser.scanner.Line() + 1,
+			Column:   column,
+			Token:    token,
+			Message:  msg,
+			LineText: read + skipped,
+		}
+	)
+	if parser.limitError != nil {
+		diagnostic.Kind = LimitIssue
+		diagnostic.Severity = parser.Severity(LimitIssue)
+		diagnostic.Column = parser.limitError.Column
+		diagnostic.Message = parser.limitError.Text
+		parser.limitError = nil
+	}
+	parser.report(diagnostic)
+	return diagnostic.LineText
+}
+
+// Collects the diagnostic, passes it to the diagnosticHandler, writes it to the output
+// and aborts the parsing if the diagnostic is an error in the strict mode or exceeds the maximum number of errors.
+func (parser *parser) report(diagnostic Diagnostic) {
+	parser.diagnostics = append(parser.diagnostics, diagnostic)
+	parser.stats.count(diagnostic)
+	if parser.diagnosticHandler != nil {
+		parser.diagnosticHandler(diagnostic)
+	}
+	if !parser.ignored(diagnostic.Severity) && parser.outputWriter != nil {
+		WriteDiagnostic(parser.outputWriter, diagnostic)
+	}
+	switch {
+	case parser.failure != nil || diagnostic.Severity != Error:
+	case parser.strict:
+		parser.failure = diagnostic
+	case parser.maxErrors > 0 && parser.stats.Errors >= parser.maxErrors:
+		parser.failure = fmt.Errorf("%w: %d errors found, the rest of the file is skipped", ErrTooManyErrors, parser.stats.Errors)
+		if !parser.ignoreErrors && parser.outputWriter != nil {
+			// The abort is written at the position of the last error, the message already describes the consequence.
+			var abort = diagnostic
+			abort.Kind, abort.Message = LimitIssue, parser.failure.Error()
+			writeDiagnostic(parser.outputWriter, abort, "")
 		}
-		var column = parser.scanner.Column() - tokenLength + 2
-		parser.scanner.SkipLine()
-		fmt.Fprintf(
-			parser.outputWriter,
-			"[%s] line: %d, column: %d, token: '%s', message: %s%s\n",
-			logTypeString,
-			parser.scanner.Line()+1,
-			column,
-			token,
-			msg,
-			", the line will be skipped",
-		)
-		fmt.Fprintln(
-			parser.outputWriter,
-			strings.Repeat(" ", len(logTypeString)+2),
-			"->",
-			parser.scanner.LineString(),
-			"\n",
-			strings.Repeat(" ", column+len(logTypeString)+3),
-			strings.Repeat("^", tokenLength),
-		)
-	} else {
-		parser.scanner.SkipLine()
 	}
 }
 
 // Implementation of the Next method in the Parser interface.
 func (parser *parser) Next() (ElementType, interface{}) {
-	// Skipping empty lines.
-	var tokenType, token = parser.scanner.Next()
-	for tokenType == scanner.EOL || tokenType == scanner.Space {
-		tokenType, token = parser.scanner.Next()
+	// After the parsing is aborted, the rest of the file is not read.
+	if parser.failure != nil {
+		return EndOfFile, parser.failure
 	}
-	// When the end of the file is reached, it always returns (EndOfFile, nil).
-	if tokenType == scanner.EOF {
-		return EndOfFile, nil
+	// The elements of the called file are returned until its end.
+	if parser.called != nil {
+		if elementType, element := parser.nextCalled(); elementType != EndOfFile {
+			return elementType, element
+		}
+		if parser.failure != nil {
+			return EndOfFile, parser.failure
+		}
+	}
+	var (
+		tokenType, token = parser.nextToken()
+		keyword          string // The keyword of the line as it is written, if it was normalized.
+		keywordColumn    int    // The column of the normalized keyword.
+	)
+	for {
+		// Skipping empty lines.
+		for tokenType == scanner.EOL || tokenType == scanner.Space {
+			tokenType, token = parser.nextToken()
+		}
+		// When the end of the file is reached, it always returns (EndOfFile, nil),
+		// unless the error of the reader aborted the parsing.
+		if tokenType == scanner.EOF {
+			if parser.failure != nil {
+				return EndOfFile, parser.failure
+			}
+			return EndOfFile, nil
+		}
+		// The keywords written in another case are normalized, if the case-insensitive matching is enabled.
+		keyword = ""
+		if lower := strings.ToLower(token); tokenType == scanner.Word && parser.caseInsensitive && lower != token {
+			if _, ok := parser.elementTypeOf(token); !ok {
+				if _, ok = parser.elementTypeOf(lower); ok {
+					keyword, keywordColumn, token = token, parser.scanner.Column()-len(token)+2, lower
+				}
+			}
+		}
+		// Skipping the lines of the filtered out elements.
+		var elementType, ok = parser.elementTypeOf(token)
+		if tokenType != scanner.Word || !ok || parser.filter == nil || parser.filter(elementType) {
+			break
+		}
+		parser.scanner.SkipLine()
+		parser.stats.Filtered++
+		parser.countIndexed(elementType)
+		tokenType, token = parser.nextToken()
+	}
+	// The call statements of the .obj files are spliced instead of being parsed, if the FileResolver is set.
+	if tokenType == scanner.Word && token == "call" && parser.resolver != nil && parser.grammar == nil {
+		parser.call(token)
+		return parser.Next()
 	}
 	// If the first token in the String is found in the registry of possible formats for describing the model element,
 	// the String is processed by a parser from the registry.
-	if elementType, ok := elementDeclarationsMap[token]; tokenType == scanner.Word && ok {
-		var p = parsersRegistry[elementType]
+	if elementType, ok := parser.elementTypeOf(token); tokenType == scanner.Word && ok {
+		var p = parser.parserOf(elementType)
 		// If the parser from the registry is nil, then the format is not supported.
 		if p != nil {
 			var (
-				prevState stateType // Contains the previous state of the parser to get the error message.
-				state     stateType // Contains the parser state of a specific element.
+				prevState stateType   // Contains the previous state of the parser to get the error message.
+				state     stateType   // Contains the parser state of a specific element.
+				element   interface{} // The element being read.
+				slot      int         // The position of the reference in the vertex being read, see checkIndex.
 				er        error
 			)
+			element = p.newElement()
 			for {
-				tokenType, token = parser.scanner.Next()
+				tokenType, token = parser.nextToken()
+				switch tokenType {
+				case scanner.Space:
+					slot = vertexSlot
+				case scanner.Slash:
+					slot++
+				}
 				prevState = state
-				state = p.transition(tokenType, prevState)
+				state = p.transition(tokenType, token, prevState)
 				switch state {
 				// The transition to the start state means the successful completion of the parser.
-				case start:
-					return elementType, p.result()
+				// The transition to the warn state means the same, but the deviation from the specification is reported.
+				case start, warn:
+					if parser.stats.Elements == nil {
+						parser.stats.Elements = make(map[ElementType]int)
+					}
+					parser.stats.Elements[elementType]++
+					parser.countIndexed(elementType)
+					if parser.normalizeIndices {
+						parser.normalize(element)
+					}
+					// The line has already been read to the end, so it is not skipped.
+					if keyword != "" {
+						parser.report(Diagnostic{
+							Kind:     DeviationIssue,
+							Severity: parser.Severity(DeviationIssue),
+							File:     parser.file,
+							Line:     parser.scanner.Line() + 1,
+							Column:   keywordColumn,
+							Token:    keyword,
+							Message:  fmt.Sprintf("the keyword '%s' is normalized to '%s'", keyword, strings.ToLower(keyword)),
+							LineText: parser.scanner.LineString(),
+						})
+					}
+					if state == warn {
+						parser.log(p.message(tokenType, prevState), token, DeviationIssue)
+					}
+					return elementType, element
 				// The transition to the error state means an erroneous entry of the element.
 				// The erroneous line must be skipped and the next element must be searched for.
 				case err:
-					parser.log(p.message(tokenType, prevState), token, e)
+					parser.log(p.message(tokenType, prevState), token, InvalidElementIssue)
 					return parser.Next()
 				default:
-					er = p.action(state, token)
+					er = p.action(state, token, element)
 					if er != nil {
-						parser.log(er.Error(), token, e)
+						parser.log(er.Error(), token, InvalidElementIssue)
 						return parser.Next()
 					}
+					if parser.normalizeIndices && tokenType == scanner.Integer {
+						if er = parser.checkIndex(elementType, slot, token); er != nil {
+							parser.log(er.Error(), token, IndexIssue)
+							return parser.Next()
+						}
+					}
 				}
 			}
 		} else {
-			parser.log("unsupported element format - "+elementType.String(), token, w)
+			parser.countIndexed(elementType)
+			var line = parser.log("unsupported element format - "+elementType.String(), token, UnsupportedElementIssue)
+			if parser.unsupportedHandler != nil {
+				parser.unsupportedHandler(parser.scanner.Line()+1, line)
+			}
 		}
+	} else if tokenType == scanner.Word && parser.passUnknown {
+		return Unrecognized, parser.unknownElement(token)
 	} else {
-		parser.log("error in the name of the element type", token, e)
+		parser.log("error in the name of the element type", token, UnknownElementIssue)
 	}
 	// If the line was not read, it means that the parser was not found in the registry,
 	// need to search for the next element.
@@ -368,6 +828,39 @@ func (parser *parser) Output(w io.Writer) {
 	parser.outputWriter = w
 }
 
+// Implementation of the Diagnostics method in the Parser interface.
+func (parser *parser) Diagnostics() []Diagnostic {
+	return parser.diagnostics
+}
+
+// Implementation of the OnDiagnostic method in the Parser interface.
+func (parser *parser) OnDiagnostic(handler func(diagnostic Diagnostic)) {
+	parser.diagnosticHandler = handler
+}
+
+// Implementation of the SetSeverity method in the Parser interface.
+func (parser *parser) SetSeverity(kind IssueKind, severity Severity) {
+	parser.policy[kind] = severity
+}
+
+// Implementation of the Severity method in the Parser interface.
+func (parser *parser) Severity(kind IssueKind) Severity {
+	if severity, ok := parser.policy[kind]; ok {
+		return severity
+	}
+	return kind.DefaultSeverity()
+}
+
+// Implementation of the IgnoreInfos method in the Parser interface.
+func (parser *parser) IgnoreInfos(ii bool) {
+	parser.ignoreInfos = ii
+}
+
+// Implementation of the IsIgnoreInfos method in the Parser interface.
+func (parser *parser) IsIgnoreInfos() bool {
+	return parser.ignoreInfos
+}
+
 // Implementation of the IgnoreWarnings method in the Parser interface.
 func (parser *parser) IgnoreWarnings(iw bool) {
 	parser.ignoreWarnings = iw
@@ -388,7 +881,146 @@ func (parser *parser) IsIgnoreErrors() bool {
 	return parser.ignoreErrors
 }
 
+// Implementation of the Strict method in the Parser interface.
+func (parser *parser) Strict(strict bool) {
+	parser.strict = strict
+}
+
+// Implementation of the IsStrict method in the Parser interface.
+func (parser *parser) IsStrict() bool {
+	return parser.strict
+}
+
+// Implementation of the SetMaxErrors method in the Parser interface.
+func (parser *parser) SetMaxErrors(n int) {
+	if n < 0 {
+		n = 0
+	}
+	parser.maxErrors = n
+}
+
+// Implementation of the MaxErrors method in the Parser interface.
+func (parser *parser) MaxErrors() int {
+	return parser.maxErrors
+}
+
+// Implementation of the Err method in the Parser interface.
+func (parser *parser) Err() error {
+	return parser.failure
+}
+
+// Implementation of the OnUnsupported method in the Parser interface.
+func (parser *parser) OnUnsupported(handler func(line int, text string)) {
+	parser.unsupportedHandler = handler
+}
+
+// Implementation of the OnComment method in the Parser interface.
+func (parser *parser) OnComment(handler func(line int, text string)) {
+	parser.commentHandler = handler
+	parser.scanner.SkipComments(handler == nil)
+}
+
+// Implementation of the Filter method in the Parser interface.
+func (parser *parser) Filter(filter func(elementType ElementType) bool) {
+	parser.filter = filter
+}
+
 // Implementation of the Line method in the Parser interface.
 func (parser *parser) Line() int {
 	return parser.scanner.Line()
 }
+
+// Implementation of the Column method in the Parser interface.
+func (parser *parser) Column() int {
+	return parser.scanner.Column()
+}
+
+// Implementation of the Offset method in the Parser interface.
+func (parser *parser) Offset() int64 {
+	return int64(parser.scanner.Position() + 1)
+}
+
+// Implementation of the SetSize method in the Parser interface.
+func (parser *parser) SetSize(size int64) {
+	if size < 0 {
+		size = -1
+	}
+	parser.size = size
+}
+
+// Implementation of the Size method in the Parser interface.
+func (parser *parser) Size() int64 {
+	return parser.size
+}
+
+// Implementation of the Stats method in the Parser interface.
+func (parser *parser) Stats() Stats {
+	var stats = parser.stats
+	stats.Elements = make(map[ElementType]int, len(parser.stats.Elements))
+	for elementType, count := range parser.stats.Elements {
+		stats.Elements[elementType] = count
+	}
+	return stats
+}
+
+// Implementation of the Progress method in the Parser interface.
+func (parser *parser) Progress() float64 {
+	switch {
+	case parser.size < 0:
+		return -1
+	case parser.size == 0:
+		return 1
+	default:
+		return math.Min(1, float64(parser.Offset())/float64(parser.size))
+	}
+}
+
+// Implementation of the SetLimits method in the Parser interface.
+func (parser *parser) SetLimits(maxLineLength, maxTokenLength int) {
+	parser.scanner.SetLimits(maxLineLength, maxTokenLength)
+}
+
+// Implementation of the Limits method in the Parser interface.
+func (parser *parser) Limits() (int, int) {
+	return parser.scanner.Limits()
+}
+
+// Implementation of the PassUnknown method in the Parser interface.
+func (parser *parser) PassUnknown(pass bool) {
+	parser.passUnknown = pass
+}
+
+// Implementation of the IsPassUnknown method in the Parser interface.
+func (parser *parser) IsPassUnknown() bool {
+	return parser.passUnknown
+}
+
+// Implementation of the CaseInsensitiveKeywords method in the Parser interface.
+func (parser *parser) CaseInsensitiveKeywords(caseInsensitive bool) {
+	parser.caseInsensitive = caseInsensitive
+}
+
+// Implementation of the IsCaseInsensitiveKeywords method in the Parser interface.
+func (parser *parser) IsCaseInsensitiveKeywords() bool {
+	return parser.caseInsensitive
+}
+
+// Implementation of the NormalizeIndices method in the Parser interface.
+func (parser *parser) NormalizeIndices(normalize bool) {
+	parser.normalizeIndices = normalize
+}
+
+// Implementation of the IsNormalizeIndices method in the Parser interface.
+func (parser *parser) IsNormalizeIndices() bool {
+	return parser.normalizeIndices
+}
+
+// Implementation of the ForwardIndices method in the Parser interface.
+func (parser *parser) ForwardIndices(allow bool) {
+	parser.forwardIndices = allow
+}
+
+// Implementation of the IsForwardIndices method in the Parser interface.
+func (parser *parser) IsForwardIndices() bool {
+	return parser.forwardIndices
+}
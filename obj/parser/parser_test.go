@@ -1,8 +1,15 @@
 package parser
 
 import (
+	"computer_graphics/obj/parser/types"
+	"computer_graphics/obj/scanner"
+	"context"
 	"fmt"
+	"io"
 	"os"
+	"reflect"
+	"strings"
+	"testing"
 )
 
 // Reads all vertices from a file containing errors and an unsupported format.
@@ -38,17 +45,17 @@ func ExampleParser_Next_vertices() {
 		elementType, element = parser.Next()
 	}
 	// Output:
-	//vertex : &{-0.046146 0.050437 0.002961 0}
-	//vertex : &{-0.045498 0.049687 0.001989 0}
+	//vertex : &{-0.046146 0.050437 0.002961 1}
+	//vertex : &{-0.045498 0.049687 0.001989 1}
 	//vertex : &{-0.045306 0.049655 0.002956 3434}
-	//vertex : &{-0.045935 0.050494 0.003832 0}
+	//vertex : &{-0.045935 0.050494 0.003832 1}
 	//vertex : &{-0.044743 0.048768 0.002943 0}
-	//vertex : &{-0.044832 0.048663 0.001729 0}
-	//vertex : &{-0.047369 0.051618 0.004211 0}
-	//vertex : &{-0.044734 0.04789 0.002286 0}
-	//vertex : &{-0.045207 0.050247 0.004572 0}
-	//vertex : &{-0.046589 0.05193 0.006586 0}
-	//vertex : &{-0.044529 0.047892 0.003273 0}
+	//vertex : &{-0.044832 0.048663 0.001729 1}
+	//vertex : &{-0.047369 0.051618 0.004211 1}
+	//vertex : &{-0.044734 0.04789 0.002286 1}
+	//vertex : &{-0.045207 0.050247 0.004572 1}
+	//vertex : &{-0.046589 0.05193 0.006586 1}
+	//vertex : &{-0.044529 0.047892 0.003273 1}
 }
 
 // Reads all faces from a file containing errors and an unsupported format.
@@ -113,3 +120,950 @@ func ExampleParser_Next_faces() {
 	//face : &{[{17 17 17} {22 22 22} {29 29 29}]}
 	//face : &{[{23 23 23} {18 18 18} {26 26 26}]}
 }
+
+// Reports the unsupported element format as info instead of warning.
+func ExampleParser_SetSeverity() {
+	var (
+		output strings.Builder
+		parser = NewParser(strings.NewReader("call file.obj\nv 1 2 3\n"))
+	)
+	parser.Output(&output)
+	parser.SetSeverity(UnsupportedElementIssue, Info)
+	var elementType, element = parser.Next()
+	fmt.Printf("%s : %v\n", elementType, element)
+	fmt.Println(strings.SplitN(output.String(), "\n", 2)[0])
+	// Output:
+	//vertex : &{1 2 3 1}
+	//[INFO] line: 1, column: 1, token: 'call', message: unsupported element format - call command, the line will be skipped
+}
+
+// Collects the lines containing elements of an unsupported format.
+func ExampleParser_OnUnsupported() {
+	var parser = NewParser(strings.NewReader("v 1 2 3\nshadow_obj shadow.obj\nctech cparm 1.0\nv 4 5 6\n"))
+	parser.Output(nil)
+	parser.OnUnsupported(func(line int, text string) {
+		fmt.Printf("%d : %s\n", line, text)
+	})
+	for elementType, _ := parser.Next(); elementType != EndOfFile; elementType, _ = parser.Next() {
+	}
+	// Output:
+	//2 : shadow_obj shadow.obj
+	//3 : ctech cparm 1.0
+}
+
+// Collects the comments, including the comments at the end of the element lines.
+func ExampleParser_OnComment() {
+	var parser = NewParser(strings.NewReader("# Blender v2.74\n# www.blender.org\nv 1 2 3 # first\nv 4 5 6\n"))
+	parser.OnComment(func(line int, text string) {
+		fmt.Printf("%d : %s\n", line, text)
+	})
+	for elementType, element := parser.Next(); elementType != EndOfFile; elementType, element = parser.Next() {
+		fmt.Printf("%s : %v\n", elementType, element)
+	}
+	// Output:
+	//1 : # Blender v2.74
+	//2 : # www.blender.org
+	//3 : # first
+	//vertex : &{1 2 3 1}
+	//vertex : &{4 5 6 1}
+}
+
+// Parses the same tokens twice: the first pass counts the vertices, the second one reads the faces.
+func ExampleNewParserFromScanner() {
+	var recorder = scanner.NewRecorder(scanner.NewScanner(strings.NewReader("v 1 2 3\nv 4 5 6\nv 7 8 9\nf 1 2 3\n")))
+	var (
+		parser   = NewParserFromScanner(recorder)
+		vertices = 0
+	)
+	parser.Output(nil)
+	for elementType, _ := parser.Next(); elementType == Vertex; elementType, _ = parser.Next() {
+		vertices++
+	}
+	fmt.Println(vertices)
+	parser = NewParserFromScanner(recorder.Replay())
+	for elementType, element := parser.Next(); elementType != EndOfFile; elementType, element = parser.Next() {
+		if elementType == Face {
+			fmt.Printf("%d : %s : %v\n", parser.Line()+1, elementType, element)
+		}
+	}
+	// Output:
+	//3
+	//4 : face : &{[{1 0 0} {2 0 0} {3 0 0}]}
+}
+
+// Example of checking the indices and converting the negative indices to the absolute ones.
+func ExampleParser_NormalizeIndices() {
+	var parser = NewParser(strings.NewReader("v 0 0 0\nv 1 0 0\nvt 0 0\nv 0 1 0\nf -3/1 -2/-1 -1/1\nl 1 0\nf 1 2 4\np -3 -4\nf 1/1/1 2/1/1 3/1/1\n"))
+	parser.Output(nil)
+	parser.NormalizeIndices(true)
+	for elementType, element := parser.Next(); elementType != EndOfFile; elementType, element = parser.Next() {
+		if elementType == Face || elementType == Point {
+			fmt.Printf("%s : %v\n", elementType, element)
+		}
+	}
+	for _, diagnostic := range parser.Diagnostics() {
+		fmt.Printf("[%s] %d:%d %s: %s\n", diagnostic.Severity, diagnostic.Line, diagnostic.Column, diagnostic.Kind, diagnostic.Message)
+	}
+	// Output:
+	//face : &{[{1 1 0} {2 1 0} {3 1 0}]}
+	//[ERROR] 6:5 invalid element: index cannot be zero
+	//[ERROR] 7:7 index: unresolved vertex index: 4, the number of the elements defined before it is 3
+	//[ERROR] 8:6 index: unresolved vertex index: -4, the number of the elements defined before it is 3
+	//[ERROR] 9:7 index: unresolved vertex normal index: 1, the number of the elements defined before it is 0
+}
+
+// Example of accepting the references to the vertices defined after the line,
+// the negative indices are still resolved against the vertices defined before it.
+func ExampleParser_ForwardIndices() {
+	var parser = NewParser(strings.NewReader("v 0 0 0\nv 1 0 0\nf 1 -1 3\nf -3 1 2\nv 0 1 0\n"))
+	parser.Output(nil)
+	parser.NormalizeIndices(true)
+	parser.ForwardIndices(true)
+	for elementType, element := parser.Next(); elementType != EndOfFile; elementType, element = parser.Next() {
+		if elementType == Face {
+			fmt.Printf("%s : %v\n", elementType, element)
+		}
+	}
+	for _, diagnostic := range parser.Diagnostics() {
+		fmt.Printf("[%s] %d:%d %s: %s\n", diagnostic.Severity, diagnostic.Line, diagnostic.Column, diagnostic.Kind, diagnostic.Message)
+	}
+	// Output:
+	//face : &{[{1 0 0} {2 0 0} {3 0 0}]}
+	//[ERROR] 4:3 index: unresolved vertex index: -3, the number of the elements defined before it is 2
+}
+
+// Reads the keywords written in upper case by some exporters, the normalization is reported as a warning.
+func ExampleParser_CaseInsensitiveKeywords() {
+	var parser = NewParser(strings.NewReader("V 0 0 0\nv 1 0 0\n  Vt 0 0\nUsemtl Red\nF 1 2 3 4 x\nFOO 1\n"))
+	parser.Output(nil)
+	parser.CaseInsensitiveKeywords(true)
+	for elementType, element := parser.Next(); elementType != EndOfFile; elementType, element = parser.Next() {
+		fmt.Printf("%s : %v\n", elementType, element)
+	}
+	for _, diagnostic := range parser.Diagnostics() {
+		fmt.Printf("[%s] %d:%d %s: %s\n", diagnostic.Severity, diagnostic.Line, diagnostic.Column, diagnostic.Kind, diagnostic.Message)
+	}
+	// Output:
+	//vertex : &{0 0 0 1}
+	//vertex : &{1 0 0 1}
+	//vertex texture : &{0 0 0}
+	//use material : &{Red}
+	//[WARNING] 1:1 deviation: the keyword 'V' is normalized to 'v'
+	//[WARNING] 3:3 deviation: the keyword 'Vt' is normalized to 'vt'
+	//[WARNING] 4:1 deviation: the keyword 'Usemtl' is normalized to 'usemtl'
+	//[ERROR] 5:11 invalid element: invalid index, expected: INTEGER, received: WORD
+	//[ERROR] 6:1 unknown element: error in the name of the element type
+}
+
+// Returns the vertex colors written by some exporters as the unrecognized statements and handles them itself.
+func ExampleParser_PassUnknown() {
+	var parser = NewParser(strings.NewReader("v 0 0 0\nvc 1 0 0  # red\n#MRGB 00ff00\n1 2 3\nv 1 0 0\n"))
+	parser.Output(nil)
+	parser.PassUnknown(true)
+	for elementType, element := parser.Next(); elementType != EndOfFile; elementType, element = parser.Next() {
+		if unknown, ok := element.(*UnknownElement); ok {
+			fmt.Printf("%s : %s, line %d: %q\n", elementType, unknown.Keyword, unknown.Line, unknown.RawLine)
+		} else {
+			fmt.Printf("%s : %v\n", elementType, element)
+		}
+	}
+	for _, diagnostic := range parser.Diagnostics() {
+		fmt.Printf("[%s] %d:%d %s: %s\n", diagnostic.Severity, diagnostic.Line, diagnostic.Column, diagnostic.Kind, diagnostic.Message)
+	}
+	fmt.Println(parser.Stats())
+	// Output:
+	//vertex : &{0 0 0 1}
+	//unrecognized statement : vc, line 2: "vc 1 0 0  # red"
+	//vertex : &{1 0 0 1}
+	//[ERROR] 4:1 unknown element: error in the name of the element type
+	//vertex: 2, unrecognized statement: 1, skipped: 1, unsupported: 0, warnings: 0, errors: 1
+}
+
+// Example of counting the statements of the file without parsing them.
+func ExampleCountElements() {
+	var stats, err = CountElements(strings.NewReader("# cube\nv 0 0 0\nv 1 0 0\n  v 0 1 0\nv x\nvn 0 0 1\nf 1 2 3\nfoo\n"))
+	fmt.Println(stats.Elements, err)
+	// Output:
+	//map[vertex:4 vertex normal:1 face:1] <nil>
+}
+
+// Example of parsing the .obj file in memory at once.
+func ExampleParseBytes() {
+	var elements, diagnostics = ParseBytes([]byte("v 1 2 3\nv 4 5\nf 1 2 3\n"))
+	for _, element := range elements {
+		fmt.Printf("%d : %s : %v\n", element.Line, element.Type, element.Value)
+	}
+	for _, diagnostic := range diagnostics {
+		fmt.Printf("[%s] %d:%d %s\n", diagnostic.Severity, diagnostic.Line, diagnostic.Column, diagnostic.Message)
+	}
+	// Output:
+	//1 : vertex : &{1 2 3 1}
+	//3 : face : &{[{1 0 0} {2 0 0} {3 0 0}]}
+	//[ERROR] 2:6 parameter Z coordinate is not specified
+}
+
+// Reads texture vertices with one, two and three coordinates, the line with four coordinates is skipped.
+func ExampleParser_Next_textureVertices() {
+	var parser = NewParser(strings.NewReader("vt 0.5\nvt 0.25 0.75\nvt 1 0 0.5\nvt 0.1 0.2 0.3 0.4\n"))
+	parser.Output(nil)
+	for elementType, element := parser.Next(); elementType != EndOfFile; elementType, element = parser.Next() {
+		fmt.Printf("%s : %v\n", elementType, element)
+	}
+	// Output:
+	//vertex texture : &{0.5 0 0}
+	//vertex texture : &{0.25 0.75 0}
+	//vertex texture : &{1 0 0.5}
+}
+
+// Reads the points of the parameter space of a trimming curve and a surface.
+func ExampleParser_Next_parameterVertices() {
+	var parser = NewParser(strings.NewReader("vp 0.310000 3.210000 2.100000\nvp 0.5 1\nvp 0.25\n"))
+	parser.Output(nil)
+	for elementType, element := parser.Next(); elementType != EndOfFile; elementType, element = parser.Next() {
+		fmt.Printf("%s : %v\n", elementType, element)
+	}
+	// Output:
+	//vertex parameter : &{0.31 3.21 2.1}
+	//vertex parameter : &{0.5 1 1}
+	//vertex parameter : &{0.25 0 1}
+}
+
+// Example of reading the line elements.
+func ExampleParser_Next_lines() {
+	var parser = NewParser(strings.NewReader("l 1 2\nl 1/1 2/2 3/3 4/4\nl 1\n"))
+	parser.Output(nil)
+	for elementType, element := parser.Next(); elementType != EndOfFile; elementType, element = parser.Next() {
+		// The element type is not printed, because the comments starting with "//line " are compiler directives.
+		fmt.Println(elementType == Line, element)
+	}
+	// Output:
+	//true &{[{1 0} {2 0}]}
+	//true &{[{1 1} {2 2} {3 3} {4 4}]}
+}
+
+// Example of reading the point elements.
+func ExampleParser_Next_points() {
+	var parser = NewParser(strings.NewReader("p 1\np 1 2 -1\np\n"))
+	parser.Output(nil)
+	for elementType, element := parser.Next(); elementType != EndOfFile; elementType, element = parser.Next() {
+		fmt.Printf("%s : %v\n", elementType, element)
+	}
+	// Output:
+	//point : &{[1]}
+	//point : &{[1 2 -1]}
+}
+
+// Example of reading the group and object statements.
+func ExampleParser_Next_groups() {
+	var parser = NewParser(strings.NewReader("o Cube.001\ng body left-arm\ng default\ng\n"))
+	parser.Output(nil)
+	for elementType, element := parser.Next(); elementType != EndOfFile; elementType, element = parser.Next() {
+		fmt.Printf("%s : %v\n", elementType, element)
+	}
+	// Output:
+	//object : &{Cube.001}
+	//group : &{[body left-arm]}
+	//group : &{[default]}
+}
+
+// Example of reading the smoothing group statements.
+func ExampleParser_Next_smoothingGroups() {
+	var parser = NewParser(strings.NewReader("s 1\ns off\ns 0\ns on\ns\n"))
+	parser.Output(nil)
+	for elementType, element := parser.Next(); elementType != EndOfFile; elementType, element = parser.Next() {
+		fmt.Printf("%s : %v\n", elementType, element)
+	}
+	// Output:
+	//smoothing group : &{1}
+	//smoothing group : &{0}
+	//smoothing group : &{0}
+}
+
+// Example of reading the material statements.
+func ExampleParser_Next_materials() {
+	var parser = NewParser(strings.NewReader("mtllib low-poly-fox.mtl common.mtl\nusemtl fox_material\nusemtl\n"))
+	parser.Output(nil)
+	for elementType, element := parser.Next(); elementType != EndOfFile; elementType, element = parser.Next() {
+		fmt.Printf("%s : %v\n", elementType, element)
+	}
+	// Output:
+	//material library : &{[low-poly-fox.mtl common.mtl]}
+	//use material : &{fox_material}
+}
+
+// Example of reading the free-form curve and surface statements.
+func ExampleParser_Next_freeForm() {
+	var parser = NewParser(strings.NewReader(
+		"cstype rat bspline\ndeg 2 2\nsurf 0.0 1.0 0.0 1.0 1/1 2/2 3/3 4/4\nsurf 0.0 1.0 0.0 1.0 5//5\nparm u 0.0 0.0 1.0 1.0\nparm v 0.0 1.0\nend\n" +
+			"cstype bezier\ndeg 3\ncurv 0.0 1.0 1 2 3 4\ncurv2 1 2\nend x\nparm w 0.0 1.0\n",
+	))
+	parser.Output(nil)
+	for elementType, element := parser.Next(); elementType != EndOfFile; elementType, element = parser.Next() {
+		fmt.Printf("%s : %v\n", elementType, element)
+	}
+	// Output:
+	//curve surface type : &{true bspline}
+	//degree : &{2 2}
+	//surface : &{0 1 0 1 [{1 1 0} {2 2 0} {3 3 0} {4 4 0}]}
+	//surface : &{0 1 0 1 [{5 0 5}]}
+	//parameter : &{1 [0 0 1 1]}
+	//parameter : &{0 [0 1]}
+	//end : &{}
+	//curve surface type : &{false bezier}
+	//degree : &{3 0}
+	//curve : &{0 1 [1 2 3 4]}
+	//curve 2D : &{[1 2]}
+}
+
+// Example of reading the merging group and level of detail statements.
+func ExampleParser_Next_mergingGroups() {
+	var parser = NewParser(strings.NewReader("mg 1 0.5\nmg off\nmg off \nmg off 0.5\nmg 2\nlod 10\n"))
+	parser.Output(nil)
+	for elementType, element := parser.Next(); elementType != EndOfFile; elementType, element = parser.Next() {
+		fmt.Printf("%s : %v\n", elementType, element)
+	}
+	// Output:
+	//merging group : &{1 0.5}
+	//merging group : &{0 0}
+	//merging group : &{0 0}
+	//level of detail : &{10}
+}
+
+// Example of reading the statements with alternative forms.
+func ExampleParser_Next_alternativeForms() {
+	var parser = NewParser(strings.NewReader("usemap wood\nusemap off\nbmat u 1 0 0 1\nbmat v 1 0 0 1\nbmat off\n"))
+	parser.Output(nil)
+	for elementType, element := parser.Next(); elementType != EndOfFile; elementType, element = parser.Next() {
+		fmt.Printf("%s : %v\n", elementType, element)
+	}
+	// Output:
+	//use mapping : &{wood}
+	//use mapping : &{}
+	//basis matrix : &{1 [1 0 0 1]}
+	//basis matrix : &{0 [1 0 0 1]}
+}
+
+// Example of reading the interpolation statements consisting of an on/off value.
+func ExampleParser_Next_interpolations() {
+	var parser = NewParser(strings.NewReader("bevel on\nc_interp off  \nd_interp on # dissolve\nbevel\nbevel 1\nc_interp yes\n"))
+	parser.Output(nil)
+	for elementType, element := parser.Next(); elementType != EndOfFile; elementType, element = parser.Next() {
+		fmt.Printf("%s : %v\n", elementType, *element.(*types.Interpolation))
+	}
+	// Output:
+	//bevel interpolation : true
+	//color interpolation : false
+	//dissolve interpolation : true
+}
+
+// Reads only the vertices, the lines of other elements are skipped without parsing.
+func ExampleParser_Filter() {
+	var parser = NewParser(strings.NewReader("v 1 2 3\nvt 0.5 0.5 # texture\nf 1 2 3\nvn x y z\nv 4 5 6\n"))
+	parser.Filter(func(elementType ElementType) bool {
+		return elementType == Vertex
+	})
+	for elementType, element := parser.Next(); elementType != EndOfFile; elementType, element = parser.Next() {
+		fmt.Printf("%s : %v\n", elementType, element)
+	}
+	// Output:
+	//vertex : &{1 2 3 1}
+	//vertex : &{4 5 6 1}
+}
+
+// The vertex color written by some exporters: vc r g b.
+type vertexColor struct {
+	R, G, B float64
+}
+
+// Registers the parser of the vertex colors extension and reads it together with the standard elements.
+func ExampleRegister() {
+	var colorType = NewElementType("vertex color")
+	fmt.Println(Register("vc", colorType, &vertexColor{}))
+	fmt.Println(Register("vc", colorType, &vertexColor{}))
+	fmt.Println(Register("v", colorType, &vertexColor{}))
+	fmt.Println(Register("color", Face, &vertexColor{}))
+	fmt.Println(Register("normal", VertexNormal, &struct{ X []int }{}))
+	fmt.Println(SupportLevel(colorType))
+	var parser = NewParser(strings.NewReader("v 1 2 3\nvc 1 0.5 0\nvc 1 0\n"))
+	parser.Output(nil)
+	for elementType, element := parser.Next(); elementType != EndOfFile; elementType, element = parser.Next() {
+		fmt.Printf("%s : %v\n", elementType, element)
+	}
+	// Output:
+	//<nil>
+	//the vertex color already has a parser
+	//the keyword "v" is already used by the vertex
+	//the face already has a parser
+	//invalid prototype of the vertex normal: the slice field must have the min tag specified
+	//fully supported
+	//vertex : &{1 2 3 1}
+	//vertex color : &{1 0.5 0}
+}
+
+// The statement of a light source in a format written like the .obj files: light x y z [intensity].
+type light struct {
+	X, Y, Z   float64
+	Intensity float64 `name:"intensity" optional:"true"`
+}
+
+// Reads a file of another format with the keywords of the Grammar,
+// the keywords of the .obj files are not recognized by its Parser.
+func ExampleGrammar() {
+	var (
+		grammar   = NewGrammar()
+		lightType = NewElementType("light")
+	)
+	fmt.Println(grammar.Register("light", lightType, &light{}))
+	fmt.Println(grammar.Register("lamp", lightType, &light{}))
+	fmt.Println(grammar.Register("light", lightType, &light{}))
+	fmt.Println(grammar.Register("bulb", lightType, &vertexColor{}))
+	var parser = NewGrammarParser(strings.NewReader("light 1 2 3\nv 1 2 3\nlamp 0 0 1 0.5\n"), grammar)
+	parser.Output(nil)
+	for elementType, element := parser.Next(); elementType != EndOfFile; elementType, element = parser.Next() {
+		fmt.Printf("%s : %v\n", elementType, element)
+	}
+	fmt.Println(parser.Diagnostics())
+	// Output:
+	//<nil>
+	//<nil>
+	//the keyword "light" is already used by the light
+	//the light already has a parser
+	//light : &{1 2 3 0}
+	//light : &{0 0 1 0.5}
+	//[line 2, column 1: error in the name of the element type]
+}
+
+// The vertex color with the alpha channel, which is accepted, but is reported as a deviation: vca r g b [a].
+type vertexColorAlpha struct {
+	R, G, B float64
+	A       float64 `name:"alpha" optional:"true" warn:"the alpha channel is ignored"`
+}
+
+// Registers the parser of the element with the field reported by the warn tag,
+// the lines with the field are returned and the warnings are available as the diagnostics.
+func ExampleRegister_warn() {
+	var (
+		colorType = NewElementType("vertex color with alpha")
+		_         = Register("vca", colorType, &vertexColorAlpha{})
+		parser    = NewParser(strings.NewReader("vca 1 0.5 0\nvca 1 0.5 0 0.25\n"))
+	)
+	parser.Output(nil)
+	for elementType, element := parser.Next(); elementType != EndOfFile; elementType, element = parser.Next() {
+		fmt.Printf("%s : %v\n", elementType, element)
+	}
+	for _, diagnostic := range parser.Diagnostics() {
+		fmt.Printf("[%s] %s: %s\n", diagnostic.Severity, diagnostic.Kind, diagnostic)
+	}
+	fmt.Println(parser.Stats())
+	// Output:
+	//vertex color with alpha : &{1 0.5 0 0}
+	//vertex color with alpha : &{1 0.5 0 0.25}
+	//[WARNING] deviation: line 2, column 17: the alpha channel is ignored
+	//vertex color with alpha: 2, skipped: 0, unsupported: 0, warnings: 1, errors: 0
+}
+
+// Retains all the elements returned by the Parser and prints them after reading the whole file.
+func ExampleParser_Next_retained() {
+	var (
+		parser   = NewParser(strings.NewReader("v 1 2 3\ng cube\nv 4 5 6\nend\ng sphere\nend\n"))
+		elements []interface{}
+	)
+	for elementType, element := parser.Next(); elementType != EndOfFile; elementType, element = parser.Next() {
+		elements = append(elements, element)
+	}
+	for _, element := range elements {
+		fmt.Println(element)
+	}
+	// Output:
+	//&{1 2 3 1}
+	//&{[cube]}
+	//&{4 5 6 1}
+	//&{}
+	//&{[sphere]}
+	//&{}
+}
+
+// Counts the faces and prints the vertices of the model, the other elements are ignored by the BaseHandler.
+type vertexPrinter struct {
+	BaseHandler
+	faces int
+}
+
+// Prints the vertex.
+func (printer *vertexPrinter) Vertex(vertex *types.Vertex) error {
+	fmt.Println(vertex.X, vertex.Y, vertex.Z)
+	return nil
+}
+
+// Counts the face, stops the walk after the second face.
+func (printer *vertexPrinter) Face(*types.Face) error {
+	printer.faces++
+	if printer.faces == 2 {
+		return fmt.Errorf("too many faces")
+	}
+	return nil
+}
+
+// Walks the elements with the typed handler instead of casting the elements returned by the Next method.
+func ExampleParser_Walk() {
+	var (
+		parser  = NewParser(strings.NewReader("v 1 2 3\nv 4 5 6\ng cube\nv 7 8 9\nf 1 2 3\nf 3 2 1\nv 0 0 0\n"))
+		printer = &vertexPrinter{}
+	)
+	fmt.Println(parser.Walk(printer))
+	fmt.Println(printer.faces)
+	// Output:
+	//1 2 3
+	//4 5 6
+	//7 8 9
+	//too many faces
+	//2
+}
+
+// Receives the elements over the channel and stops reading the file after the first face by canceling the context.
+func ExampleParser_Stream() {
+	var (
+		parser      = NewParser(strings.NewReader("v 1 2 3\nv 4 5 6\n\nv 7 8 9\nf 1 2 3\nf 3 2 1\n"))
+		ctx, cancel = context.WithCancel(context.Background())
+	)
+	defer cancel()
+	for element := range parser.Stream(ctx) {
+		fmt.Printf("%d: %s : %v\n", element.Line, element.Type, element.Value)
+		if element.Type == Face {
+			cancel()
+			break
+		}
+	}
+	// Output:
+	//1: vertex : &{1 2 3 1}
+	//2: vertex : &{4 5 6 1}
+	//4: vertex : &{7 8 9 1}
+	//5: face : &{[{1 0 0} {2 0 0} {3 0 0}]}
+}
+
+// Prints the elements and the problems of the document.
+func printDocument(document *Document) {
+	for _, element := range document.Elements() {
+		fmt.Printf("%d: %s : %v\n", element.Line, element.Type, element.Value)
+	}
+	for _, diagnostic := range document.Diagnostics() {
+		fmt.Printf("[%s] %d:%d %s\n", diagnostic.Severity, diagnostic.Line, diagnostic.Column, diagnostic.Message)
+	}
+}
+
+// Edits the document and parses only the edited lines again.
+func ExampleDocument_Edit() {
+	var document = NewDocument("v 1 2 3\nv 4 5\nv 7 8 9\nf 1 2 3\n")
+	printDocument(document)
+	fmt.Println(document.Edit(2, 1, "v 4 5 6\nvt 0.5 0.5"))
+	printDocument(document)
+	fmt.Println(document.Edit(1, 1, ""), document.LinesCount())
+	printDocument(document)
+	fmt.Println(document.Edit(5, 1, "v 0 0 0"))
+	// Output:
+	//1: vertex : &{1 2 3 1}
+	//3: vertex : &{7 8 9 1}
+	//4: face : &{[{1 0 0} {2 0 0} {3 0 0}]}
+	//[ERROR] 2:6 parameter Z coordinate is not specified
+	//<nil>
+	//1: vertex : &{1 2 3 1}
+	//2: vertex : &{4 5 6 1}
+	//3: vertex texture : &{0.5 0.5 0}
+	//4: vertex : &{7 8 9 1}
+	//5: face : &{[{1 0 0} {2 0 0} {3 0 0}]}
+	//<nil> 4
+	//1: vertex : &{4 5 6 1}
+	//2: vertex texture : &{0.5 0.5 0}
+	//3: vertex : &{7 8 9 1}
+	//4: face : &{[{1 0 0} {2 0 0} {3 0 0}]}
+	//the lines 5-5 are out of the document of 4 lines
+}
+
+// Collects the diagnostics instead of writing them, the warnings are passed to the handler as soon as they are found.
+func ExampleParser_Diagnostics() {
+	var parser = NewParser(strings.NewReader("v 1 2 3\nvn 0 0 1\nv 1 2\nfoo 1\n"))
+	parser.Output(nil)
+	parser.OnDiagnostic(func(diagnostic Diagnostic) {
+		if diagnostic.Severity == Warning {
+			fmt.Printf("warning: %s\n", diagnostic.Message)
+		}
+	})
+	for elementType, _ := parser.Next(); elementType != EndOfFile; elementType, _ = parser.Next() {
+	}
+	for _, diagnostic := range parser.Diagnostics() {
+		fmt.Printf("[%s] %d:%d %s: %q\n", diagnostic.Severity, diagnostic.Line, diagnostic.Column, diagnostic.Message, diagnostic.LineText)
+	}
+	// Output:
+	//warning: unsupported element format - vertex normal
+	//[WARNING] 2:1 unsupported element format - vertex normal: "vn 0 0 1"
+	//[ERROR] 3:6 parameter Z coordinate is not specified: "v 1 2"
+	//[ERROR] 4:1 error in the name of the element type: "foo 1"
+}
+
+// Stops reading at the first invalid line in the strict mode, the unsupported elements are only reported as warnings.
+func ExampleParser_Strict() {
+	var parser = NewParser(strings.NewReader("v 1 2 3\nvn 0 0 1\nv 1 2\nv 4 5 6\n"))
+	parser.Output(nil)
+	parser.Strict(true)
+	var elementType, element = parser.Next()
+	for ; elementType != EndOfFile; elementType, element = parser.Next() {
+		fmt.Printf("%s : %v\n", elementType, element)
+	}
+	fmt.Println("element:", element)
+	fmt.Println("error:", parser.Err())
+	fmt.Println(len(parser.Diagnostics()))
+	// Output:
+	//vertex : &{1 2 3 1}
+	//element: line 3, column 6: parameter Z coordinate is not specified
+	//error: line 3, column 6: parameter Z coordinate is not specified
+	//2
+}
+
+// Prints the progress of reading the input after each element.
+func ExampleParser_Progress() {
+	var parser = NewParser(strings.NewReader("v 1 2 3\nvt 0.5 0.5\nf 1 2 3\n"))
+	for elementType, _ := parser.Next(); elementType != EndOfFile; elementType, _ = parser.Next() {
+		fmt.Printf("%s: %d %d %d %.2f\n", elementType, parser.Line(), parser.Column(), parser.Offset(), parser.Progress())
+	}
+	fmt.Printf("%.2f\n", NewParserFromScanner(scanner.NewScanner(strings.NewReader(""))).Progress())
+	// Output:
+	//vertex: 0 7 8 0.30
+	//vertex texture: 1 10 19 0.70
+	//face: 2 7 27 1.00
+	//-1.00
+}
+
+// Prints the summary of the file after reading it.
+func ExampleParser_Stats() {
+	var parser = NewParser(strings.NewReader("v 1 2 3\nv 4 5 6\nv 7 8\nvn 0 0 1\nvt 0 0\nf 1 2 3\ng cube\nf 3 2 1\n"))
+	parser.Output(nil)
+	parser.Filter(func(elementType ElementType) bool {
+		return elementType != VertexTexture
+	})
+	for elementType, _ := parser.Next(); elementType != EndOfFile; elementType, _ = parser.Next() {
+	}
+	var stats = parser.Stats()
+	fmt.Println(stats.Elements[Face], stats.Filtered)
+	fmt.Println(stats)
+	// Output:
+	//2 1
+	//vertex: 2, face: 2, group: 1, skipped: 2, unsupported: 1, warnings: 1, errors: 1
+}
+
+// Checks the exact counts of the Stats and of the diagnostics of each kind.
+func TestParser_Stats(t *testing.T) {
+	var tests = []struct {
+		name  string
+		text  string
+		setup func(parser Parser)
+		stats Stats
+		kinds map[IssueKind]int
+	}{
+		{
+			name:  "empty",
+			text:  "",
+			stats: Stats{Elements: map[ElementType]int{}},
+			kinds: map[IssueKind]int{},
+		},
+		{
+			name: "filtered",
+			text: "v 1 2 3\nv 4 5 6\nv 7 8\nvn 0 0 1\nvt 0 0\nf 1 2 3\ng cube\nf 3 2 1\n",
+			setup: func(parser Parser) {
+				parser.Filter(func(elementType ElementType) bool {
+					return elementType != VertexTexture
+				})
+			},
+			stats: Stats{
+				Elements:    map[ElementType]int{Vertex: 2, Face: 2, Group: 1},
+				Skipped:     2,
+				Filtered:    1,
+				Unsupported: 1,
+				Warnings:    1,
+				Errors:      1,
+			},
+			kinds: map[IssueKind]int{UnsupportedElementIssue: 1, InvalidElementIssue: 1},
+		},
+		{
+			name: "deviations",
+			text: "V 0 0 0\nv 1 0 0\nVt 0 0\nF 1 2 3\nfoo\n",
+			setup: func(parser Parser) {
+				parser.CaseInsensitiveKeywords(true)
+			},
+			stats: Stats{
+				Elements: map[ElementType]int{Vertex: 2, VertexTexture: 1, Face: 1},
+				Skipped:  1,
+				Warnings: 3,
+				Errors:   1,
+			},
+			kinds: map[IssueKind]int{UnknownElementIssue: 1, DeviationIssue: 3},
+		},
+		{
+			name: "severities",
+			text: "v 1 2 3\nv 1 x 3\nvn 0 0 1\nvn 0 1 0\nf 1 2 3\nf 1 2 5\n",
+			setup: func(parser Parser) {
+				parser.NormalizeIndices(true)
+				parser.SetSeverity(UnsupportedElementIssue, Info)
+				parser.SetSeverity(IndexIssue, Warning)
+			},
+			stats: Stats{
+				Elements:    map[ElementType]int{Vertex: 1},
+				Skipped:     5,
+				Unsupported: 2,
+				Infos:       2,
+				Warnings:    2,
+				Errors:      1,
+			},
+			kinds: map[IssueKind]int{InvalidElementIssue: 1, UnsupportedElementIssue: 2, IndexIssue: 2},
+		},
+	}
+	for _, test := range tests {
+		var parser = NewParser(strings.NewReader(test.text))
+		parser.Output(nil)
+		if test.setup != nil {
+			test.setup(parser)
+		}
+		for elementType, _ := parser.Next(); elementType != EndOfFile; elementType, _ = parser.Next() {
+		}
+		// The stats are compared field by field, the String method omits the Infos and the Filtered.
+		if stats := parser.Stats(); !reflect.DeepEqual(stats, test.stats) {
+			t.Errorf("%s: expected the stats %#v, received %#v", test.name, test.stats, stats)
+		}
+		var kinds = make(map[IssueKind]int)
+		for _, diagnostic := range parser.Diagnostics() {
+			kinds[diagnostic.Kind]++
+		}
+		if fmt.Sprint(kinds) != fmt.Sprint(test.kinds) {
+			t.Errorf("%s: expected the diagnostics of the kinds %v, received %v", test.name, test.kinds, kinds)
+		}
+	}
+}
+
+// FileResolver that opens the files stored in memory.
+type memoryResolver map[string]string
+
+// Implementation of the Open method in the FileResolver interface.
+func (resolver memoryResolver) Open(name string) (io.ReadCloser, error) {
+	var text, ok = resolver[name]
+	if !ok {
+		return nil, fmt.Errorf("file %s not found", name)
+	}
+	return io.NopCloser(strings.NewReader(text)), nil
+}
+
+// Splices the elements of the called files into the elements of the main file.
+func ExampleParser_Resolve() {
+	var parser = NewParser(strings.NewReader("v 0 0 0\ncall quad.obj 1 2\ncall missing.obj\nf 1 2 3\n"))
+	parser.Output(nil)
+	parser.Resolve(memoryResolver{
+		"quad.obj": "v $1 0 0\nv $1 $2 0\nv 0 $2 x\n",
+	})
+	for elementType, element := parser.Next(); elementType != EndOfFile; elementType, element = parser.Next() {
+		fmt.Println(elementType, element)
+	}
+	for _, diagnostic := range parser.Diagnostics() {
+		fmt.Printf("[%s] %s\n", diagnostic.Severity, diagnostic.Error())
+	}
+	// Output:
+	//vertex &{0 0 0 1}
+	//vertex &{1 0 0 1}
+	//vertex &{1 2 0 1}
+	//face &{[{1 0 0} {2 0 0} {3 0 0}]}
+	//[ERROR] quad.obj: line 3, column 7: invalid Z coordinate, expected: FLOAT, received: WORD
+	//[ERROR] line 3, column 6: failed to read the called file - file missing.obj not found
+}
+
+// Checks that the included files are read up to the limit and the larger ones fail with the ErrIncludedTooLarge.
+func TestLimitIncluded(t *testing.T) {
+	var tests = []struct {
+		text string
+		want string
+		err  error
+	}{
+		{"v 1 2 3\n", "v 1 2 3\n", nil},
+		{"v 1 2 3\n" + "v", "v 1 2 3\n", ErrIncludedTooLarge},
+	}
+	for _, test := range tests {
+		var data, err = io.ReadAll(&includedReader{reader: strings.NewReader(test.text), left: 8})
+		if string(data) != test.want || err != test.err {
+			t.Errorf("%q: expected %q and %v, received %q and %v", test.text, test.want, test.err, data, err)
+		}
+	}
+	var parser = NewParser(strings.NewReader("call endless.obj\n"))
+	parser.Output(nil)
+	parser.Resolve(endlessResolver{})
+	for elementType, _ := parser.Next(); elementType != EndOfFile; elementType, _ = parser.Next() {
+	}
+	var diagnostics = parser.Diagnostics()
+	if len(diagnostics) != 1 || !strings.HasSuffix(diagnostics[0].Message, ErrIncludedTooLarge.Error()) {
+		t.Errorf("expected the called file larger than the limit, received %v", diagnostics)
+	}
+}
+
+// FileResolver that opens the endless files of the comments.
+type endlessResolver struct{}
+
+// Implementation of the Open method in the FileResolver interface.
+func (endlessResolver) Open(string) (io.ReadCloser, error) {
+	return io.NopCloser(endlessReader{}), nil
+}
+
+// Reader of the endless comment.
+type endlessReader struct{}
+
+// Implementation of the Read method in the io.Reader interface.
+func (endlessReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = '#'
+	}
+	return len(p), nil
+}
+
+// Stops the parsing after the second error, the abort is written to the output at the position of the last error.
+func ExampleParser_SetMaxErrors() {
+	var (
+		parser = NewParser(strings.NewReader("v 1 2 3\nv 1 x 3\nv 1 2 x\nv 4 5 6\n"))
+		output strings.Builder
+	)
+	parser.Output(&output)
+	parser.SetMaxErrors(2)
+	for elementType, element := parser.Next(); elementType != EndOfFile; elementType, element = parser.Next() {
+		fmt.Println(elementType, element)
+	}
+	fmt.Println(parser.Err())
+	// Only the first lines of the diagnostics are printed, without the highlighted lines of the file.
+	for _, line := range strings.Split(output.String(), "\n") {
+		if strings.HasPrefix(line, "[") {
+			fmt.Println(line)
+		}
+	}
+	// Output:
+	//vertex &{1 2 3 1}
+	//too many errors: 2 errors found, the rest of the file is skipped
+	//[ERROR] line: 2, column: 5, token: 'x', message: invalid Y coordinate, expected: FLOAT, received: WORD, the line will be skipped
+	//[ERROR] line: 3, column: 7, token: 'x', message: invalid Z coordinate, expected: FLOAT, received: WORD, the line will be skipped
+	//[ERROR] line: 3, column: 7, token: 'x', message: too many errors: 2 errors found, the rest of the file is skipped
+}
+
+// Skips the lines that are too long for the parser.
+func ExampleParser_SetLimits() {
+	var parser = NewParser(strings.NewReader("v 1 2 3\nf " + strings.Repeat("1 ", 100) + "\nvt 0.5 0.25\n"))
+	parser.Output(nil)
+	parser.SetLimits(64, 16)
+	for elementType, element := parser.Next(); elementType != EndOfFile; elementType, element = parser.Next() {
+		fmt.Println(elementType, element)
+	}
+	for _, diagnostic := range parser.Diagnostics() {
+		fmt.Printf("[%s] %s, %d bytes of the line are kept\n", diagnostic.Severity, diagnostic.Error(), len(diagnostic.LineText))
+	}
+	// Output:
+	//vertex &{1 2 3 1}
+	//vertex texture &{0.5 0.25 0}
+	//[ERROR] line 2, column 65: the line is longer than 64 bytes, 64 bytes of the line are kept
+}
+
+// Reads the elements of the file on two workers, the problems are reported with the numbers of the lines in the file.
+func ExampleParallelParser() {
+	var parser = NewParallelParser(strings.NewReader("v 1 2 3\nv 4 5\nvn 0 0 1\nf 1 2 3\n"), 2)
+	defer parser.Close()
+	parser.Output(nil)
+	for elementType, element := parser.Next(); elementType != EndOfFile; elementType, element = parser.Next() {
+		fmt.Println(parser.Line(), elementType, element)
+	}
+	for _, diagnostic := range parser.Diagnostics() {
+		fmt.Printf("[%s] %s\n", diagnostic.Severity, diagnostic.Error())
+	}
+	// Output:
+	//0 vertex &{1 2 3 1}
+	//3 face &{[{1 0 0} {2 0 0} {3 0 0}]}
+	//[ERROR] line 2, column 6: parameter Z coordinate is not specified
+	//[WARNING] line 3, column 1: unsupported element format - vertex normal
+}
+
+// Generates the text of a .obj file with the specified number of vertices and faces connecting them.
+func generateObj(count int) string {
+	var builder strings.Builder
+	for i := 0; i < count; i++ {
+		fmt.Fprintf(&builder, "v %f %f %f\n", float64(i)*0.001, float64(i)*-0.002, float64(i)*0.003)
+	}
+	for i := 1; i+2 <= count; i++ {
+		fmt.Fprintf(&builder, "f %d/%d/%d %d/%d/%d %d/%d/%d\n", i, i, i, i+1, i+1, i+1, i+2, i+2, i+2)
+	}
+	return builder.String()
+}
+
+// Checks that the ParallelParser returns the same elements as the Parser.
+func TestParallelParser(t *testing.T) {
+	var (
+		text       = generateObj(30000) + "v 1 2\n" + generateObj(10)
+		sequential = NewParser(strings.NewReader(text))
+		parallel   = NewParallelParser(strings.NewReader(text), 4)
+	)
+	defer parallel.Close()
+	sequential.Output(nil)
+	parallel.Output(nil)
+	for count := 0; ; count++ {
+		var (
+			sequentialType, sequentialElement = sequential.Next()
+			parallelType, parallelElement     = parallel.Next()
+		)
+		if sequentialType != parallelType || fmt.Sprint(sequentialElement) != fmt.Sprint(parallelElement) ||
+			sequential.Line() != parallel.Line() {
+			t.Fatalf("element %d: expected %s %v on line %d, received %s %v on line %d", count,
+				sequentialType, sequentialElement, sequential.Line(), parallelType, parallelElement, parallel.Line())
+		}
+		if sequentialType == EndOfFile {
+			break
+		}
+	}
+	if fmt.Sprint(sequential.Diagnostics()) != fmt.Sprint(parallel.Diagnostics()) {
+		t.Errorf("expected diagnostics %v, received %v", sequential.Diagnostics(), parallel.Diagnostics())
+	}
+}
+
+// Comparing the throughput of the Parser with the generated parsers and with the finite state machines using reflection
+// and the ParallelParser on a large .obj file, with the throughput of counting its statements by CountElements.
+func BenchmarkParser(b *testing.B) {
+	var text = generateObj(200000)
+	b.Run("sequential", func(b *testing.B) {
+		b.SetBytes(int64(len(text)))
+		for i := 0; i < b.N; i++ {
+			var parser = NewParser(strings.NewReader(text))
+			for elementType, _ := parser.Next(); elementType != EndOfFile; elementType, _ = parser.Next() {
+			}
+		}
+	})
+	b.Run("reflection", func(b *testing.B) {
+		// The generated parsers are replaced with the finite state machines they were generated for.
+		var registry = parsersRegistry
+		defer func() {
+			parsersRegistry = registry
+		}()
+		for i, p := range parsersRegistry {
+			if generated, ok := p.(*generatedParser); ok {
+				parsersRegistry[i] = generated.finiteStateMachine
+			}
+		}
+		b.SetBytes(int64(len(text)))
+		for i := 0; i < b.N; i++ {
+			var parser = NewParser(strings.NewReader(text))
+			for elementType, _ := parser.Next(); elementType != EndOfFile; elementType, _ = parser.Next() {
+			}
+		}
+	})
+	b.Run("count", func(b *testing.B) {
+		b.SetBytes(int64(len(text)))
+		for i := 0; i < b.N; i++ {
+			if _, err := CountElements(strings.NewReader(text)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("parallel", func(b *testing.B) {
+		b.SetBytes(int64(len(text)))
+		for i := 0; i < b.N; i++ {
+			var parser = NewParallelParser(strings.NewReader(text), 0)
+			for elementType, _ := parser.Next(); elementType != EndOfFile; elementType, _ = parser.Next() {
+			}
+		}
+	})
+}
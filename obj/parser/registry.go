@@ -1,51 +1,138 @@
 package parser
 
-import "computer_graphics/obj/parser/types"
+import (
+	"computer_graphics/obj/parser/types"
+	"computer_graphics/obj/scanner"
+	"fmt"
+	"strings"
+)
+
+//go:generate go run -tags reflectparsers ../../cmd/genparsers -o parsers_generated.go
 
 // A registry of parsers for each type of element in the .obj file.
 // To add support for the new model description format, you need to implement a parser for this element
 // and put this parser in the registry.
 // The parser index in the registry must match the value of the ElementType constant corresponding to the element type.
 // Look at the comments on the lines of the registry.
+// The parsers of the unsupported elements and of the extension elements can be added at runtime by Register.
+// The finite state machines built from the structures are replaced by the parsers from the parsers_generated.go file,
+// which do not use reflection, if they are generated for the same structures, see GenerateParsers.
 var parsersRegistry = [...]elementParser{
-	buildParser(Vertex, types.NewVertex()), // Vertex
-	nil,                                    // VertexTexture
-	nil,                                    // VertexNormal
-	nil,                                    // VertexParameter
-	nil,                                    // CurveSurfaceType
-	nil,                                    // Degree
-	nil,                                    // BasisMatrix
-	nil,                                    // Step
-	nil,                                    // Point
-	nil,                                    // Line
-	buildParser(Face, types.NewFace()),     // Face
-	nil,                                    // Curve
-	nil,                                    // Curve2D
-	nil,                                    // Surface
-	nil,                                    // Parameter
-	nil,                                    // Trim
-	nil,                                    // Hole
-	nil,                                    // SpecialCurve
-	nil,                                    // SpecialPoint
-	nil,                                    // End
-	nil,                                    // Connect
-	nil,                                    // Group
-	nil,                                    // SmoothingGroup
-	nil,                                    // MergingGroup
-	nil,                                    // Object
-	nil,                                    // BevelInterpolation
-	nil,                                    // ColorInterpolation
-	nil,                                    // DissolveInterpolation
-	nil,                                    // LevelOfDetail
-	nil,                                    // MapLibrary
-	nil,                                    // UseMapping
-	nil,                                    // UseMaterial
-	nil,                                    // MaterialLibrary
-	nil,                                    // ShadowObject
-	nil,                                    // TraceObject
-	nil,                                    // CurveApproximation
-	nil,                                    // SurfaceApproximation
-	nil,                                    // Call
-	nil,                                    // Scmp
-	nil,                                    // Csh
+	buildParser(Vertex, types.NewVertex()),               // Vertex
+	buildParser(VertexTexture, types.NewTextureVertex()), // VertexTexture
+	nil, // VertexNormal
+	buildParser(VertexParameter, types.NewParameterVertex()),   // VertexParameter
+	buildParser(CurveSurfaceType, types.NewCurveSurfaceType()), // CurveSurfaceType
+	buildParser(Degree, types.NewDegree()),                     // Degree
+	buildParser(BasisMatrix, types.NewBasisMatrix()),           // BasisMatrix
+	nil,                                          // Step
+	buildParser(Point, types.NewPoint()),         // Point
+	buildParser(Line, types.NewLine()),           // Line
+	buildParser(Face, types.NewFace()),           // Face
+	buildParser(Curve, types.NewCurve()),         // Curve
+	buildParser(Curve2D, types.NewCurve2D()),     // Curve2D
+	buildParser(Surface, types.NewSurface()),     // Surface
+	buildParser(Parameter, types.NewParameter()), // Parameter
+	nil,                                  // Trim
+	nil,                                  // Hole
+	nil,                                  // SpecialCurve
+	nil,                                  // SpecialPoint
+	buildParser(End, types.NewEnd()),     // End
+	nil,                                  // Connect
+	buildParser(Group, types.NewGroup()), // Group
+	buildParser(SmoothingGroup, types.NewSmoothingGroup()),       // SmoothingGroup
+	buildParser(MergingGroup, types.NewMergingGroup()),           // MergingGroup
+	buildParser(Object, types.NewObject()),                       // Object
+	buildParser(BevelInterpolation, types.NewInterpolation()),    // BevelInterpolation
+	buildParser(ColorInterpolation, types.NewInterpolation()),    // ColorInterpolation
+	buildParser(DissolveInterpolation, types.NewInterpolation()), // DissolveInterpolation
+	buildParser(LevelOfDetail, types.NewLevelOfDetail()),         // LevelOfDetail
+	nil, // MapLibrary
+	buildParser(UseMapping, types.NewUseMapping()),           // UseMapping
+	buildParser(UseMaterial, types.NewUseMaterial()),         // UseMaterial
+	buildParser(MaterialLibrary, types.NewMaterialLibrary()), // MaterialLibrary
+	nil, // ShadowObject
+	nil, // TraceObject
+	nil, // CurveApproximation
+	nil, // SurfaceApproximation
+	nil, // Call
+	nil, // Scmp
+	nil, // Csh
+}
+
+// The names of the extension element types created by NewElementType,
+// the element type follows the EndOfFile marker and the previous extension element types.
+var extensionNames []string
+
+// The parsers of the extension element types installed by Register, nil if the parser is not registered yet.
+var extensionParsers []elementParser
+
+// Creates a new element type for the element that is not described in the specification of .obj files,
+// for example, the 'vc' vertex colors written by some exporters.
+// The name is returned by the String method of the element type.
+// The parser of the element must be installed by Register.
+// Panics if there are too many element types.
+func NewElementType(name string) ElementType {
+	var elementType = int(EndOfFile) + 1 + len(extensionNames)
+	if elementType > int(^ElementType(0)) {
+		panic("too many extension element types")
+	}
+	extensionNames = append(extensionNames, name)
+	extensionParsers = append(extensionParsers, nil)
+	return ElementType(elementType)
+}
+
+// Returns the parser of the element type from the registry or from the extension parsers,
+// nil if the element type is not supported.
+func parserOf(elementType ElementType) elementParser {
+	switch {
+	case int(elementType) < len(parsersRegistry):
+		return parsersRegistry[elementType]
+	case elementType > EndOfFile && int(elementType-EndOfFile) <= len(extensionParsers):
+		return extensionParsers[elementType-EndOfFile-1]
+	default:
+		return nil
+	}
+}
+
+// Builds the parser of the elements described by the prototype and installs it,
+// so that the lines starting with the keyword are parsed as the elements of the type.
+// The prototype must be a pointer to a structure or bool, the same as the structures from the package types,
+// see the buildParser documentation for the rules of the structure fields and tags.
+// The Parser returns the elements as the pointers to the structures of the prototype type.
+//
+// The element type can be one of the unsupported standard types or the type created by NewElementType.
+// Returns an error if the keyword is not a word, the keyword is already used by another element type,
+// the element type already has a parser or the prototype cannot be parsed.
+//
+// Register is intended to be called during the initialization of the program,
+// it must not be called concurrently with the parsing.
+func Register(keyword string, elementType ElementType, prototype interface{}) (e error) {
+	var tokenType, token = scanner.NewScanner(strings.NewReader(keyword)).Next()
+	if tokenType != scanner.Word || token != keyword {
+		return fmt.Errorf("the keyword %q is not a word", keyword)
+	}
+	if elementType == EndOfFile || elementType > EndOfFile && int(elementType-EndOfFile) > len(extensionParsers) {
+		return fmt.Errorf("the element type %d is not a standard or extension element type", elementType)
+	}
+	if used, ok := elementDeclarationsMap[keyword]; ok && used != elementType {
+		return fmt.Errorf("the keyword %q is already used by the %s", keyword, used)
+	}
+	if parserOf(elementType) != nil {
+		return fmt.Errorf("the %s already has a parser", elementType)
+	}
+	// The builder panics if the prototype does not follow the rules.
+	defer func() {
+		if r := recover(); r != nil {
+			e = fmt.Errorf("invalid prototype of the %s: %v", elementType, r)
+		}
+	}()
+	var p = buildParser(elementType, prototype)
+	if elementType < EndOfFile {
+		parsersRegistry[elementType] = p
+	} else {
+		extensionParsers[elementType-EndOfFile-1] = p
+	}
+	elementDeclarationsMap[keyword] = elementType
+	return nil
 }
@@ -0,0 +1,60 @@
+package parser
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// The statistics of the elements read by the Parser and the problems found by it, see Parser.Stats.
+type Stats struct {
+	Elements    map[ElementType]int // The number of the elements of each type returned by the Parser.
+	Skipped     int                 // The number of the lines skipped because of the problems.
+	Filtered    int                 // The number of the lines skipped by the filter, see Parser.Filter.
+	Unsupported int                 // The number of the statements of the unsupported formats.
+	Infos       int                 // The number of the problems with the Info severity.
+	Warnings    int                 // The number of the problems with the Warning severity.
+	Errors      int                 // The number of the problems with the Error severity.
+}
+
+// Counts the problem described by the diagnostic, each problem except the DeviationIssue skips a line.
+func (stats *Stats) count(diagnostic Diagnostic) {
+	if diagnostic.Kind != DeviationIssue {
+		stats.Skipped++
+	}
+	if diagnostic.Kind == UnsupportedElementIssue {
+		stats.Unsupported++
+	}
+	switch diagnostic.Severity {
+	case Info:
+		stats.Infos++
+	case Warning:
+		stats.Warnings++
+	default:
+		stats.Errors++
+	}
+}
+
+// Returns the summary of the statistics, for example,
+// 'vertex: 12000, face: 24000, skipped: 3, unsupported: 3, warnings: 3, errors: 0'.
+// The elements are listed in the order of their types, the types without elements are omitted.
+func (stats Stats) String() string {
+	var types = make([]ElementType, 0, len(stats.Elements))
+	for elementType := range stats.Elements {
+		types = append(types, elementType)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		return types[i] < types[j]
+	})
+	var parts = make([]string, 0, len(types)+4)
+	for _, elementType := range types {
+		parts = append(parts, fmt.Sprintf("%s: %d", elementType, stats.Elements[elementType]))
+	}
+	parts = append(parts,
+		fmt.Sprintf("skipped: %d", stats.Skipped),
+		fmt.Sprintf("unsupported: %d", stats.Unsupported),
+		fmt.Sprintf("warnings: %d", stats.Warnings),
+		fmt.Sprintf("errors: %d", stats.Errors),
+	)
+	return strings.Join(parts, ", ")
+}
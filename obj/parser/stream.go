@@ -0,0 +1,36 @@
+package parser
+
+import "context"
+
+// An element read by the Parser and delivered by Parser.Stream.
+type Element struct {
+	Type  ElementType // The type of the element.
+	Value interface{} // The element, the same value that is returned by the Next method.
+	Line  int         // The number of the line of the element starting from 1, as the Line of the Diagnostic.
+}
+
+// Parses the whole .obj file read into memory and returns its elements in the order of the lines
+// and the diagnostics of the problems found in it.
+// Unlike the Parser created by NewParser, the problems are not written to os.Stderr, they are only returned.
+// The call statements are not supported, since the data has no directory to resolve the called files in.
+// Any data can be passed, so it is also the entry point for fuzzing the scanner and the parser.
+func ParseBytes(data []byte) ([]Element, []Diagnostic) {
+	var chunk = parseChunk(data, 0)
+	return chunk.elements, chunk.diagnostics
+}
+
+// Implementation of the Stream method in the Parser interface.
+func (parser *parser) Stream(ctx context.Context) <-chan Element {
+	var elements = make(chan Element)
+	go func() {
+		defer close(elements)
+		for elementType, value := parser.Next(); elementType != EndOfFile; elementType, value = parser.Next() {
+			select {
+			case elements <- Element{Type: elementType, Value: value, Line: parser.Line() + 1}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return elements
+}
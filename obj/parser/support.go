@@ -0,0 +1,42 @@
+package parser
+
+// The level of support of an element type by the Parser.
+type Support uint8
+
+const (
+	NotSupported       Support = iota // The elements of the type are skipped as unsupported, see Parser.OnUnsupported.
+	PartiallySupported                // The elements are parsed, but some forms allowed by the specification are rejected.
+	FullySupported                    // All forms of the element described in the specification are parsed.
+)
+
+// Converts a support level constant to its string representation.
+var supportsMap = [...]string{"not supported", "partially supported", "fully supported"}
+
+// Converts a support level constant to its string representation.
+func (support Support) String() string {
+	return supportsMap[support]
+}
+
+// The element types that have a parser in the registry, but do not accept all the forms from the specification.
+// The scanner reads the names only as words starting with a letter or an underscore,
+// so the names starting with a digit and the paths containing directories are rejected.
+var partiallySupported = map[ElementType]bool{
+	Group:           true, // The names starting with a digit, the group statement without names.
+	Object:          true, // The names starting with a digit.
+	UseMaterial:     true, // The names starting with a digit.
+	MaterialLibrary: true, // The paths to other directories.
+}
+
+// Returns the level of support of the element type by the Parser,
+// so that tools can warn users about the statements that will be lost before reading the file.
+// The EndOfFile marker is not an element and is not supported.
+func SupportLevel(elementType ElementType) Support {
+	switch {
+	case parserOf(elementType) == nil:
+		return NotSupported
+	case partiallySupported[elementType]:
+		return PartiallySupported
+	default:
+		return FullySupported
+	}
+}
@@ -1,5 +1,14 @@
+// The structures of the elements of .obj files filled by the parser package, the only package defining them.
+//
+// The exported names of the structures, their fields and the types of the fields are the stable API:
+// they are changed only together with the Version. The struct tags describe the grammar of the statements
+// for the parser and are not part of the API, the parser may change them in any version,
+// so the other packages should not read them. New fields and structures can be added without changing the Version.
 package types
 
+// The version of the API of the package, incremented on each incompatible change of the structures.
+const Version = 2
+
 // One of the possible direction values.
 type DirectionType uint8
 
@@ -10,24 +19,73 @@ const (
 
 // Specifies a geometric vertex.
 type Vertex struct {
-	X float64 `name:"X coordinate"`                     // X coordinate of the vertex.
-	Y float64 `name:"Y coordinate"`                     // Y coordinate of the vertex.
-	Z float64 `name:"Z coordinate"`                     // Z coordinate of the vertex.
-	W float64 `name:"weight parameter" optional:"true"` // Weight required for rational curves and surfaces.
+	X float64 `name:"X coordinate"` // X coordinate of the vertex.
+	Y float64 `name:"Y coordinate"` // Y coordinate of the vertex.
+	Z float64 `name:"Z coordinate"` // Z coordinate of the vertex.
+	// Weight required for rational curves and surfaces, 1 if omitted. The specified weight is reported as the deviation.
+	W float64 `name:"weight parameter" optional:"true" default:"1" warn:"the weight parameter is only used by the rational curves and surfaces"`
 }
 
-// Creates a new vertex.
+// Creates a new vertex with the default weight.
 func NewVertex() *Vertex {
-	return &Vertex{}
+	return &Vertex{W: 1}
+}
+
+// Specifies a texture vertex and its coordinates.
+type TextureVertex struct {
+	U float64 `name:"horizontal direction"`                 // The value for the horizontal direction of the texture.
+	V float64 `name:"vertical direction" optional:"true"`   // The value for the vertical direction of the texture.
+	W float64 `name:"depth of the texture" optional:"true"` // The value for the depth of the texture.
+}
+
+// Creates a new texture vertex.
+func NewTextureVertex() *TextureVertex {
+	return &TextureVertex{}
+}
+
+// Specifies a point in the parameter space of a curve or surface.
+type ParameterVertex struct {
+	U float64 `name:"point of the curve"`                              // The point in the parameter space of a curve or the first coordinate in the parameter space of a surface.
+	V float64 `name:"second coordinate" optional:"true"`               // The second coordinate in the parameter space of a surface.
+	W float64 `name:"weight of the point" optional:"true" default:"1"` // The weight required for rational trimming curves, 1 if omitted.
+}
+
+// Creates a new parameter vertex with the default weight.
+func NewParameterVertex() *ParameterVertex {
+	return &ParameterVertex{W: 1}
+}
+
+// Specifies a point element.
+type Point struct {
+	Vertices []int `name:"vertex" min:"1" nonzero:"true"` // Reference numbers for the vertices of the points.
+}
+
+// Creates a new point.
+func NewPoint() *Point {
+	return &Point{}
+}
+
+// Specifies a line element.
+type Line struct {
+	// Contains information about all vertexes of the line.
+	Vertices []struct {
+		Index   int `name:"index" nonzero:"true"`                   // Reference number for the vertex.
+		Texture int `name:"texture" optional:"true" nonzero:"true"` // Reference number for the texture vertex.
+	} `name:"vertex" delimiter:"slash" min:"2"`
+}
+
+// Creates a new line.
+func NewLine() *Line {
+	return &Line{}
 }
 
 // Specifies a face element.
 type Face struct {
 	// Contains information about all vertexes of the face.
 	Vertices []struct {
-		Index   int `name:"index"`                   // Reference number for the vertex.
-		Texture int `name:"texture" optional:"true"` // Reference number for the texture vertex.
-		Normal  int `name:"normal" optional:"true"`  // Reference number for the vertex normal.
+		Index   int `name:"index" nonzero:"true"`                   // Reference number for the vertex.
+		Texture int `name:"texture" optional:"true" nonzero:"true"` // Reference number for the texture vertex.
+		Normal  int `name:"normal" optional:"true" nonzero:"true"`  // Reference number for the vertex normal.
 	} `name:"vertex" delimiter:"slash" min:"3"`
 }
 
@@ -35,3 +93,196 @@ type Face struct {
 func NewFace() *Face {
 	return &Face{}
 }
+
+// Specifies a group statement.
+type Group struct {
+	Names []string `name:"group name" min:"1"` // The names of the groups to which the following elements belong.
+}
+
+// Creates a new group statement.
+func NewGroup() *Group {
+	return &Group{}
+}
+
+// Specifies an object statement.
+type Object struct {
+	Name string `name:"object name"` // The name of the object to which the following elements belong.
+}
+
+// Creates a new object statement.
+func NewObject() *Object {
+	return &Object{}
+}
+
+// Specifies a smoothing group statement.
+type SmoothingGroup struct {
+	Number int `name:"group number" off:"true"` // The number of the smoothing group, 0 or 'off' turns off the smoothing.
+}
+
+// Creates a new smoothing group statement.
+func NewSmoothingGroup() *SmoothingGroup {
+	return &SmoothingGroup{}
+}
+
+// Specifies a merging group statement.
+type MergingGroup struct {
+	Number     int     `name:"group number" off:"true"` // The number of the merging group, 0 or 'off' turns off the merging.
+	Resolution float64 `name:"resolution"`              // The maximum distance between the merged surfaces, zero if the merging is off.
+}
+
+// Creates a new merging group statement.
+func NewMergingGroup() *MergingGroup {
+	return &MergingGroup{}
+}
+
+// Specifies a texture map statement.
+type UseMapping struct {
+	Name string `name:"map name" oneof:"off"` // The name of the texture map, empty if the 'off' turns off the texture mapping.
+}
+
+// Creates a new texture map statement.
+func NewUseMapping() *UseMapping {
+	return &UseMapping{}
+}
+
+// Specifies a material name statement.
+type UseMaterial struct {
+	Name string `name:"material name"` // The name of the material applied to the following elements.
+}
+
+// Creates a new material name statement.
+func NewUseMaterial() *UseMaterial {
+	return &UseMaterial{}
+}
+
+// Specifies a material library statement.
+type MaterialLibrary struct {
+	Files []string `name:"file name" min:"1"` // The names of the .mtl files containing the material definitions.
+}
+
+// Creates a new material library statement.
+func NewMaterialLibrary() *MaterialLibrary {
+	return &MaterialLibrary{}
+}
+
+// Specifies the type of the following curves and surfaces.
+// The type is one of bmatrix, bezier, bspline, cardinal or taylor, optionally preceded by rat for rational forms.
+type CurveSurfaceType struct {
+	Rat  bool   `name:"rat" prefix:"rat"`                                                // True if the rat word is specified.
+	Type string `name:"type" oneof:"bmatrix|bezier|bspline|cardinal|taylor" only:"true"` // The name of the type.
+}
+
+// Creates a new curve or surface type statement.
+func NewCurveSurfaceType() *CurveSurfaceType {
+	return &CurveSurfaceType{}
+}
+
+// Returns true if the curves and surfaces are rational.
+func (t *CurveSurfaceType) Rational() bool {
+	return t.Rat
+}
+
+// Returns the name of the type without the rat prefix.
+func (t *CurveSurfaceType) Name() string {
+	return t.Type
+}
+
+// Specifies the degree of the following curves and surfaces.
+type Degree struct {
+	U int `name:"degree in the u direction"`                 // The degree of the curves and of the surfaces in the u direction.
+	V int `name:"degree in the v direction" optional:"true"` // The degree of the surfaces in the v direction.
+}
+
+// Creates a new degree statement.
+func NewDegree() *Degree {
+	return &Degree{}
+}
+
+// Specifies the basis matrix of the following curves and surfaces with the bmatrix type.
+type BasisMatrix struct {
+	Direction DirectionType `name:"direction"`            // The direction of the basis matrix.
+	Matrix    []float64     `name:"matrix value" min:"1"` // The values of the basis matrix by rows.
+}
+
+// Creates a new basis matrix statement.
+func NewBasisMatrix() *BasisMatrix {
+	return &BasisMatrix{}
+}
+
+// Specifies a curve.
+type Curve struct {
+	Start    float64 `name:"starting parameter value"`             // The starting parameter value of the curve.
+	End      float64 `name:"ending parameter value"`               // The ending parameter value of the curve.
+	Vertices []int   `name:"control point" min:"2" nonzero:"true"` // Reference numbers for the vertices of the control points.
+}
+
+// Creates a new curve.
+func NewCurve() *Curve {
+	return &Curve{}
+}
+
+// Specifies a 2D curve on a surface.
+type Curve2D struct {
+	Vertices []int `name:"control point" min:"2" nonzero:"true"` // Reference numbers for the parameter space vertices of the control points.
+}
+
+// Creates a new 2D curve.
+func NewCurve2D() *Curve2D {
+	return &Curve2D{}
+}
+
+// Specifies a surface.
+type Surface struct {
+	StartS float64 `name:"starting parameter value in the u direction"` // The starting parameter value in the u direction.
+	EndS   float64 `name:"ending parameter value in the u direction"`   // The ending parameter value in the u direction.
+	StartT float64 `name:"starting parameter value in the v direction"` // The starting parameter value in the v direction.
+	EndT   float64 `name:"ending parameter value in the v direction"`   // The ending parameter value in the v direction.
+	// Contains information about all control points of the surface.
+	Vertices []struct {
+		Index   int `name:"index" nonzero:"true"`                   // Reference number for the vertex.
+		Texture int `name:"texture" optional:"true" nonzero:"true"` // Reference number for the texture vertex.
+		Normal  int `name:"normal" optional:"true" nonzero:"true"`  // Reference number for the vertex normal.
+	} `name:"control point" delimiter:"slash" min:"1"`
+}
+
+// Creates a new surface.
+func NewSurface() *Surface {
+	return &Surface{}
+}
+
+// Specifies the global parameter values of the curve or surface.
+type Parameter struct {
+	Direction DirectionType `name:"direction"`               // The direction of the parameter values.
+	Values    []float64     `name:"parameter value" min:"2"` // The parameter values (knots) in the direction.
+}
+
+// Creates a new parameter statement.
+func NewParameter() *Parameter {
+	return &Parameter{}
+}
+
+// Specifies the end of the curve or surface body.
+type End struct{}
+
+// Creates a new end statement.
+func NewEnd() *End {
+	return &End{}
+}
+
+// Specifies the on/off value of the bevel, color and dissolve interpolation statements.
+type Interpolation bool
+
+// Creates a new interpolation statement.
+func NewInterpolation() *Interpolation {
+	return new(Interpolation)
+}
+
+// Specifies a level of detail statement.
+type LevelOfDetail struct {
+	Level int `name:"level"` // The level of detail to be displayed from 1 to 100, 0 turns off the level of detail.
+}
+
+// Creates a new level of detail statement.
+func NewLevelOfDetail() *LevelOfDetail {
+	return &LevelOfDetail{}
+}
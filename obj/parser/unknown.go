@@ -0,0 +1,27 @@
+package parser
+
+// The element type of the statements with unknown keywords returned by the Parser
+// if the passing of them is enabled, see Parser.PassUnknown.
+// The elements of the type are *UnknownElement. The type has no keyword and no parser, so it is not supported.
+var Unrecognized = NewElementType("unrecognized statement")
+
+// A statement with an unknown keyword returned by the Parser as the Unrecognized element,
+// so that the callers can handle the extensions written by specific exporters themselves.
+type UnknownElement struct {
+	Keyword string // The first word of the statement.
+	RawLine string // The full text of the line containing the statement.
+	Line    int    // The number of the line containing the statement starting from 1.
+}
+
+// Skips the rest of the line of the statement with the unknown keyword and returns it as the UnknownElement.
+func (parser *parser) unknownElement(keyword string) *UnknownElement {
+	var (
+		read       = parser.scanner.LineString()
+		skipped, _ = parser.scanner.SkipLine()
+	)
+	if parser.stats.Elements == nil {
+		parser.stats.Elements = make(map[ElementType]int)
+	}
+	parser.stats.Elements[Unrecognized]++
+	return &UnknownElement{Keyword: keyword, RawLine: read + skipped, Line: parser.scanner.Line() + 1}
+}
@@ -0,0 +1,140 @@
+package parser
+
+import "computer_graphics/obj/parser/types"
+
+// Receives the elements read by Parser.Walk, each by the method corresponding to its type,
+// so that the elements do not need to be cast from interface{}.
+// The elements of the types that do not have their own method are passed to the Element method.
+// If a method returns an error, the walk stops and returns the error.
+//
+// Embed the BaseHandler to implement only the methods of the needed element types.
+type ElementHandler interface {
+	// Receives the geometric vertices.
+	Vertex(vertex *types.Vertex) error
+	// Receives the texture vertices.
+	TextureVertex(vertex *types.TextureVertex) error
+	// Receives the parameter space vertices.
+	ParameterVertex(vertex *types.ParameterVertex) error
+	// Receives the points.
+	Point(point *types.Point) error
+	// Receives the lines.
+	Line(line *types.Line) error
+	// Receives the faces.
+	Face(face *types.Face) error
+	// Receives the group statements.
+	Group(group *types.Group) error
+	// Receives the object statements.
+	Object(object *types.Object) error
+	// Receives the smoothing group statements.
+	SmoothingGroup(group *types.SmoothingGroup) error
+	// Receives the material name statements.
+	UseMaterial(material *types.UseMaterial) error
+	// Receives the material library statements.
+	MaterialLibrary(library *types.MaterialLibrary) error
+	// Receives the elements of all other types, including the extension element types, with their types.
+	Element(elementType ElementType, element interface{}) error
+}
+
+// Implements the ElementHandler interface by ignoring all the elements.
+// Embed it into the handler to implement only the methods of the needed element types.
+type BaseHandler struct{}
+
+// Implementation of the Vertex method in the ElementHandler interface.
+func (BaseHandler) Vertex(*types.Vertex) error {
+	return nil
+}
+
+// Implementation of the TextureVertex method in the ElementHandler interface.
+func (BaseHandler) TextureVertex(*types.TextureVertex) error {
+	return nil
+}
+
+// Implementation of the ParameterVertex method in the ElementHandler interface.
+func (BaseHandler) ParameterVertex(*types.ParameterVertex) error {
+	return nil
+}
+
+// Implementation of the Point method in the ElementHandler interface.
+func (BaseHandler) Point(*types.Point) error {
+	return nil
+}
+
+// Implementation of the Line method in the ElementHandler interface.
+func (BaseHandler) Line(*types.Line) error {
+	return nil
+}
+
+// Implementation of the Face method in the ElementHandler interface.
+func (BaseHandler) Face(*types.Face) error {
+	return nil
+}
+
+// Implementation of the Group method in the ElementHandler interface.
+func (BaseHandler) Group(*types.Group) error {
+	return nil
+}
+
+// Implementation of the Object method in the ElementHandler interface.
+func (BaseHandler) Object(*types.Object) error {
+	return nil
+}
+
+// Implementation of the SmoothingGroup method in the ElementHandler interface.
+func (BaseHandler) SmoothingGroup(*types.SmoothingGroup) error {
+	return nil
+}
+
+// Implementation of the UseMaterial method in the ElementHandler interface.
+func (BaseHandler) UseMaterial(*types.UseMaterial) error {
+	return nil
+}
+
+// Implementation of the MaterialLibrary method in the ElementHandler interface.
+func (BaseHandler) MaterialLibrary(*types.MaterialLibrary) error {
+	return nil
+}
+
+// Implementation of the Element method in the ElementHandler interface.
+func (BaseHandler) Element(ElementType, interface{}) error {
+	return nil
+}
+
+// Implementation of the Walk method in the Parser interface.
+func (parser *parser) Walk(handler ElementHandler) error {
+	for elementType, element := parser.Next(); elementType != EndOfFile; elementType, element = parser.Next() {
+		if err := dispatch(handler, elementType, element); err != nil {
+			return err
+		}
+	}
+	return parser.Err()
+}
+
+// Passes the element to the method of the handler corresponding to the element type.
+func dispatch(handler ElementHandler, elementType ElementType, element interface{}) error {
+	switch elementType {
+	case Vertex:
+		return handler.Vertex(element.(*types.Vertex))
+	case VertexTexture:
+		return handler.TextureVertex(element.(*types.TextureVertex))
+	case VertexParameter:
+		return handler.ParameterVertex(element.(*types.ParameterVertex))
+	case Point:
+		return handler.Point(element.(*types.Point))
+	case Line:
+		return handler.Line(element.(*types.Line))
+	case Face:
+		return handler.Face(element.(*types.Face))
+	case Group:
+		return handler.Group(element.(*types.Group))
+	case Object:
+		return handler.Object(element.(*types.Object))
+	case SmoothingGroup:
+		return handler.SmoothingGroup(element.(*types.SmoothingGroup))
+	case UseMaterial:
+		return handler.UseMaterial(element.(*types.UseMaterial))
+	case MaterialLibrary:
+		return handler.MaterialLibrary(element.(*types.MaterialLibrary))
+	default:
+		return handler.Element(elementType, element)
+	}
+}
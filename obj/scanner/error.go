@@ -0,0 +1,46 @@
+package scanner
+
+import "fmt"
+
+// One of the possible kinds of problems that the Scanner can find.
+type ErrorKind uint8
+
+const (
+	IOError      ErrorKind = iota // Reading from the reader failed, the Scanner stops reading as if the end of the reader is reached.
+	LexicalError                  // A sequence of characters does not match any token type, the Unknown token is returned.
+	LimitError                    // A line or a token is too long, the rest of the line is skipped, see Scanner.SetLimits.
+)
+
+// Converts an error kind constant to its string representation.
+var errorKindNamesMap = [...]string{"I/O", "lexical", "limit"}
+
+// Converts an error kind constant to its string representation.
+func (kind ErrorKind) String() string {
+	return errorKindNamesMap[kind]
+}
+
+// Describes a problem found by the Scanner.
+// Allows you to distinguish failures of the reader from invalid data in it.
+type ScanError struct {
+	Line   int       // The number of the line where the problem was found, starting from 1.
+	Column int       // The position in the line where the problem was found, starting from 1, the byte order mark is not counted.
+	Offset int       // The position where the problem was found relative to the beginning of the sequence of bytes being read, including the byte order mark.
+	Kind   ErrorKind // The kind of the problem.
+	Text   string    // The text of the Unknown token for the LexicalError or the error message for the IOError and the LimitError.
+	Err    error     // The error returned by the reader for the IOError, nil for the LexicalError.
+}
+
+// Implementation of the Error method in the error interface.
+func (e *ScanError) Error() string {
+	switch e.Kind {
+	case IOError, LimitError:
+		return fmt.Sprintf("line: %d, column: %d, offset: %d, %s error: %s", e.Line, e.Column, e.Offset, e.Kind, e.Text)
+	default:
+		return fmt.Sprintf("line: %d, column: %d, offset: %d, %s error: unknown token '%s'", e.Line, e.Column, e.Offset, e.Kind, e.Text)
+	}
+}
+
+// Returns the error returned by the reader, so that errors.Is and errors.As can be used with the ScanError.
+func (e *ScanError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,230 @@
+package scanner
+
+// A token read by the recorded Scanner together with the state of the Scanner after reading it.
+type recordedToken struct {
+	tokenType  TokenType
+	token      string
+	line       int        // The result of the Line method.
+	column     int        // The result of the Column method.
+	position   int        // The result of the Position method.
+	lineLength int        // The length of the line fragment returned by the LineString method.
+	err        *ScanError // The error found while reading the token or nil.
+}
+
+// Records the tokens read from another Scanner, so that they can be replayed any number of times
+// without re-reading and re-lexing the underlying reader.
+//
+// The Recorder is itself a Scanner: reading from it reads the tokens from the recorded Scanner for the first time.
+// The Replay method creates independent Scanners that start from the beginning of the recording.
+// If a replaying Scanner reaches the end of the recording before the recorded Scanner has returned EOF,
+// the missing tokens are read and recorded, so the passes can be done in any order and can stop at any point.
+//
+// Comments are always recorded, each replaying Scanner skips them according to its own SkipComments setting.
+type Recorder struct {
+	Scanner // The Scanner reading the tokens for the first time.
+
+	scanner Scanner         // The recorded Scanner.
+	initial recordedToken   // The state of the recorded Scanner before reading the first token.
+	tokens  []recordedToken // The tokens read from the recorded Scanner.
+	lines   []string        // The full text of the lines that have been read to the end.
+	done    bool            // true if the EOF token has been recorded.
+
+	lastError *ScanError // The last error of the recorded Scanner.
+}
+
+// Creates a new Recorder that records the tokens of the scanner.
+// The scanner must not be used directly after that.
+func NewRecorder(scanner Scanner) *Recorder {
+	var recorder = &Recorder{scanner: scanner}
+	recorder.initial = recordedToken{
+		line:     scanner.Line(),
+		column:   scanner.Column(),
+		position: scanner.Position(),
+		err:      scanner.LastError(),
+	}
+	recorder.lastError = recorder.initial.err
+	recorder.Scanner = recorder.newPlayer(scanner.IsSkipComments())
+	scanner.SkipComments(false)
+	return recorder
+}
+
+// Returns a new Scanner that replays the recorded tokens from the beginning.
+// Comments are skipped by default, like in the Scanner created by the NewScanner function.
+func (recorder *Recorder) Replay() Scanner {
+	return recorder.newPlayer(true)
+}
+
+// Returns the number of the recorded tokens.
+func (recorder *Recorder) Len() int {
+	return len(recorder.tokens)
+}
+
+// Creates a new player starting from the beginning of the recording.
+func (recorder *Recorder) newPlayer(skipComments bool) *player {
+	return &player{recorder: recorder, current: &recorder.initial, skipComments: skipComments}
+}
+
+// Returns the recorded token by its index, reading it from the recorded Scanner if necessary.
+// If the index is beyond the end of the recording, returns the EOF token.
+func (recorder *Recorder) token(index int) *recordedToken {
+	for index >= len(recorder.tokens) && !recorder.done {
+		recorder.record()
+	}
+	if index >= len(recorder.tokens) {
+		return &recorder.tokens[len(recorder.tokens)-1]
+	}
+	return &recorder.tokens[index]
+}
+
+// Reads the next token from the recorded Scanner and adds it to the recording.
+func (recorder *Recorder) record() {
+	var (
+		tokenType, token = recorder.scanner.Next()
+		last             = &recorder.initial
+		recorded         = recordedToken{
+			tokenType: tokenType,
+			token:     token,
+			line:      recorder.scanner.Line(),
+			column:    recorder.scanner.Column(),
+			position:  recorder.scanner.Position(),
+		}
+	)
+	if len(recorder.tokens) != 0 {
+		last = &recorder.tokens[len(recorder.tokens)-1]
+	}
+	// The length of the line fragment is calculated from the tokens, so as not to copy the line for each token.
+	if recorded.line == last.line {
+		recorded.lineLength = last.lineLength
+	}
+	if tokenType != EOL {
+		recorded.lineLength += len(token)
+	}
+	if err := recorder.scanner.LastError(); err != recorder.lastError {
+		recorded.err = err
+		recorder.lastError = err
+	}
+	recorder.tokens = append(recorder.tokens, recorded)
+	if tokenType == EOL || tokenType == EOF {
+		// Saving the full text of the line that has been read to the end.
+		for len(recorder.lines) <= recorded.line {
+			recorder.lines = append(recorder.lines, "")
+		}
+		recorder.lines[recorded.line] = recorder.scanner.LineString()
+	}
+	recorder.done = tokenType == EOF
+}
+
+// Returns the text of the line up to the specified length.
+func (recorder *Recorder) lineString(line, length int) string {
+	var text string
+	if line < len(recorder.lines) {
+		text = recorder.lines[line]
+	} else {
+		// The line has not been read to the end yet, but the recorded Scanner contains its beginning.
+		text = recorder.scanner.LineString()
+	}
+	if length > len(text) {
+		length = len(text)
+	}
+	return text[:length]
+}
+
+// Implements the Scanner interface.
+// Returns the tokens of the Recorder one by one.
+type player struct {
+	recorder     *Recorder
+	index        int            // The index of the next token.
+	current      *recordedToken // The last returned token.
+	lastError    *ScanError     // The last problem found in the returned tokens.
+	skipComments bool           // true if comments should be skipped.
+}
+
+// Moves to the next token and returns it.
+func (player *player) step() *recordedToken {
+	player.current = player.recorder.token(player.index)
+	if player.current.tokenType != EOF {
+		player.index++
+	}
+	return player.current
+}
+
+// Implementation of the Next method in the Scanner interface.
+func (player *player) Next() (TokenType, string) {
+	var token = player.step()
+	for token.tokenType == Comment && player.skipComments {
+		token = player.step()
+	}
+	if token.err != nil {
+		player.lastError = token.err
+	}
+	return token.tokenType, token.token
+}
+
+// Implementation of the SkipLine method in the Scanner interface.
+func (player *player) SkipLine() (string, int) {
+	if player.current.tokenType == EOL {
+		return "", 0
+	}
+	var (
+		line     = player.current.line
+		skipped  = player.current.lineLength // The length of the line fragment that was read before skipping.
+		position = player.current.position
+	)
+	for player.step().tokenType != EOL && player.current.tokenType != EOF {
+	}
+	if player.current.tokenType == EOF && player.current.err != nil && player.current.err.Kind == IOError {
+		player.lastError = player.current.err
+	}
+	var text = player.LineString()
+	if player.current.line != line || skipped > len(text) {
+		skipped = 0
+	}
+	return text[skipped:], player.current.position - position
+}
+
+// Implementation of the LineString method in the Scanner interface.
+func (player *player) LineString() string {
+	return player.recorder.lineString(player.current.line, player.current.lineLength)
+}
+
+// Implementation of the Position method in the Scanner interface.
+func (player *player) Position() int {
+	return player.current.position
+}
+
+// Implementation of the Line method in the Scanner interface.
+func (player *player) Line() int {
+	return player.current.line
+}
+
+// Implementation of the Column method in the Scanner interface.
+func (player *player) Column() int {
+	return player.current.column
+}
+
+// Implementation of the IsSkipComments method in the Scanner interface.
+func (player *player) IsSkipComments() bool {
+	return player.skipComments
+}
+
+// Implementation of the LastError method in the Scanner interface.
+func (player *player) LastError() *ScanError {
+	return player.lastError
+}
+
+// Implementation of the SkipComments method in the Scanner interface.
+func (player *player) SkipComments(skipComments bool) {
+	player.skipComments = skipComments
+}
+
+// Implementation of the SetLimits method in the Scanner interface.
+// The limits are set for the recorded Scanner, so they apply to the tokens that have not been recorded yet
+// and are shared by all replaying Scanners.
+func (player *player) SetLimits(maxLineLength, maxTokenLength int) {
+	player.recorder.scanner.SetLimits(maxLineLength, maxTokenLength)
+}
+
+// Implementation of the Limits method in the Scanner interface.
+func (player *player) Limits() (int, int) {
+	return player.recorder.scanner.Limits()
+}
@@ -0,0 +1,111 @@
+package scanner
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// The state of the Scanner after reading a token.
+type scannerState struct {
+	tokenType  TokenType
+	token      string
+	line       int
+	column     int
+	position   int
+	lineString string
+	lastError  string
+	skipped    string // The text returned by the SkipLine method.
+	skipCount  int    // The number of bytes returned by the SkipLine method.
+}
+
+// Reads the tokens from the Scanner, calling SkipLine after every skipEvery token if skipEvery is not 0,
+// and returns the states of the Scanner after each token.
+func readStates(s Scanner, skipEvery int) []scannerState {
+	var states []scannerState
+	for i := 1; ; i++ {
+		var (
+			tokenType, token = s.Next()
+			skipped          string
+			skipCount        int
+		)
+		if skipEvery != 0 && i%skipEvery == 0 {
+			skipped, skipCount = s.SkipLine()
+		}
+		var state = scannerState{tokenType, token, s.Line(), s.Column(), s.Position(), s.LineString(), "", skipped, skipCount}
+		if s.LastError() != nil {
+			state.lastError = s.LastError().Error()
+		}
+		states = append(states, state)
+		if tokenType == EOF {
+			return states
+		}
+	}
+}
+
+// Checks that the replaying Scanners behave exactly like the recorded one.
+func TestRecorder(t *testing.T) {
+	var inputs = []string{
+		"",
+		"\n\n",
+		string(generateObj(20)),
+		"# header\r\nv 1 2 3 # comment\r\nv 1-2 3\r\nf 1 2 3",
+		"o name\nv 0.1 -0.2 0..3\n\n  # comment\nf 1/1/1 2//2 3/3\n#end",
+	}
+	for i, input := range inputs {
+		for _, skipComments := range []bool{true, false} {
+			for _, skipEvery := range []int{0, 1, 3, 7} {
+				var name = fmt.Sprintf("input-%d/comments-%t/skip-%d", i, !skipComments, skipEvery)
+				t.Run(name, func(t *testing.T) {
+					var direct = NewScanner(strings.NewReader(input))
+					direct.SkipComments(skipComments)
+					var expected = readStates(direct, skipEvery)
+					var recorder = NewRecorder(NewScanner(strings.NewReader(input)))
+					recorder.SkipComments(skipComments)
+					// Replaying before the recording is complete.
+					var replay = recorder.Replay()
+					replay.SkipComments(skipComments)
+					compareStates(t, "replay", expected, readStates(replay, skipEvery))
+					compareStates(t, "recorder", expected, readStates(recorder, skipEvery))
+					replay = recorder.Replay()
+					replay.SkipComments(skipComments)
+					compareStates(t, "second replay", expected, readStates(replay, skipEvery))
+				})
+			}
+		}
+	}
+}
+
+// Reports the first difference between the expected and actual states.
+func compareStates(t *testing.T, name string, expected, actual []scannerState) {
+	t.Helper()
+	for i := 0; i < len(expected) && i < len(actual); i++ {
+		if expected[i] != actual[i] {
+			t.Fatalf("%s: token %d: expected %+v, got %+v", name, i, expected[i], actual[i])
+		}
+	}
+	if len(expected) != len(actual) {
+		t.Fatalf("%s: expected %d tokens, got %d", name, len(expected), len(actual))
+	}
+}
+
+// Example of reading the same tokens twice without re-reading the reader.
+func ExampleRecorder() {
+	var recorder = NewRecorder(NewScanner(strings.NewReader("v 1 2 3\nf 1 2 3\n")))
+	// The first pass reads only the first word.
+	var tokenType, token = recorder.Next()
+	fmt.Printf("%s : '%s'\n", tokenType, token)
+	// The second pass reads all tokens.
+	var replay = recorder.Replay()
+	for tokenType, token = replay.Next(); tokenType != EOF; tokenType, token = replay.Next() {
+		if tokenType == Word {
+			fmt.Printf("%d : '%s'\n", replay.Line()+1, token)
+		}
+	}
+	fmt.Println(recorder.Len())
+	// Output:
+	//WORD : 'v'
+	//1 : 'v'
+	//2 : 'f'
+	//17
+}
@@ -1,30 +1,55 @@
 package scanner
 
 import (
+	"bytes"
+	"fmt"
 	"io"
+	"unicode/utf8"
 )
 
 // One of the possible values that the Scanner.Next method returns.
 type TokenType uint8
 
 const (
-	Word    TokenType = iota // Can consist of letters, numbers, and underscores. Cannot start with a number.
+	Word    TokenType = iota // Can consist of letters, numbers, underscores, dots and minuses. Must start with a letter or an underscore.
 	Integer                  // Consists of digits. Can start with a minus.
 	Float                    // Consists of digits with a dot between them. Can start with a minus.
 	Slash                    // '/' character.
 	Space                    // A sequence of spaces and/or tabs.
-	EOL                      // '\n' character.
+	EOL                      // '\n' character or the "\r\n" sequence, the token text is always "\n".
 	EOF                      // Indicates that the end of the sequence of bytes being read has been reached.
 	Unknown                  // Unknown type of token.
 	Comment                  // Starts with the '#' character and ends with the character before the end of the line.
 )
 
 // Number of different token options.
+// The token types are numbered from 0 to TokensCount-1 in the order of declaration,
+// so they can be used as indices of arrays of the TokensCount length, for example in transition tables.
 const TokensCount = 9
 
+// Compile-time checks that TokensCount is equal to the number of declared token types and token type names:
+// the size of one of these arrays becomes negative if the numbers differ.
+var (
+	_ [TokensCount - int(Comment) - 1]struct{}
+	_ [int(Comment) + 1 - TokensCount]struct{}
+	_ [TokensCount - len(tokenTypeNamesMap)]struct{}
+	_ [len(tokenTypeNamesMap) - TokensCount]struct{}
+)
+
+// Returns all token types in ascending order of their values.
+// The value of each token type is equal to its index in the returned slice.
+// A new slice is returned on each call, so it can be modified by the caller.
+func AllTokenTypes() []TokenType {
+	var tokenTypes = make([]TokenType, TokensCount)
+	for i := range tokenTypes {
+		tokenTypes[i] = TokenType(i)
+	}
+	return tokenTypes
+}
+
 // Converts the state of the finite state machine from which it moved to the initial state to the type of the read token.
 // See https://github.com/as30606552/ComputerGraphicsProject/wiki/Scanner.
-var tokenTypeMap = [...]TokenType{Unknown, Comment, EOL, Space, Slash, Unknown, Unknown, Integer, Float, Word, Unknown}
+var tokenTypeMap = [...]TokenType{Unknown, Comment, EOL, Space, Slash, Unknown, Unknown, Integer, Float, Word, Unknown, Unknown}
 
 // Converts a token type constant to its string representation.
 var tokenTypeNamesMap = [...]string{"WORD", "INTEGER", "FLOAT", "SLASH", "SPACE", "EOL", "EOF", "UNKNOWN", "COMMENT"}
@@ -40,12 +65,16 @@ type Scanner interface {
 	// If all bytes are read from the reader before calling the method, the (EOF, "") is always returned.
 	Next() (TokenType, string)
 	// Skips all characters until the beginning of the next line.
-	// LineString method can be called after to get the skipped line.
-	SkipLine()
+	// Returns the skipped text of the line without the line ending and the number of skipped bytes including it.
+	// If the end of the line has already been read, nothing is skipped.
+	// LineString method can be called after to get the whole line.
+	SkipLine() (string, int)
 	// Returns the line fragment that was read by the Scanner.
+	// The '\r' character of the "\r\n" line ending is not included.
 	LineString() string
 	// Returns the position of the character that was last processed by the Scanner
 	// relative to the beginning of the sequence of bytes being read.
+	// The skipped byte order mark is counted, so the position is the offset of the character in the file.
 	Position() int
 	// Returns the number of the line that was last processed by the Scanner.
 	Line() int
@@ -53,8 +82,21 @@ type Scanner interface {
 	Column() int
 	// Returns true if the Scanner will skip comments and will not return comment tokens.
 	IsSkipComments() bool
+	// Returns the last problem found by the Scanner or nil if there were no problems.
+	// An error of the reader is also returned by this method, after that the Scanner returns only EOF tokens.
+	LastError() *ScanError
 	// You can use this method to enable or disable skipping comments.
+	// If comments are not skipped, the Next method returns Comment tokens
+	// containing the full text of the comment, starting with the '#' character.
 	SkipComments(skipComments bool)
+	// Sets the maximum length of a line and of a token in bytes, zero or negative values mean no limit.
+	// When a limit is exceeded, the rest of the line is skipped without being stored,
+	// the Unknown token containing the beginning of the token is returned and the LimitError is found,
+	// so that files with huge lines do not make the Scanner allocate unbounded memory.
+	// The limits are DefaultMaxLineLength and DefaultMaxTokenLength by default.
+	SetLimits(maxLineLength, maxTokenLength int)
+	// Returns the maximum length of a line and of a token in bytes, zero if there is no limit.
+	Limits() (maxLineLength, maxTokenLength int)
 }
 
 // One of the possible states of a finite state machine.
@@ -73,6 +115,7 @@ const (
 	foundFloat                  // A sequence of characters satisfying the Float token is found, a digit is expected.
 	foundWord                   // A sequence of characters satisfying the Word token is found.
 	unknown                     // A sequence of characters that does not match the above types.
+	foundCr                     // '\r' character found, '\n' is expected.
 )
 
 // One of the possible character types that can be contained in a sequence of bytes to be read.
@@ -86,8 +129,9 @@ const (
 	minus                    // '-'
 	dot                      // '.'
 	digit                    // '0' - '9'
-	letter                   // 'a' - 'z' or 'A' - 'Z' or '_'
+	letter                   // 'a' - 'z' or 'A' - 'Z' or '_' or any byte of a multi-byte UTF-8 sequence.
 	other                    // Any other character.
+	cr                       // '\r'
 )
 
 // Calculates the character type.
@@ -95,6 +139,8 @@ func getSymbolType(symbol byte) symbolType {
 	switch symbol {
 	case '\n':
 		return eol
+	case '\r':
+		return cr
 	case ' ':
 		return space
 	case '\t':
@@ -116,91 +162,257 @@ func getSymbolType(symbol byte) symbolType {
 	if 'a' <= symbol && symbol <= 'z' || 'A' <= symbol && symbol <= 'Z' {
 		return letter
 	}
+	// All bytes of multi-byte UTF-8 sequences have the high bit set,
+	// so non-ASCII characters in names are read as parts of words.
+	if symbol >= utf8.RuneSelf {
+		return letter
+	}
 	return other
 }
 
 // The finite state machine table.
 // See https://github.com/as30606552/ComputerGraphicsProject/wiki/Scanner.
-var matrix = [9][11]stateType{
-	{foundEol, start, start, start, start, start, start, start, start, start, start},
-	{foundSpace, skipLine, start, foundSpace, start, start, start, start, start, start, start},
-	{skipLine, skipLine, start, start, start, start, start, start, start, start, start},
-	{foundSlash, skipLine, start, start, start, start, start, start, start, start, start},
-	{foundMinus, skipLine, start, start, start, unknown, unknown, unknown, unknown, unknown, unknown},
-	{unknown, skipLine, start, start, start, unknown, unknown, foundDot, unknown, unknown, unknown},
-	{foundInt, skipLine, start, start, start, foundInt, foundFloat, foundInt, foundFloat, foundWord, unknown},
-	{foundWord, skipLine, start, start, start, unknown, unknown, unknown, unknown, foundWord, unknown},
-	{unknown, skipLine, start, start, start, unknown, unknown, unknown, unknown, unknown, unknown},
+var matrix = [10][12]stateType{
+	{foundEol, start, start, start, start, start, start, start, start, start, start, foundEol},
+	{foundSpace, skipLine, start, foundSpace, start, start, start, start, start, start, start, start},
+	{skipLine, skipLine, start, start, start, start, start, start, start, start, start, start},
+	{foundSlash, skipLine, start, start, start, start, start, start, start, start, start, start},
+	{foundMinus, skipLine, start, start, start, unknown, unknown, unknown, unknown, foundWord, unknown, start},
+	{unknown, skipLine, start, start, start, unknown, unknown, foundDot, unknown, foundWord, unknown, start},
+	{foundInt, skipLine, start, start, start, foundInt, foundFloat, foundInt, foundFloat, foundWord, unknown, start},
+	{foundWord, skipLine, start, start, start, unknown, unknown, unknown, unknown, foundWord, unknown, start},
+	{unknown, skipLine, start, start, start, unknown, unknown, unknown, unknown, unknown, unknown, start},
+	{foundCr, start, start, start, start, start, start, start, start, start, start, start},
 }
 
-// The size of the buffer in which the scanner stores the read characters.
-const bufsize uint8 = 255
+const (
+	defaultBufSize = 64 * 1024 // The default size of the buffer in which the scanner stores the read characters.
+	minBufSize     = 16        // The minimum size of the buffer, smaller sizes are increased to it.
+	maxEmptyReads  = 100       // The number of consecutive empty reads after which the reader is considered broken.
+)
+
+const (
+	DefaultMaxLineLength  = 1 << 20 // The default maximum length of a line in bytes, see Scanner.SetLimits.
+	DefaultMaxTokenLength = 1 << 16 // The default maximum length of a token in bytes, see Scanner.SetLimits.
+)
 
 // Implements the Scanner interface.
 // Stores the scanner state and a buffer of read bytes.
 type scanner struct {
-	reader io.Reader // The io.Reader from which the tokens will be read.
+	reader     io.Reader     // The io.Reader from which the tokens will be read.
+	byteReader io.ByteReader // Not nil if the reader implements io.ByteReader, in this case the buffer is not used.
 
-	buffer  [bufsize]byte // Temporary storage for bytes extracted from the reader but not yet processed.
-	bufpos  uint8         // The position of the currently processed byte in the buffer.
-	buflast uint8         // The number of bytes contained in the buffer.
+	buffer  []byte // Temporary storage for bytes extracted from the reader but not yet processed.
+	bufpos  int    // The position of the currently processed byte in the buffer.
+	buflast int    // The number of bytes contained in the buffer.
+	eof     bool   // true if all bytes are read from the reader.
+
+	pending []byte // Bytes read from the byteReader but not yet processed.
 
 	lineStr      []byte // Current processed line string.
 	switchLine   bool   // true if the scanner read the string to the end.
 	lineNum      int    // The number of the currently processed line.
 	posNum       int    // The position of the currently processed character relative to the beginning of the byte sequence.
 	skipComments bool   // true if comments should be skipped.
+
+	maxLineLength  int  // The maximum length of a line, 0 if there is no limit.
+	maxTokenLength int  // The maximum length of a token, 0 if there is no limit.
+	lineTruncated  bool // true if the current line is longer than maxLineLength and its end is not stored.
+	lineOverflow   bool // true if the current line has just exceeded the maxLineLength and the problem is not found yet.
+
+	lastError *ScanError // The last problem found by the Scanner.
 }
 
 // Creates a new Scanner that reads from the reader.
 // Sets skipping comments by default.
+// If the reader implements io.ByteReader (like bufio.Reader), bytes are taken from it directly,
+// otherwise the reader is buffered with the default buffer size.
 func NewScanner(reader io.Reader) Scanner {
-	var scanner = scanner{reader: reader, skipComments: true}
-	// Initialization: allocating memory and filling the buffer.
-	scanner.refreshBuffer()
+	return NewScannerSize(reader, defaultBufSize)
+}
+
+// Creates a new Scanner that reads from the reader using a buffer of at least bufSize bytes.
+// Large buffers reduce the number of Read calls when reading huge files.
+// The bufSize is ignored if the reader implements io.ByteReader, because such a reader is already buffered.
+func NewScannerSize(reader io.Reader, bufSize int) Scanner {
+	var scanner = scanner{
+		reader:         reader,
+		skipComments:   true,
+		maxLineLength:  DefaultMaxLineLength,
+		maxTokenLength: DefaultMaxTokenLength,
+	}
+	if byteReader, ok := reader.(io.ByteReader); ok {
+		scanner.byteReader = byteReader
+	} else {
+		if bufSize < minBufSize {
+			bufSize = minBufSize
+		}
+		scanner.buffer = make([]byte, bufSize)
+	}
+	// Initialization: allocating memory and skipping the byte order mark.
 	scanner.refreshLine()
 	scanner.lineNum = 0
+	scanner.skipBOM()
 	return Scanner(&scanner)
 }
 
-// Reads new values to the buffer.
-// The number of bytes read is stored in the buflast field.
+// The UTF-8 byte order mark that is written at the beginning of files by some Windows tools.
+var bom = []byte{0xEF, 0xBB, 0xBF}
+
+// Skips the byte order mark if the sequence of bytes being read starts with it.
+func (scanner *scanner) skipBOM() {
+	var prefix []byte
+	if scanner.byteReader != nil {
+		for len(scanner.pending) < len(bom) && !scanner.eof {
+			scanner.readByte()
+		}
+		prefix = scanner.pending
+	} else {
+		for scanner.buflast < len(bom) && !scanner.eof {
+			scanner.refreshBuffer()
+		}
+		prefix = scanner.buffer[:scanner.buflast]
+	}
+	if !bytes.HasPrefix(prefix, bom) {
+		return
+	}
+	if scanner.byteReader != nil {
+		scanner.pending = scanner.pending[len(bom):]
+	} else {
+		scanner.bufpos = len(bom)
+	}
+	scanner.posNum = len(bom)
+}
+
+// Reads new values to the buffer after the bytes that have not yet been processed.
+// The number of bytes contained in the buffer is stored in the buflast field.
 // The current bufpos is reset to 0.
+// Repeats reading if the reader returned no bytes without an error, like bufio.Reader does.
 func (scanner *scanner) refreshBuffer() {
-	var n, err = scanner.reader.Read(scanner.buffer[:])
-	if err != nil && err != io.EOF {
-		panic(err)
-	}
-	scanner.buflast = uint8(n)
+	// Moving the unprocessed bytes to the beginning of the buffer.
+	scanner.buflast = copy(scanner.buffer, scanner.buffer[scanner.bufpos:scanner.buflast])
 	scanner.bufpos = 0
+	for i := 0; i < maxEmptyReads; i++ {
+		var n, err = scanner.reader.Read(scanner.buffer[scanner.buflast:])
+		scanner.buflast += n
+		if err != nil {
+			if err != io.EOF {
+				scanner.ioError(err)
+			}
+			scanner.eof = true
+		}
+		if n > 0 || scanner.eof {
+			return
+		}
+	}
+	scanner.ioError(io.ErrNoProgress)
+	scanner.eof = true
+}
+
+// Reads the next byte from the byteReader to the pending bytes.
+func (scanner *scanner) readByte() {
+	var symbol, err = scanner.byteReader.ReadByte()
+	if err != nil {
+		if err != io.EOF {
+			scanner.ioError(err)
+		}
+		scanner.eof = true
+		return
+	}
+	scanner.pending = append(scanner.pending, symbol)
+}
+
+// Saves the error of the reader as the last error.
+func (scanner *scanner) ioError(err error) {
+	scanner.lastError = &ScanError{
+		Line:   scanner.lineNum + 1,
+		Column: len(scanner.lineStr) + 1,
+		Offset: scanner.posNum,
+		Kind:   IOError,
+		Text:   err.Error(),
+		Err:    err,
+	}
+}
+
+// Saves the information about the Unknown token that has just been read as the last error.
+// The error of the reader is kept, because the token was cut off by the failed read.
+func (scanner *scanner) lexicalError(token []byte) {
+	if scanner.lastError != nil && scanner.lastError.Kind == IOError {
+		return
+	}
+	scanner.lastError = &ScanError{
+		Line:   scanner.lineNum + 1,
+		Column: len(scanner.lineStr) - len(token) + 1,
+		Offset: scanner.posNum - len(token),
+		Kind:   LexicalError,
+		Text:   string(token),
+	}
+}
+
+// Saves the information about the exceeded limit as the last error, skips the rest of the line
+// except its ending and returns the Unknown token containing the beginning of the token being read.
+func (scanner *scanner) limitError(token []byte, text string) (TokenType, string) {
+	// The last byte of the token exceeding the maximum length of the line is not stored in the line.
+	var stored = len(token)
+	if scanner.lineTruncated {
+		stored--
+	}
+	scanner.lastError = &ScanError{
+		Line:   scanner.lineNum + 1,
+		Column: len(scanner.lineStr) - stored + 1,
+		Offset: scanner.posNum - len(token),
+		Kind:   LimitError,
+		Text:   text,
+	}
+	for scanner.has() && scanner.peek() != '\n' {
+		scanner.step()
+	}
+	scanner.lineOverflow = false
+	if scanner.maxTokenLength > 0 && len(token) > scanner.maxTokenLength {
+		token = token[:scanner.maxTokenLength]
+	}
+	return Unknown, string(token)
 }
 
 // Moving the scanner to the next line.
+// The memory of the line string is reused, because the line is only available as a copy through LineString.
 func (scanner *scanner) refreshLine() {
-	scanner.lineStr = make([]byte, 0, 100)
+	if scanner.lineStr == nil {
+		scanner.lineStr = make([]byte, 0, 100)
+	} else {
+		scanner.lineStr = scanner.lineStr[:0]
+	}
+	scanner.lineTruncated = false
+	scanner.lineOverflow = false
 	scanner.lineNum++
 }
 
 // Returns true if there is a next token.
 func (scanner *scanner) has() bool {
+	if scanner.byteReader != nil {
+		if len(scanner.pending) == 0 && !scanner.eof {
+			scanner.readByte()
+		}
+		return len(scanner.pending) != 0
+	}
+	if scanner.bufpos < scanner.buflast {
+		return true
+	}
 	// The buffer is processed to the end.
 	// It is necessary to read the new data to the buffer.
-	if scanner.bufpos == scanner.buflast {
-		// If the number of types in the buffer is less than the buffer size,
-		// it means that the buffer was not fully filled the previous time when reading it.
-		if scanner.buflast < bufsize {
-			return false
-		} else {
-			scanner.refreshBuffer()
-		}
+	if !scanner.eof {
+		scanner.refreshBuffer()
 	}
-	return scanner.bufpos != scanner.buflast
+	return scanner.bufpos < scanner.buflast
 }
 
 // Returns the next character from the reader.
 // Panics if it can't get the next character, because this method is only used if the next character is present.
 func (scanner *scanner) peek() byte {
 	if scanner.has() {
+		if scanner.byteReader != nil {
+			return scanner.pending[0]
+		}
 		return scanner.buffer[scanner.bufpos]
 	}
 	// Impossible situation.
@@ -216,12 +428,25 @@ func (scanner *scanner) step() {
 		scanner.switchLine = false
 	}
 	var symbol = scanner.peek()
-	if symbol == '\n' {
+	switch {
+	case symbol == '\n':
 		scanner.switchLine = true
-	} else {
+	case scanner.maxLineLength <= 0 || len(scanner.lineStr) < scanner.maxLineLength:
 		scanner.lineStr = append(scanner.lineStr, symbol)
+	case !scanner.lineTruncated:
+		scanner.lineTruncated = true
+		scanner.lineOverflow = true
+	}
+	if scanner.byteReader != nil {
+		if len(scanner.pending) == 1 {
+			// Reusing the memory of the pending bytes.
+			scanner.pending = scanner.pending[:0]
+		} else {
+			scanner.pending = scanner.pending[1:]
+		}
+	} else {
+		scanner.bufpos++
 	}
-	scanner.bufpos++
 	scanner.posNum++
 }
 
@@ -239,15 +464,6 @@ func (scanner *scanner) Next() (TokenType, string) {
 	)
 	for scanner.has() {
 		symbol = scanner.peek()
-		// Skipping the '\r' character to handle line ends on Windows
-		if symbol == '\r' {
-			if scanner.has() {
-				scanner.step()
-				symbol = scanner.peek()
-			} else {
-				symbol = '\n'
-			}
-		}
 		tokenType = tokenTypeMap[state]
 		state = matrix[getSymbolType(symbol)][state] // The next state is contained in the matrix.
 		// The transition to the start state means the end of the token.
@@ -256,33 +472,61 @@ func (scanner *scanner) Next() (TokenType, string) {
 			if scanner.skipComments && tokenType == Comment {
 				return scanner.Next()
 			}
+			if tokenType == Unknown {
+				scanner.lexicalError(buffer)
+			}
 			return tokenType, string(buffer)
 		}
+		// The '\r' character before '\n' is not a part of the EOL token.
+		if state == foundEol {
+			buffer = buffer[:0]
+		}
 		buffer = append(buffer, symbol)
 		scanner.step()
+		switch {
+		case scanner.lineOverflow:
+			return scanner.limitError(buffer, fmt.Sprintf("the line is longer than %d bytes", scanner.maxLineLength))
+		case scanner.maxTokenLength > 0 && len(buffer) > scanner.maxTokenLength:
+			return scanner.limitError(buffer, fmt.Sprintf("the token is longer than %d bytes", scanner.maxTokenLength))
+		}
 	}
 	// All bytes are read from the reader.
-	return tokenTypeMap[state], string(buffer)
+	tokenType = tokenTypeMap[state]
+	// The comment at the end of the file must be omitted in the same way as other comments.
+	if scanner.skipComments && tokenType == Comment {
+		return EOF, ""
+	}
+	if tokenType == Unknown {
+		scanner.lexicalError(buffer)
+	}
+	return tokenType, string(buffer)
 }
 
 // Implementation of the SkipLine method in the Scanner interface.
-func (scanner *scanner) SkipLine() {
+func (scanner *scanner) SkipLine() (string, int) {
 	if scanner.switchLine {
-		return
+		return "", 0
 	}
-	var symbol byte
+	var (
+		skipped  = len(scanner.lineStr) // The length of the line fragment that was read before skipping.
+		position = scanner.posNum
+		symbol   byte
+	)
 	for scanner.has() {
 		symbol = scanner.peek()
 		scanner.step()
 		if symbol == '\n' {
-			return
+			break
 		}
 	}
+	// The skipped line is not reported even if it exceeds the limit.
+	scanner.lineOverflow = false
+	return string(bytes.TrimSuffix(scanner.lineStr[skipped:], []byte{'\r'})), scanner.posNum - position
 }
 
 // Implementation of the LineString method in the Scanner interface.
 func (scanner *scanner) LineString() string {
-	return string(scanner.lineStr)
+	return string(bytes.TrimSuffix(scanner.lineStr, []byte{'\r'}))
 }
 
 // Implementation of the Position method in the Scanner interface.
@@ -303,6 +547,11 @@ func (scanner *scanner) Column() int {
 	return len(scanner.lineStr) - 1
 }
 
+// Implementation of the LastError method in the Scanner interface.
+func (scanner *scanner) LastError() *ScanError {
+	return scanner.lastError
+}
+
 // Implementation of the IsSkipComments method in the Scanner interface.
 func (scanner *scanner) IsSkipComments() bool {
 	return scanner.skipComments
@@ -312,3 +561,20 @@ func (scanner *scanner) IsSkipComments() bool {
 func (scanner *scanner) SkipComments(skipComments bool) {
 	scanner.skipComments = skipComments
 }
+
+// Implementation of the SetLimits method in the Scanner interface.
+func (scanner *scanner) SetLimits(maxLineLength, maxTokenLength int) {
+	if maxLineLength < 0 {
+		maxLineLength = 0
+	}
+	if maxTokenLength < 0 {
+		maxTokenLength = 0
+	}
+	scanner.maxLineLength = maxLineLength
+	scanner.maxTokenLength = maxTokenLength
+}
+
+// Implementation of the Limits method in the Scanner interface.
+func (scanner *scanner) Limits() (int, int) {
+	return scanner.maxLineLength, scanner.maxTokenLength
+}
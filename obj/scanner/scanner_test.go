@@ -1,8 +1,15 @@
 package scanner
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
 	"strings"
+	"testing"
+	"testing/iotest"
 )
 
 // Reading the correct data.
@@ -40,3 +47,257 @@ func ExampleScanner_Next_incorrect() {
 	//SPACE : ' '
 	//UNKNOWN : '0.0.1'
 }
+
+// Generates the contents of a large .obj file with the specified number of vertices and faces.
+func generateObj(count int) []byte {
+	var builder strings.Builder
+	for i := 0; i < count; i++ {
+		fmt.Fprintf(&builder, "v %f %f %f\n", float64(i)*0.001, float64(i)*-0.002, float64(i)*0.003)
+	}
+	for i := 1; i+2 <= count; i++ {
+		fmt.Fprintf(&builder, "f %d/%d/%d %d/%d/%d %d/%d/%d\n", i, i, i, i+1, i+1, i+1, i+2, i+2, i+2)
+	}
+	return []byte(builder.String())
+}
+
+// Reads all tokens from the Scanner.
+func readAll(s Scanner) {
+	for tokenType, _ := s.Next(); tokenType != EOF; tokenType, _ = s.Next() {
+	}
+}
+
+// Comparing the throughput of the Scanner with different buffer sizes on a large .obj file.
+func BenchmarkScanner(b *testing.B) {
+	var (
+		data = generateObj(100000)
+		name = filepath.Join(b.TempDir(), "large.obj")
+	)
+	if err := os.WriteFile(name, data, 0666); err != nil {
+		b.Fatal(err)
+	}
+	// Opens the file, reads all tokens from it using the Scanner created by the newScanner and closes the file.
+	var scanFile = func(b *testing.B, newScanner func(reader io.Reader) Scanner) {
+		b.SetBytes(int64(len(data)))
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var file, err = os.Open(name)
+			if err != nil {
+				b.Fatal(err)
+			}
+			readAll(newScanner(file))
+			if err = file.Close(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+	for _, size := range []int{255, 4096, defaultBufSize} {
+		var size = size
+		b.Run(fmt.Sprintf("buffer-%d", size), func(b *testing.B) {
+			scanFile(b, func(reader io.Reader) Scanner { return NewScannerSize(reader, size) })
+		})
+	}
+	b.Run("byte-reader", func(b *testing.B) {
+		scanFile(b, func(reader io.Reader) Scanner { return NewScanner(bufio.NewReader(reader)) })
+	})
+}
+
+// Reading data that starts with the UTF-8 byte order mark and contains non-ASCII characters.
+func ExampleScanner_Next_utf8() {
+	var s = NewScanner(strings.NewReader("\xEF\xBB\xBFo Лиса_1 ü"))
+	var tokenType, token = s.Next()
+	for tokenType != EOF {
+		fmt.Printf("%s : '%s'\n", tokenType, token)
+		tokenType, token = s.Next()
+	}
+	// Output:
+	//WORD : 'o'
+	//SPACE : ' '
+	//WORD : 'Лиса_1'
+	//SPACE : ' '
+	//WORD : 'ü'
+}
+
+// Reading comments with their full text.
+func ExampleScanner_SkipComments() {
+	var s = NewScanner(strings.NewReader("# Blender v2.74\nv 1 # vertex\n#end"))
+	s.SkipComments(false)
+	var tokenType, token = s.Next()
+	for tokenType != EOF {
+		if tokenType == Comment {
+			fmt.Printf("%d : '%s'\n", s.Line()+1, token)
+		}
+		tokenType, token = s.Next()
+	}
+	// Output:
+	//1 : '# Blender v2.74'
+	//2 : '# vertex'
+	//3 : '#end'
+}
+
+// Skipping the rest of the lines after the element names.
+func ExampleScanner_SkipLine() {
+	var s = NewScanner(strings.NewReader("vn 0 0 1\r\nlod 2 # level\n\nv 1 2 3"))
+	for tokenType, token := s.Next(); tokenType != EOF; tokenType, token = s.Next() {
+		if tokenType == Word {
+			var skipped, count = s.SkipLine()
+			fmt.Printf("%s : '%s' %d : '%s'\n", token, skipped, count, s.LineString())
+		}
+	}
+	// Output:
+	//vn : ' 0 0 1' 8 : 'vn 0 0 1'
+	//lod : ' 2 # level' 11 : 'lod 2 # level'
+	//v : ' 1 2 3' 6 : 'v 1 2 3'
+}
+
+// Getting information about the problems found by the Scanner.
+func ExampleScanner_LastError() {
+	var s = NewScanner(io.MultiReader(
+		strings.NewReader("v 1 2\nf 1-2 3"),
+		iotest.ErrReader(errors.New("connection lost")),
+	))
+	for tokenType, token := s.Next(); tokenType != EOF; tokenType, token = s.Next() {
+		if tokenType == Unknown {
+			fmt.Printf("%s : '%s'\n", s.LastError().Kind, token)
+			fmt.Println(s.LastError())
+		}
+	}
+	fmt.Printf("%s : %v\n", s.LastError().Kind, s.LastError().Err)
+	fmt.Println(s.LastError())
+	// Output:
+	//lexical : '1-2'
+	//line: 2, column: 3, offset: 8, lexical error: unknown token '1-2'
+	//I/O : connection lost
+	//line: 2, column: 8, offset: 13, I/O error: connection lost
+}
+
+// Skipping the lines and the tokens exceeding the limits.
+func ExampleScanner_SetLimits() {
+	var (
+		s      = NewScanner(strings.NewReader("v 1 2\nvt 1234567 0\nv 1 2 3 4 5 6\nf 1 2 3\n"))
+		tokens []string
+	)
+	s.SetLimits(10, 5)
+	for tokenType, token := s.Next(); tokenType != EOF; tokenType, token = s.Next() {
+		if tokenType == EOL {
+			fmt.Println(strings.Join(tokens, " "))
+			tokens = tokens[:0]
+			continue
+		}
+		tokens = append(tokens, fmt.Sprintf("[%s '%s']", tokenType, token))
+		if tokenType == Unknown {
+			tokens = append(tokens, s.LastError().Error())
+		}
+	}
+	// Output:
+	//[WORD 'v'] [SPACE ' '] [INTEGER '1'] [SPACE ' '] [INTEGER '2']
+	//[WORD 'vt'] [SPACE ' '] [UNKNOWN '12345'] line: 2, column: 4, offset: 9, limit error: the token is longer than 5 bytes
+	//[WORD 'v'] [SPACE ' '] [INTEGER '1'] [SPACE ' '] [INTEGER '2'] [SPACE ' '] [INTEGER '3'] [SPACE ' '] [INTEGER '4'] [SPACE ' '] [UNKNOWN '5'] line: 3, column: 11, offset: 29, limit error: the line is longer than 10 bytes
+	//[WORD 'f'] [SPACE ' '] [INTEGER '1'] [SPACE ' '] [INTEGER '2'] [SPACE ' '] [INTEGER '3']
+}
+
+// Example of reading a file with Windows line endings.
+func ExampleScanner_Next_crlf() {
+	var s = NewScanner(strings.NewReader("v 1 -2\r\n# comment\r\nf\r\n\r"))
+	s.SkipComments(false)
+	var tokenType, token = s.Next()
+	for tokenType != EOF {
+		fmt.Printf("%d %s : %q\n", s.Line(), tokenType, token)
+		if tokenType == EOL {
+			fmt.Printf("%q\n", s.LineString())
+		}
+		tokenType, token = s.Next()
+	}
+	// Output:
+	//0 WORD : "v"
+	//0 SPACE : " "
+	//0 INTEGER : "1"
+	//0 SPACE : " "
+	//0 INTEGER : "-2"
+	//0 EOL : "\n"
+	//"v 1 -2"
+	//1 COMMENT : "# comment"
+	//1 EOL : "\n"
+	//"# comment"
+	//2 WORD : "f"
+	//2 EOL : "\n"
+	//"f"
+	//3 UNKNOWN : "\r"
+}
+
+// Checking that the failed read cutting off the last token is reported as the I/O error, not as the lexical one.
+func TestScanner_LastError_read(t *testing.T) {
+	var s = NewScanner(io.MultiReader(
+		strings.NewReader("v 1-"),
+		iotest.ErrReader(errors.New("connection lost")),
+	))
+	for tokenType, _ := s.Next(); tokenType != EOF; tokenType, _ = s.Next() {
+	}
+	if err := s.LastError(); err == nil || err.Kind != IOError || err.Text != "connection lost" {
+		t.Errorf("Incorrect last error, got: %v, want: the I/O error 'connection lost'", err)
+	}
+}
+
+// Checking that the skipped byte order mark is counted in the positions and the offsets of the errors,
+// but not in the columns, which are relative to the text of the line, with the buffered and the byte readers.
+func TestScanner_bom(t *testing.T) {
+	const text = "v 1 @\nv 2 @\n"
+	for name, newReader := range map[string]func(string) io.Reader{
+		"buffer": func(text string) io.Reader { return strings.NewReader(text) },
+		"bytes":  func(text string) io.Reader { return bufio.NewReader(strings.NewReader(text)) },
+	} {
+		for bom, prefix := range []string{"", "\xEF\xBB\xBF"} {
+			var (
+				s     = NewScannerSize(newReader(prefix+text), minBufSize)
+				shift = len(prefix)
+				want  = []ScanError{
+					{Line: 1, Column: 5, Offset: 4 + shift, Kind: LexicalError, Text: "@"},
+					{Line: 2, Column: 5, Offset: 10 + shift, Kind: LexicalError, Text: "@"},
+				}
+			)
+			if s.Position() != shift-1 {
+				t.Errorf("%s, bom %d: incorrect position before the first token, got: %d, want: %d", name, bom, s.Position(), shift-1)
+			}
+			for tokenType, _ := s.Next(); tokenType != EOF; tokenType, _ = s.Next() {
+				if tokenType != Unknown {
+					continue
+				}
+				if err := s.LastError(); len(want) == 0 || *err != want[0] {
+					t.Errorf("%s, bom %d: incorrect error, got: %v, want: %v", name, bom, err, want)
+				} else {
+					want = want[1:]
+				}
+				if line := s.LineString(); line != "v 1 @" && line != "v 2 @" {
+					t.Errorf("%s, bom %d: incorrect line, got: %q", name, bom, line)
+				}
+			}
+			if s.Position() != len(prefix+text)-1 {
+				t.Errorf("%s, bom %d: incorrect position after the last token, got: %d, want: %d", name, bom, s.Position(), len(prefix+text)-1)
+			}
+		}
+	}
+}
+
+// Checking that the token types can be used as indices of arrays of the TokensCount length.
+func TestAllTokenTypes(t *testing.T) {
+	var (
+		tokenTypes = AllTokenTypes()
+		names      = map[string]bool{}
+	)
+	if len(tokenTypes) != TokensCount {
+		t.Fatalf("Incorrect number of token types, got: %d, want: %d", len(tokenTypes), TokensCount)
+	}
+	for i, tokenType := range tokenTypes {
+		if int(tokenType) != i {
+			t.Errorf("Incorrect value of the token type %s, got: %d, want: %d", tokenType, tokenType, i)
+		}
+		if names[tokenType.String()] {
+			t.Errorf("Duplicate name of the token type %d: %s", tokenType, tokenType)
+		}
+		names[tokenType.String()] = true
+	}
+	for _, tokenType := range tokenTypeMap {
+		if int(tokenType) >= TokensCount {
+			t.Errorf("The finite state machine returns an undeclared token type: %d", tokenType)
+		}
+	}
+}
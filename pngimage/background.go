@@ -0,0 +1,87 @@
+package pngimage
+
+import "math"
+
+// Creates an Image with the specified width and height filled with the specified color.
+func FilledImage(width, height uint, rgb RGB) *Image {
+	var img = NewImage(width, height)
+	img.Fill(rgb)
+	return img
+}
+
+// Creates an Image with the vertical gradient from the top color in the first row to the bottom color in the last row.
+// Can be used as a backdrop for the rendered models.
+func VerticalGradient(width, height uint, top, bottom RGB) *Image {
+	var img = NewImage(width, height)
+	for y := 0; y < int(height); y++ {
+		var rgb = lerpColor(top, bottom, gradientPosition(y, int(height)))
+		for x := 0; x < int(width); x++ {
+			img.SetRGB(x, y, rgb)
+		}
+	}
+	return img
+}
+
+// Creates an Image with the radial gradient from the center color in the center of the image
+// to the edge color in its corners.
+// Can be used as a backdrop for the rendered models.
+func RadialGradient(width, height uint, center, edge RGB) *Image {
+	var img = NewImage(width, height)
+	for y := 0; y < int(height); y++ {
+		for x := 0; x < int(width); x++ {
+			img.SetRGB(x, y, lerpColor(center, edge, centerDistance(x, y, int(width), int(height))))
+		}
+	}
+	return img
+}
+
+// Fills all pixels of the image with the specified color.
+func (img *Image) Fill(rgb RGB) {
+	for x := 0; x < img.Width(); x++ {
+		for y := 0; y < img.Height(); y++ {
+			img.SetRGB(x, y, rgb)
+		}
+	}
+}
+
+// Darkens the pixels of the image towards its corners.
+// The amount from 0 to 1 specifies the darkening of the corners: 0 does not change the image, 1 makes the corners black.
+// The darkening grows with the square of the distance from the center of the image.
+func (img *Image) Vignette(amount float64) {
+	var width, height = img.Width(), img.Height()
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			var d = centerDistance(x, y, width, height)
+			img.SetRGB(x, y, img.Get(x, y).Scale(1-amount*d*d))
+		}
+	}
+}
+
+// Returns the position of the pixel with the specified coordinate along the side of the specified size from 0 to 1.
+func gradientPosition(coordinate, size int) float64 {
+	if size < 2 {
+		return 0
+	}
+	return float64(coordinate) / float64(size-1)
+}
+
+// Returns the distance from the center of the pixel (x, y) to the center of the image
+// relative to the distance from the center of the image to the center of its corner pixel, from 0 to 1.
+func centerDistance(x, y, width, height int) float64 {
+	var (
+		cx, cy = float64(width-1) / 2, float64(height-1) / 2
+		max    = math.Hypot(cx, cy)
+	)
+	if max == 0 {
+		return 0
+	}
+	return math.Hypot(float64(x)-cx, float64(y)-cy) / max
+}
+
+// Returns the color between the colors a and b, t from 0 to 1 specifies the position between them.
+func lerpColor(a, b RGB, t float64) RGB {
+	var lerp = func(a, b uint8) uint8 {
+		return uint8(math.Round(float64(a) + (float64(b)-float64(a))*t))
+	}
+	return RGB{R: lerp(a.R, b.R), G: lerp(a.G, b.G), B: lerp(a.B, b.B)}
+}
@@ -0,0 +1,112 @@
+package pngimage
+
+import (
+	"image"
+	"image/draw"
+	"strings"
+)
+
+const (
+	GlyphWidth  = 5 // The width of the glyphs of the built-in font in pixels without scaling.
+	GlyphHeight = 7 // The height of the glyphs of the built-in font in pixels without scaling.
+)
+
+// The glyphs of the built-in bitmap font: each row of a glyph is a byte,
+// the lowest GlyphWidth bits are the pixels from left to right starting from the highest of them.
+// Only the upper case letters, digits and the common punctuation are drawn,
+// the lower case letters are drawn as the upper case ones, other characters as the question mark.
+var glyphs = map[rune][GlyphHeight]uint8{
+	'A':  {0x0E, 0x11, 0x11, 0x1F, 0x11, 0x11, 0x11},
+	'B':  {0x1E, 0x11, 0x11, 0x1E, 0x11, 0x11, 0x1E},
+	'C':  {0x0E, 0x11, 0x10, 0x10, 0x10, 0x11, 0x0E},
+	'D':  {0x1E, 0x11, 0x11, 0x11, 0x11, 0x11, 0x1E},
+	'E':  {0x1F, 0x10, 0x10, 0x1E, 0x10, 0x10, 0x1F},
+	'F':  {0x1F, 0x10, 0x10, 0x1E, 0x10, 0x10, 0x10},
+	'G':  {0x0E, 0x11, 0x10, 0x17, 0x11, 0x11, 0x0F},
+	'H':  {0x11, 0x11, 0x11, 0x1F, 0x11, 0x11, 0x11},
+	'I':  {0x0E, 0x04, 0x04, 0x04, 0x04, 0x04, 0x0E},
+	'J':  {0x07, 0x02, 0x02, 0x02, 0x02, 0x12, 0x0C},
+	'K':  {0x11, 0x12, 0x14, 0x18, 0x14, 0x12, 0x11},
+	'L':  {0x10, 0x10, 0x10, 0x10, 0x10, 0x10, 0x1F},
+	'M':  {0x11, 0x1B, 0x15, 0x15, 0x11, 0x11, 0x11},
+	'N':  {0x11, 0x11, 0x19, 0x15, 0x13, 0x11, 0x11},
+	'O':  {0x0E, 0x11, 0x11, 0x11, 0x11, 0x11, 0x0E},
+	'P':  {0x1E, 0x11, 0x11, 0x1E, 0x10, 0x10, 0x10},
+	'Q':  {0x0E, 0x11, 0x11, 0x11, 0x15, 0x12, 0x0D},
+	'R':  {0x1E, 0x11, 0x11, 0x1E, 0x14, 0x12, 0x11},
+	'S':  {0x0F, 0x10, 0x10, 0x0E, 0x01, 0x01, 0x1E},
+	'T':  {0x1F, 0x04, 0x04, 0x04, 0x04, 0x04, 0x04},
+	'U':  {0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x0E},
+	'V':  {0x11, 0x11, 0x11, 0x11, 0x11, 0x0A, 0x04},
+	'W':  {0x11, 0x11, 0x11, 0x15, 0x15, 0x15, 0x0A},
+	'X':  {0x11, 0x11, 0x0A, 0x04, 0x0A, 0x11, 0x11},
+	'Y':  {0x11, 0x11, 0x11, 0x0A, 0x04, 0x04, 0x04},
+	'Z':  {0x1F, 0x01, 0x02, 0x04, 0x08, 0x10, 0x1F},
+	'0':  {0x0E, 0x11, 0x13, 0x15, 0x19, 0x11, 0x0E},
+	'1':  {0x04, 0x0C, 0x04, 0x04, 0x04, 0x04, 0x0E},
+	'2':  {0x0E, 0x11, 0x01, 0x02, 0x04, 0x08, 0x1F},
+	'3':  {0x1F, 0x02, 0x04, 0x02, 0x01, 0x11, 0x0E},
+	'4':  {0x02, 0x06, 0x0A, 0x12, 0x1F, 0x02, 0x02},
+	'5':  {0x1F, 0x10, 0x1E, 0x01, 0x01, 0x11, 0x0E},
+	'6':  {0x06, 0x08, 0x10, 0x1E, 0x11, 0x11, 0x0E},
+	'7':  {0x1F, 0x01, 0x02, 0x04, 0x08, 0x08, 0x08},
+	'8':  {0x0E, 0x11, 0x11, 0x0E, 0x11, 0x11, 0x0E},
+	'9':  {0x0E, 0x11, 0x11, 0x0F, 0x01, 0x02, 0x0C},
+	' ':  {},
+	'-':  {0x00, 0x00, 0x00, 0x1F, 0x00, 0x00, 0x00},
+	'+':  {0x00, 0x04, 0x04, 0x1F, 0x04, 0x04, 0x00},
+	'=':  {0x00, 0x00, 0x1F, 0x00, 0x1F, 0x00, 0x00},
+	'_':  {0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x1F},
+	'.':  {0x00, 0x00, 0x00, 0x00, 0x00, 0x0C, 0x0C},
+	',':  {0x00, 0x00, 0x00, 0x00, 0x0C, 0x04, 0x08},
+	':':  {0x00, 0x0C, 0x0C, 0x00, 0x0C, 0x0C, 0x00},
+	'/':  {0x00, 0x01, 0x02, 0x04, 0x08, 0x10, 0x00},
+	'(':  {0x02, 0x04, 0x08, 0x08, 0x08, 0x04, 0x02},
+	')':  {0x08, 0x04, 0x02, 0x02, 0x02, 0x04, 0x08},
+	'%':  {0x18, 0x19, 0x02, 0x04, 0x08, 0x13, 0x03},
+	'\'': {0x04, 0x04, 0x08, 0x00, 0x00, 0x00, 0x00},
+	'!':  {0x04, 0x04, 0x04, 0x04, 0x04, 0x00, 0x04},
+	'?':  {0x0E, 0x11, 0x01, 0x02, 0x04, 0x00, 0x04},
+}
+
+// Returns the width in pixels of the text drawn by DrawText with the specified scale.
+func TextWidth(text string, scale int) int {
+	var count = len([]rune(text))
+	if count == 0 {
+		return 0
+	}
+	return (count*(GlyphWidth+1) - 1) * scale
+}
+
+// Draws the single-line text with the built-in bitmap font, the upper left corner of the text is at (x, y).
+// Each pixel of the font is drawn as a square with the side equal to the scale, the glyphs are separated by one column.
+// The pixels outside the image are not drawn.
+func (img *Image) DrawText(x, y int, text string, scale int, rgb RGB) {
+	for _, char := range strings.ToUpper(text) {
+		var glyph, ok = glyphs[char]
+		if !ok {
+			glyph = glyphs['?']
+		}
+		for row, bits := range glyph {
+			for column := 0; column < GlyphWidth; column++ {
+				if bits&(1<<(GlyphWidth-1-column)) == 0 {
+					continue
+				}
+				var r = image.Rect(x+column*scale, y+row*scale, x+(column+1)*scale, y+(row+1)*scale)
+				draw.Draw(img.img, r, image.NewUniform(rgb.ToRGBA()), image.Point{}, draw.Src)
+			}
+		}
+		x += (GlyphWidth + 1) * scale
+	}
+}
+
+// Copies the source image to the image, so that the upper left corner of the source is at (x, y).
+// The pixels outside the image are not copied.
+func (img *Image) Blit(src image.Image, x, y int) {
+	// The pixels of the Image are copied directly instead of through the color.Color interface.
+	if i, ok := src.(*Image); ok {
+		src = i.img
+	}
+	var bounds = src.Bounds()
+	draw.Draw(img.img, bounds.Sub(bounds.Min).Add(image.Pt(x, y)), src, bounds.Min, draw.Src)
+}
@@ -0,0 +1,129 @@
+package pngimage
+
+import (
+	"math"
+	"sync/atomic"
+)
+
+// The pixels of the image with the depth of the closest drawn surface, which can be written by several goroutines.
+// Each pixel is packed into a single uint64 and updated atomically by the CompareAndSet method,
+// so that parallel renderers that draw the triangles on different goroutines without splitting the image into tiles
+// still keep the closest surface in each pixel.
+// The depth is stored with the float32 precision.
+type FrameBuffer struct {
+	width, height int
+	pixels        []uint64 // The depth key in the high 32 bits and the color in the low 24 bits of each pixel.
+}
+
+// Creates a new FrameBuffer with the specified width and height filled with the background color
+// and the positive infinity depth, so that any surface is closer.
+func NewFrameBuffer(width, height uint, background RGB) *FrameBuffer {
+	var buffer = &FrameBuffer{width: int(width), height: int(height), pixels: make([]uint64, width*height)}
+	buffer.Clear(background)
+	return buffer
+}
+
+// Fills the FrameBuffer with the background color and the positive infinity depth.
+// Must not be called concurrently with other methods.
+func (buffer *FrameBuffer) Clear(background RGB) {
+	var pixel = packPixel(math.Inf(+1), background)
+	for i := range buffer.pixels {
+		buffer.pixels[i] = pixel
+	}
+}
+
+// Returns the width of the FrameBuffer in pixels.
+func (buffer *FrameBuffer) Width() int {
+	return buffer.width
+}
+
+// Returns the height of the FrameBuffer in pixels.
+func (buffer *FrameBuffer) Height() int {
+	return buffer.height
+}
+
+// Writes the color and the depth to the pixel at (x, y) if the depth is less than the stored one
+// and returns true if the pixel was written. Can be called by several goroutines at once.
+// The depths equal with the float32 precision are ordered by the colors, the smaller color wins,
+// so that the result does not depend on the order in which the goroutines write the pixels.
+// The NaN depth is never written.
+func (buffer *FrameBuffer) CompareAndSet(x, y int, depth float64, rgb RGB) bool {
+	if math.IsNaN(depth) {
+		return false
+	}
+	var (
+		address = &buffer.pixels[y*buffer.width+x]
+		pixel   = packPixel(depth, rgb)
+	)
+	for {
+		var stored = atomic.LoadUint64(address)
+		if pixel >= stored {
+			return false
+		}
+		if atomic.CompareAndSwapUint64(address, stored, pixel) {
+			return true
+		}
+	}
+}
+
+// Sets the color of the pixel at (x, y) keeping its depth, so that the FrameBuffer can be used as a render target.
+// Can be called by several goroutines at once.
+func (buffer *FrameBuffer) SetRGB(x, y int, rgb RGB) {
+	var address = &buffer.pixels[y*buffer.width+x]
+	for {
+		var stored = atomic.LoadUint64(address)
+		if atomic.CompareAndSwapUint64(address, stored, stored&^0xffffff|packColor(rgb)) {
+			return
+		}
+	}
+}
+
+// Returns the color of the pixel at (x, y).
+func (buffer *FrameBuffer) Get(x, y int) RGB {
+	var pixel = atomic.LoadUint64(&buffer.pixels[y*buffer.width+x])
+	return RGB{uint8(pixel >> 16), uint8(pixel >> 8), uint8(pixel)}
+}
+
+// Returns the depth of the pixel at (x, y) with the float32 precision.
+func (buffer *FrameBuffer) Depth(x, y int) float64 {
+	return unpackDepth(uint32(atomic.LoadUint64(&buffer.pixels[y*buffer.width+x]) >> 32))
+}
+
+// Creates an Image with the colors of the pixels of the FrameBuffer.
+func (buffer *FrameBuffer) Image() *Image {
+	var img = NewImage(uint(buffer.width), uint(buffer.height))
+	for y := 0; y < buffer.height; y++ {
+		for x := 0; x < buffer.width; x++ {
+			img.SetRGB(x, y, buffer.Get(x, y))
+		}
+	}
+	return img
+}
+
+// Packs the depth and the color into the value of the pixel, the pixels with smaller depths have smaller values.
+func packPixel(depth float64, rgb RGB) uint64 {
+	return uint64(packDepth(depth))<<32 | packColor(rgb)
+}
+
+// Packs the color into the low 24 bits.
+func packColor(rgb RGB) uint64 {
+	return uint64(rgb.R)<<16 | uint64(rgb.G)<<8 | uint64(rgb.B)
+}
+
+// Converts the depth to the float32 bits ordered like the depths:
+// the sign bit of the positive values is set and all the bits of the negative values are inverted.
+func packDepth(depth float64) uint32 {
+	var bits = math.Float32bits(float32(depth))
+	if bits&(1<<31) != 0 {
+		return ^bits
+	}
+	return bits | 1<<31
+}
+
+// Converts the bits created by the packDepth back to the depth.
+func unpackDepth(key uint32) float64 {
+	if key&(1<<31) != 0 {
+		return float64(math.Float32frombits(key &^ (1 << 31)))
+	}
+	return float64(math.Float32frombits(^key))
+}
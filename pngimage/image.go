@@ -12,7 +12,8 @@ import (
 
 // Wrapper around the image.Image for working with images in RGB format without specifying alpha value.
 // All pixels have a maximum alfa value, meaning they are completely opaque.
-// Implements the interface image.Image, so that all the functions that work with images can be used.
+// Implements the interfaces image.Image and draw.Image, so that all the functions that work with images can be used,
+// for example, the image/draw package can composite other images over the rendered one.
 type Image struct {
 	img *image.RGBA
 }
@@ -24,35 +25,17 @@ func NewImage(width, height uint) *Image {
 
 // Creates an all-white Image with the specified width and height.
 func WhiteImage(width, height uint) *Image {
-	var (
-		img = NewImage(width, height)
-		rgb = WhiteColor()
-	)
-	for i := 0; i < int(width); i++ {
-		for j := 0; j < int(height); j++ {
-			img.Set(i, j, rgb)
-		}
-	}
-	return img
+	return FilledImage(width, height, WhiteColor())
 }
 
 // Creates an all-black Image with the specified width and height.
 func BlackImage(width, height uint) *Image {
-	var (
-		img = NewImage(width, height)
-		rgb = BlackColor()
-	)
-	for i := 0; i < int(width); i++ {
-		for j := 0; j < int(height); j++ {
-			img.Set(i, j, rgb)
-		}
-	}
-	return img
+	return FilledImage(width, height, BlackColor())
 }
 
 // Implementation of the ColorModel method in the image.Image interface.
 func (img *Image) ColorModel() color.Model {
-	return img.img.ColorModel()
+	return RGBModel
 }
 
 // Implementation of the Bounds method in the image.Image interface.
@@ -61,19 +44,31 @@ func (img *Image) Bounds() image.Rectangle {
 }
 
 // Implementation of the At method in the image.Image interface.
+// Returns the RGB color, black for the pixels outside the image.
 func (img *Image) At(x, y int) color.Color {
-	return img.img.At(x, y)
+	return img.Get(x, y)
 }
 
 // Returns the color of the pixel at (x, y).
+// Unlike At, does not allocate memory for the color.Color interface.
 func (img *Image) Get(x, y int) RGB {
-	var r, g, b, _ = img.At(x, y).RGBA()
-	return RGB{uint8(r), uint8(g), uint8(b)}
+	var c = img.img.RGBAAt(x, y)
+	return RGB{c.R, c.G, c.B}
+}
+
+// Implementation of the Set method in the draw.Image interface.
+// The color is converted by the RGBModel, so the translucent colors are stored as if drawn over black;
+// use the image/draw package with the draw.Over operator to blend them with the image instead.
+// The pixels outside the image are not changed.
+func (img *Image) Set(x, y int, c color.Color) {
+	img.SetRGB(x, y, RGBModel.Convert(c).(RGB))
 }
 
 // Sets the color of the pixel at (x, y).
-func (img *Image) Set(x, y int, rgb RGB) {
-	img.img.Set(x, y, rgb.ToRGBA())
+// Unlike Set, does not allocate memory, so it can be called for each pixel of each frame.
+// The pixels outside the image are not changed.
+func (img *Image) SetRGB(x, y int, rgb RGB) {
+	img.img.SetRGBA(x, y, rgb.ToRGBA())
 }
 
 // Returns the width of the image in pixels.
@@ -110,9 +105,9 @@ func (img *Image) Line(x1, y1, x2, y2 int, rgb RGB) {
 	// Calculate the y-axis offset relative to the center of the pixel at each step.
 	for x := x1; x <= x2; x++ {
 		if steep {
-			img.Set(y, x, rgb)
+			img.SetRGB(y, x, rgb)
 		} else {
-			img.Set(x, y, rgb)
+			img.SetRGB(x, y, rgb)
 		}
 		inaccuracy += deltaInaccuracy
 		if inaccuracy > 0.5 {
@@ -2,8 +2,15 @@ package pngimage
 
 import (
 	"fmt"
+	xdraw "golang.org/x/image/draw"
+	"image"
+	"image/color"
+	"image/draw"
 	"math"
+	"math/rand"
 	"os"
+	"runtime"
+	"sync"
 	"testing"
 )
 
@@ -43,3 +50,309 @@ func ExampleImage_Line() {
 	}
 	// Output: Ok
 }
+
+// Example of the colors of the procedural backgrounds.
+func ExampleVerticalGradient() {
+	var img = VerticalGradient(3, 5, BlackColor(), RGB{R: 200, G: 100})
+	for y := 0; y < img.Height(); y++ {
+		fmt.Println(img.Get(1, y))
+	}
+	img = RadialGradient(5, 5, WhiteColor(), BlackColor())
+	fmt.Println(img.Get(2, 2), img.Get(0, 2), img.Get(0, 0))
+	img.Vignette(0.5)
+	fmt.Println(img.Get(2, 2), img.Get(0, 2), img.Get(0, 0))
+	// Output:
+	//{0 0 0}
+	//{50 25 0}
+	//{100 50 0}
+	//{150 75 0}
+	//{200 100 0}
+	//{255 255 255} {75 75 75} {0 0 0}
+	//{255 255 255} {56 56 56} {0 0 0}
+}
+
+// The results of the color arithmetic are clamped instead of wrapping around.
+func ExampleRGB_Scale() {
+	var rgb = RGB{R: 200, G: 100, B: 0}
+	fmt.Println(rgb.Scale(0.5), rgb.Scale(1.5), rgb.Scale(-0.01))
+	fmt.Println(rgb.Add(RGB{R: 100, G: 100, B: 100}))
+	fmt.Println(rgb.Mul(RGB{R: 255, G: 128, B: 255}))
+	// Output:
+	//{100 50 0} {255 150 0} {0 0 0}
+	//{255 200 100}
+	//{200 50 0}
+}
+
+// Example of drawing a label with the built-in font and copying it to another image.
+func ExampleImage_DrawText() {
+	var (
+		label = BlackImage(uint(TextWidth("Hi!", 1)), GlyphHeight)
+		img   = BlackImage(19, 9)
+	)
+	label.DrawText(0, 0, "Hi!", 1, WhiteColor())
+	img.Blit(label, 1, 1)
+	for y := 0; y < img.Height(); y++ {
+		for x := 0; x < img.Width(); x++ {
+			if img.Get(x, y) == WhiteColor() {
+				fmt.Print("#")
+			} else {
+				fmt.Print(".")
+			}
+		}
+		fmt.Println()
+	}
+	fmt.Println(TextWidth("Hi!", 2))
+	// Output:
+	//...................
+	//.#...#..###....#...
+	//.#...#...#.....#...
+	//.#...#...#.....#...
+	//.#####...#.....#...
+	//.#...#...#.....#...
+	//.#...#...#.........
+	//.#...#..###....#...
+	//...................
+	//34
+}
+
+// Example of the closest surface kept by the FrameBuffer regardless of the order of the writes.
+func ExampleFrameBuffer_CompareAndSet() {
+	var buffer = NewFrameBuffer(2, 1, BlackColor())
+	fmt.Println(buffer.CompareAndSet(0, 0, 5, RGB{R: 255}))
+	fmt.Println(buffer.CompareAndSet(0, 0, 7, RGB{G: 255}))
+	fmt.Println(buffer.CompareAndSet(0, 0, -2.5, RGB{B: 255}))
+	fmt.Println(buffer.CompareAndSet(0, 0, math.NaN(), WhiteColor()))
+	fmt.Println(buffer.Get(0, 0), buffer.Depth(0, 0))
+	// The equal depths are ordered by the colors.
+	fmt.Println(buffer.CompareAndSet(1, 0, 1, RGB{G: 255}))
+	fmt.Println(buffer.CompareAndSet(1, 0, 1, RGB{R: 255}))
+	fmt.Println(buffer.CompareAndSet(1, 0, 1, RGB{B: 255}))
+	fmt.Println(buffer.Get(1, 0), buffer.Depth(1, 0))
+	// Output:
+	//true
+	//false
+	//true
+	//false
+	//{0 0 255} -2.5
+	//true
+	//false
+	//true
+	//{0 0 255} 1
+}
+
+// A rectangle of the pixels with the same depth and color drawn by the benchmarks of the FrameBuffer.
+type testRectangle struct {
+	x, y, width, height int
+	depth               float64
+	rgb                 RGB
+}
+
+// Creates the random rectangles inside the image of the specified size.
+func randomRectangles(count, size int) []testRectangle {
+	var (
+		random     = rand.New(rand.NewSource(1))
+		rectangles = make([]testRectangle, count)
+	)
+	for i := range rectangles {
+		var width, height = 1 + random.Intn(size/4), 1 + random.Intn(size/4)
+		rectangles[i] = testRectangle{
+			x:      random.Intn(size - width),
+			y:      random.Intn(size - height),
+			width:  width,
+			height: height,
+			depth:  random.Float64(),
+			rgb:    RandomColor(),
+		}
+	}
+	return rectangles
+}
+
+// Testing that the Image works as the destination and the source of the image/draw package.
+func TestImage_draw(t *testing.T) {
+	var _ draw.Image = (*Image)(nil)
+	var (
+		img  = WhiteImage(4, 2)
+		red  = color.NRGBA{R: 255, A: 128}
+		mask = image.NewAlpha(image.Rect(0, 0, 4, 2))
+	)
+	mask.SetAlpha(3, 1, color.Alpha{A: 255})
+	draw.Draw(img, image.Rect(0, 0, 1, 1), image.NewUniform(red), image.Point{}, draw.Over)
+	draw.Draw(img, image.Rect(1, 0, 2, 1), image.NewUniform(red), image.Point{}, draw.Src)
+	draw.Draw(img, image.Rect(2, 0, 3, 1), image.NewUniform(RedColor()), image.Point{}, draw.Src)
+	draw.DrawMask(img, img.Bounds(), image.NewUniform(BlueColor()), image.Point{}, mask, image.Point{}, draw.Over)
+	var copied = image.NewRGBA(img.Bounds())
+	draw.Draw(copied, copied.Bounds(), img, image.Point{}, draw.Src)
+	for _, test := range []struct {
+		x, y int
+		want RGB
+	}{
+		{0, 0, RGB{R: 255, G: 127, B: 127}}, // Translucent red over white.
+		{1, 0, RGB{R: 128}},                 // Translucent red replacing the pixel, as if over black.
+		{2, 0, RedColor()},
+		{3, 0, WhiteColor()},
+		{3, 1, BlueColor()}, // The only pixel of the mask.
+		{0, 1, WhiteColor()},
+	} {
+		if got := img.Get(test.x, test.y); got != test.want {
+			t.Errorf("pixel (%d, %d): got: %v, want: %v", test.x, test.y, got, test.want)
+		}
+		if got := RGBModel.Convert(copied.At(test.x, test.y)); got != test.want {
+			t.Errorf("copied pixel (%d, %d): got: %v, want: %v", test.x, test.y, got, test.want)
+		}
+	}
+	if r, g, b, a := WhiteColor().RGBA(); r != 0xffff || g != 0xffff || b != 0xffff || a != 0xffff {
+		t.Errorf("white RGBA: got: %x %x %x %x, want: ffff ffff ffff ffff", r, g, b, a)
+	}
+}
+
+// Testing that the Image works as the destination and the source of the scalers of the golang.org/x/image/draw package.
+func TestImage_scale(t *testing.T) {
+	var (
+		src    = NewImage(2, 2)
+		scaled = NewImage(4, 4)
+		smooth = NewImage(4, 4)
+	)
+	src.SetRGB(0, 0, RedColor())
+	src.SetRGB(1, 0, GreenColor())
+	src.SetRGB(0, 1, BlueColor())
+	src.SetRGB(1, 1, WhiteColor())
+	xdraw.NearestNeighbor.Scale(scaled, scaled.Bounds(), src, src.Bounds(), xdraw.Src, nil)
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 4; x++ {
+			if got, want := scaled.Get(x, y), src.Get(x/2, y/2); got != want {
+				t.Errorf("nearest neighbor pixel (%d, %d): got: %v, want: %v", x, y, got, want)
+			}
+		}
+	}
+	xdraw.ApproxBiLinear.Scale(smooth, smooth.Bounds(), src, src.Bounds(), xdraw.Src, nil)
+	for _, test := range []struct {
+		x, y int
+		want RGB
+	}{
+		{0, 0, RedColor()}, // The corners keep the colors of the source pixels.
+		{3, 0, GreenColor()},
+		{0, 3, BlueColor()},
+		{3, 3, WhiteColor()},
+	} {
+		if got := smooth.Get(test.x, test.y); got != test.want {
+			t.Errorf("bilinear pixel (%d, %d): got: %v, want: %v", test.x, test.y, got, test.want)
+		}
+	}
+	if got := smooth.Get(1, 0); got == RedColor() || got == GreenColor() {
+		t.Errorf("bilinear pixel (1, 0): got: %v, want a blend of red and green", got)
+	}
+}
+
+// Testing that the concurrent writes to the FrameBuffer keep the same pixels as the sequential ones.
+func TestFrameBuffer_CompareAndSet(t *testing.T) {
+	const size = 64
+	var (
+		rectangles = randomRectangles(2000, size)
+		sequential = NewFrameBuffer(size, size, BlackColor())
+		parallel   = NewFrameBuffer(size, size, BlackColor())
+		wg         sync.WaitGroup
+		workers    = 8
+	)
+	for _, r := range rectangles {
+		drawRectangle(sequential, r)
+	}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := len(rectangles) - 1 - w; i >= 0; i -= workers {
+				drawRectangle(parallel, rectangles[i])
+			}
+		}(w)
+	}
+	wg.Wait()
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			if sequential.Get(x, y) != parallel.Get(x, y) || sequential.Depth(x, y) != parallel.Depth(x, y) {
+				t.Fatalf("pixel (%d, %d): got: %v %g, want: %v %g",
+					x, y, parallel.Get(x, y), parallel.Depth(x, y), sequential.Get(x, y), sequential.Depth(x, y))
+			}
+		}
+	}
+}
+
+// Draws the rectangle on the FrameBuffer by the CompareAndSet method.
+func drawRectangle(buffer *FrameBuffer, r testRectangle) {
+	for y := r.y; y < r.y+r.height; y++ {
+		for x := r.x; x < r.x+r.width; x++ {
+			buffer.CompareAndSet(x, y, r.depth, r.rgb)
+		}
+	}
+}
+
+// Compares the parallel drawing without tiles, which writes the pixels by the FrameBuffer.CompareAndSet method,
+// with the tiled drawing, in which each goroutine draws its own rows of the image without synchronization.
+func BenchmarkFrameBuffer(b *testing.B) {
+	const size = 512
+	var (
+		rectangles = randomRectangles(5000, size)
+		workers    = runtime.NumCPU()
+	)
+	b.Run("atomic", func(b *testing.B) {
+		var buffer = NewFrameBuffer(size, size, BlackColor())
+		for i := 0; i < b.N; i++ {
+			buffer.Clear(BlackColor())
+			var wg sync.WaitGroup
+			for w := 0; w < workers; w++ {
+				wg.Add(1)
+				go func(w int) {
+					defer wg.Done()
+					for k := w; k < len(rectangles); k += workers {
+						drawRectangle(buffer, rectangles[k])
+					}
+				}(w)
+			}
+			wg.Wait()
+		}
+	})
+	b.Run("tiled", func(b *testing.B) {
+		var (
+			img   = BlackImage(size, size)
+			depth = make([]float64, size*size)
+		)
+		for i := 0; i < b.N; i++ {
+			for k := range depth {
+				depth[k] = math.Inf(+1)
+			}
+			var wg sync.WaitGroup
+			for w := 0; w < workers; w++ {
+				wg.Add(1)
+				go func(top, bottom int) {
+					defer wg.Done()
+					for _, r := range rectangles {
+						for y := maxInt(r.y, top); y < minInt(r.y+r.height, bottom); y++ {
+							for x := r.x; x < r.x+r.width; x++ {
+								if r.depth < depth[y*size+x] {
+									depth[y*size+x] = r.depth
+									img.SetRGB(x, y, r.rgb)
+								}
+							}
+						}
+					}
+				}(w*size/workers, (w+1)*size/workers)
+			}
+			wg.Wait()
+		}
+	})
+}
+
+// Returns the greater of the numbers.
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// Returns the smaller of the numbers.
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,77 @@
+package pngimage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image/png"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// The length of the PNG signature and the IHDR chunk that starts each PNG file.
+const headerLength = 8 + 4 + 4 + 13 + 4
+
+// Writes the image in the PNG format with the text metadata stored in the tEXt chunks,
+// which are shown by image viewers and can be read by other tools.
+// The keys are written in alphabetical order, they must be from 1 to 79 characters long.
+func (img *Image) EncodeWithText(w io.Writer, text map[string]string) error {
+	var encoded bytes.Buffer
+	if err := png.Encode(&encoded, img.img); err != nil {
+		return err
+	}
+	var data = encoded.Bytes()
+	var keys = make([]string, 0, len(text))
+	for key := range text {
+		if len(key) == 0 || len(key) > 79 || strings.IndexByte(key, 0) >= 0 {
+			return errors.New("invalid PNG text key: " + key)
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	if _, err := w.Write(data[:headerLength]); err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if err := writeTextChunk(w, key, text[key]); err != nil {
+			return err
+		}
+	}
+	var _, err = w.Write(data[headerLength:])
+	return err
+}
+
+// Saves the image in a file named filename with the text metadata, see Image.EncodeWithText.
+// The file name must contain the .png postfix.
+func (img *Image) SaveWithText(filename string, text map[string]string) error {
+	if !strings.HasSuffix(filename, ".png") {
+		return errors.New("file must be in PNG format")
+	}
+	var file, err = os.Create(filename)
+	if err != nil {
+		return err
+	}
+	if err := img.EncodeWithText(file, text); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// Writes the tEXt chunk: the length of the data, the type, the data and the CRC of the type and the data.
+func writeTextChunk(w io.Writer, key, value string) error {
+	var (
+		length = len(key) + 1 + len(value)
+		chunk  = make([]byte, 4+4+length+4)
+	)
+	binary.BigEndian.PutUint32(chunk, uint32(length))
+	copy(chunk[4:], "tEXt")
+	copy(chunk[8:], key)
+	copy(chunk[8+len(key)+1:], value)
+	binary.BigEndian.PutUint32(chunk[8+length:], crc32.ChecksumIEEE(chunk[4:8+length]))
+	var _, err = w.Write(chunk)
+	return err
+}
@@ -2,6 +2,7 @@ package pngimage
 
 import (
 	"image/color"
+	"math"
 	"math/rand"
 )
 
@@ -12,9 +13,25 @@ type RGB struct {
 	R, G, B uint8
 }
 
+// The color.Model of the RGB colors, used by the Image.
+// The colors with alpha less than the maximum are converted as if drawn over black,
+// since the RGB colors are always completely opaque.
+var RGBModel = color.ModelFunc(rgbModel)
+
 // Implementation of the RGBA method in the color.Color interface.
+// The channels are scaled to 16 bits, as required by the interface.
 func (rgb RGB) RGBA() (r, g, b, a uint32) {
-	return uint32(rgb.R), uint32(rgb.G), uint32(rgb.B), 255
+	return uint32(rgb.R) * 0x101, uint32(rgb.G) * 0x101, uint32(rgb.B) * 0x101, 0xffff
+}
+
+// Converts the color to RGB, see RGBModel.
+func rgbModel(c color.Color) color.Color {
+	if rgb, ok := c.(RGB); ok {
+		return rgb
+	}
+	// The channels returned by the RGBA method are already multiplied by alpha.
+	var r, g, b, _ = c.RGBA()
+	return RGB{R: uint8(r >> 8), G: uint8(g >> 8), B: uint8(b >> 8)}
 }
 
 // Converts an RGB object to an color.RGBA object.
@@ -27,6 +44,40 @@ func (rgb RGB) ToRGBA() color.RGBA {
 	}
 }
 
+// Multiplies each channel of the color by the factor.
+// The result is rounded and clamped to [0, 255], so that the factors slightly out of range do not wrap around.
+func (rgb RGB) Scale(f float64) RGB {
+	return RGB{
+		R: clampChannel(float64(rgb.R) * f),
+		G: clampChannel(float64(rgb.G) * f),
+		B: clampChannel(float64(rgb.B) * f),
+	}
+}
+
+// Adds the channels of the colors, the result is clamped to 255.
+func (rgb RGB) Add(other RGB) RGB {
+	return RGB{
+		R: clampChannel(float64(rgb.R) + float64(other.R)),
+		G: clampChannel(float64(rgb.G) + float64(other.G)),
+		B: clampChannel(float64(rgb.B) + float64(other.B)),
+	}
+}
+
+// Multiplies the channels of the colors as the values from 0 to 1, for example, to tint a color by a light color.
+// The result is rounded.
+func (rgb RGB) Mul(other RGB) RGB {
+	return RGB{
+		R: clampChannel(float64(rgb.R) * float64(other.R) / 255),
+		G: clampChannel(float64(rgb.G) * float64(other.G) / 255),
+		B: clampChannel(float64(rgb.B) * float64(other.B) / 255),
+	}
+}
+
+// Rounds the value of the channel and clamps it to [0, 255].
+func clampChannel(value float64) uint8 {
+	return uint8(math.Round(math.Max(0, math.Min(255, value))))
+}
+
 // Creates black RGB color.
 func BlackColor() RGB {
 	return RGB{R: 0, G: 0, B: 0}
@@ -0,0 +1,151 @@
+package render
+
+import (
+	"computer_graphics/pngimage"
+	"math"
+	"math/rand"
+)
+
+// HDRTarget that averages the frames drawn on it in the high dynamic range,
+// so that the frames drawn with different sample positions give the anti-aliased image (progressive anti-aliasing).
+// The pixels are drawn on the current frame, EndFrame adds the frame to the sum of the frames,
+// Resolve maps the average of the frames to the colors of another Target by the tone mapping.
+// The colors of the HDRMaterial are kept brighter than white until the tone mapping,
+// so the bright samples are not clipped before averaging and the edges of the bright surfaces stay bright.
+type Accumulator struct {
+	width, height int
+	background    Vec3                          // The color of the pixels of the frame that were not drawn.
+	frame         []Vec3                        // The pixels of the current frame.
+	sum           []Vec3                        // The sums of the colors of the pixels of the finished frames, without clipping.
+	frames        int                           // The number of the finished frames.
+	toneMapping   func(color Vec3) pngimage.RGB // Maps the average colors to the colors of the target.
+}
+
+// Creates a new Accumulator with the specified size in pixels and the color of the pixels that were not drawn.
+func NewAccumulator(width, height int, background pngimage.RGB) *Accumulator {
+	var a = &Accumulator{
+		width:       width,
+		height:      height,
+		background:  rgbToVec3(background),
+		frame:       make([]Vec3, width*height),
+		sum:         make([]Vec3, width*height),
+		toneMapping: ClampToneMapping,
+	}
+	a.clearFrame()
+	return a
+}
+
+// Implementation of the Width method in the Target interface.
+func (a *Accumulator) Width() int {
+	return a.width
+}
+
+// Implementation of the Height method in the Target interface.
+func (a *Accumulator) Height() int {
+	return a.height
+}
+
+// Implementation of the SetRGB method in the Target interface.
+// Sets the color of the pixel of the current frame.
+func (a *Accumulator) SetRGB(x, y int, rgb pngimage.RGB) {
+	a.frame[y*a.width+x] = rgbToVec3(rgb)
+}
+
+// Implementation of the SetHDR method in the HDRTarget interface.
+// Sets the color of the pixel of the current frame.
+func (a *Accumulator) SetHDR(x, y int, color Vec3) {
+	a.frame[y*a.width+x] = color
+}
+
+// Sets the function that maps the average colors of the pixels to the colors of the target in Resolve,
+// by default, ClampToneMapping. For example, ReinhardToneMapping keeps the details of the colors brighter than white.
+func (a *Accumulator) SetToneMapping(toneMapping func(color Vec3) pngimage.RGB) {
+	a.toneMapping = toneMapping
+}
+
+// Adds the current frame to the sum of the frames and starts a new frame filled with the background color.
+func (a *Accumulator) EndFrame() {
+	for i, color := range a.frame {
+		a.sum[i].X += color.X
+		a.sum[i].Y += color.Y
+		a.sum[i].Z += color.Z
+	}
+	a.frames++
+	a.clearFrame()
+}
+
+// Returns the number of the frames finished by EndFrame.
+func (a *Accumulator) Frames() int {
+	return a.frames
+}
+
+// Writes the average color of each pixel over the finished frames mapped by the tone mapping to the target.
+// The target must have the same size as the Accumulator.
+// Does nothing if there are no finished frames.
+func (a *Accumulator) Resolve(target Target) {
+	if a.frames == 0 {
+		return
+	}
+	var k = 1 / float64(a.frames)
+	for y := 0; y < a.height; y++ {
+		for x := 0; x < a.width; x++ {
+			var sum = a.sum[y*a.width+x]
+			target.SetRGB(x, y, a.toneMapping(Vec3{sum.X * k, sum.Y * k, sum.Z * k}))
+		}
+	}
+}
+
+// Removes all the frames and starts a new frame.
+func (a *Accumulator) Reset() {
+	for i := range a.sum {
+		a.sum[i] = Vec3{}
+	}
+	a.frames = 0
+	a.clearFrame()
+}
+
+// Fills the current frame with the background color.
+func (a *Accumulator) clearFrame() {
+	for i := range a.frame {
+		a.frame[i] = a.background
+	}
+}
+
+// Tone mapping that clamps the channels of the color to 255, so that all colors brighter than white become white.
+func ClampToneMapping(color Vec3) pngimage.RGB {
+	return pngimage.RGB{R: clampChannel(color.X), G: clampChannel(color.Y), B: clampChannel(color.Z)}
+}
+
+// Tone mapping by the Reinhard operator x / (1 + x) applied to the channels as the values from 0 to 1:
+// the colors brighter than white are compressed instead of clipped, but the whole image becomes darker.
+func ReinhardToneMapping(color Vec3) pngimage.RGB {
+	var reinhard = func(channel float64) uint8 {
+		var x = math.Max(0, channel) / 255
+		return clampChannel(x / (1 + x) * 255)
+	}
+	return pngimage.RGB{R: reinhard(color.X), G: reinhard(color.Y), B: reinhard(color.Z)}
+}
+
+// Rounds the channel and clamps it to the range from 0 to 255.
+func clampChannel(channel float64) uint8 {
+	return uint8(math.Round(math.Max(0, math.Min(255, channel))))
+}
+
+// Returns the sample positions within the pixel for n x n frames of the progressive anti-aliasing:
+// the pixel is divided into n x n cells, and a random point is taken in each cell (stratified jittered sampling).
+// The positions are the same for the same seed, so that the images are reproducible.
+func JitteredSamples(n int, seed int64) [][2]float64 {
+	var (
+		random  = rand.New(rand.NewSource(seed))
+		samples = make([][2]float64, 0, n*n)
+	)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			samples = append(samples, [2]float64{
+				(float64(i) + random.Float64()) / float64(n),
+				(float64(j) + random.Float64()) / float64(n),
+			})
+		}
+	}
+	return samples
+}
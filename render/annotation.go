@@ -0,0 +1,106 @@
+package render
+
+import (
+	"computer_graphics/model"
+	"computer_graphics/pngimage"
+)
+
+// The shift of the outlines towards the viewer,
+// so that the outlines are not hidden by the faces they surround due to the depth interpolation errors.
+const outlineBias = 1
+
+// Material that tints the selected faces and the surroundings of the selected vertices of the model,
+// so that the problem places found by the validation tools (degenerate faces, non-manifold edges,
+// ICP outliers and so on) are visible on the final image.
+// The faces and the vertices are identified by their indices in the model starting from 0,
+// see Fragment.FaceIndex and model.Face.Indices.
+type HighlightMaterial struct {
+	Base         Material     // The material of the points that are not highlighted.
+	Faces        map[int]bool // The indices of the highlighted faces.
+	Vertices     map[int]bool // The indices of the highlighted vertices.
+	Color        pngimage.RGB // The color of the tint.
+	Amount       float64      // The strength of the tint from 0 to 1.
+	VertexRadius float64      // The part of the faces around the highlighted vertex in barycentric coordinates from 0 to 1.
+}
+
+// Implementation of the Shade method in the Material interface.
+func (m *HighlightMaterial) Shade(fragment *Fragment) pngimage.RGB {
+	var color = m.Base.Shade(fragment)
+	if !m.highlighted(fragment) {
+		return color
+	}
+	return pngimage.RGB{
+		R: blendChannel(color.R, m.Color.R, m.Amount),
+		G: blendChannel(color.G, m.Color.G, m.Amount),
+		B: blendChannel(color.B, m.Color.B, m.Amount),
+	}
+}
+
+// Adds the faces to the highlighted ones and returns the material itself.
+func (m *HighlightMaterial) HighlightFaces(indices ...int) *HighlightMaterial {
+	for _, index := range indices {
+		m.Faces[index] = true
+	}
+	return m
+}
+
+// Adds the vertices to the highlighted ones and returns the material itself.
+func (m *HighlightMaterial) HighlightVertices(indices ...int) *HighlightMaterial {
+	for _, index := range indices {
+		m.Vertices[index] = true
+	}
+	return m
+}
+
+// Checks whether the fragment belongs to a highlighted face or lies near a highlighted vertex.
+func (m *HighlightMaterial) highlighted(fragment *Fragment) bool {
+	if m.Faces[fragment.FaceIndex] {
+		return true
+	}
+	if len(m.Vertices) == 0 {
+		return false
+	}
+	var (
+		i1, i2, i3 = fragment.Face.Indices()
+		bary       = fragment.Barycentric
+		threshold  = 1 - m.VertexRadius
+	)
+	return m.Vertices[i1] && bary.X >= threshold ||
+		m.Vertices[i2] && bary.Y >= threshold ||
+		m.Vertices[i3] && bary.Z >= threshold
+}
+
+// Creates a new HighlightMaterial that tints the highlighted places of the base material with the color.
+// By default, the tint strength is 0.6 and a quarter of the faces around the highlighted vertices is tinted.
+func NewHighlightMaterial(base Material, color pngimage.RGB) *HighlightMaterial {
+	return &HighlightMaterial{
+		Base:         base,
+		Faces:        make(map[int]bool),
+		Vertices:     make(map[int]bool),
+		Color:        color,
+		Amount:       0.6,
+		VertexRadius: 0.25,
+	}
+}
+
+// Draws the edges of the faces of the model with the specified indices starting from 0.
+// The outlines are tested against the z-buffer like the lines, see RenderLines,
+// so the model must be rendered before to hide the outlines of the invisible faces.
+func (r *Renderer) OutlineFaces(m *model.Model, rgb pngimage.RGB, indices ...int) {
+	for _, index := range indices {
+		var (
+			face     = m.GetFace(index)
+			vertices = [...]Vec3{
+				vertexToVec3(face.Vertex1()),
+				vertexToVec3(face.Vertex2()),
+				vertexToVec3(face.Vertex3()),
+			}
+		)
+		for i := range vertices {
+			vertices[i].Z -= outlineBias
+		}
+		for i := range vertices {
+			r.renderSegment(vertices[i], vertices[(i+1)%len(vertices)], rgb)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package render
+
+import (
+	"computer_graphics/pngimage"
+	"math"
+	"strconv"
+)
+
+// Settings of the export of the DepthBuffer as a grayscale image.
+type DepthExport struct {
+	// The depths mapped to white and black, the depths out of the range are clamped.
+	// If Near equals Far, the range of the depths of the drawn pixels is used.
+	Near, Far float64
+	Invert    bool // If true, the near depths are black and the far depths are white.
+}
+
+// Returns the minimum and the maximum depth of the drawn pixels,
+// the pixels with the background depth or the infinite depth are not drawn.
+// Returns false if no pixels are drawn.
+func (buffer *DepthBuffer) Range() (min, max float64, ok bool) {
+	min, max = math.Inf(+1), math.Inf(-1)
+	for _, depth := range buffer.depth {
+		if depth == buffer.background || math.IsInf(depth, 0) || math.IsNaN(depth) {
+			continue
+		}
+		min = math.Min(min, depth)
+		max = math.Max(max, depth)
+		ok = true
+	}
+	return min, max, ok
+}
+
+// Converts the DepthBuffer to a grayscale image: the near depths are white and the far depths are black.
+// The pixels that are not drawn have the color of the far depths.
+func (buffer *DepthBuffer) Image(export DepthExport) *pngimage.Image {
+	var (
+		img       = pngimage.NewImage(uint(buffer.width), uint(buffer.height))
+		near, far = buffer.exportRange(export)
+	)
+	for y := 0; y < buffer.height; y++ {
+		for x := 0; x < buffer.width; x++ {
+			var (
+				depth = buffer.At(x, y)
+				t     = 1.0 // The distance from the near depth: 0 is white, 1 is black.
+			)
+			if depth != buffer.background && !math.IsInf(depth, 0) && !math.IsNaN(depth) && far != near {
+				t = math.Max(0, math.Min(1, (depth-near)/(far-near)))
+			}
+			if export.Invert {
+				t = 1 - t
+			}
+			img.SetRGB(x, y, pngimage.WhiteColor().Scale(1-t))
+		}
+	}
+	return img
+}
+
+// Saves the DepthBuffer as a grayscale PNG image, see DepthBuffer.Image.
+// The minimum and the maximum depth of the drawn pixels and the depths mapped to white and black
+// are embedded in the text metadata of the image with the keys depth_min, depth_max, depth_near and depth_far.
+func (buffer *DepthBuffer) SavePNG(filename string, export DepthExport) error {
+	var (
+		near, far = buffer.exportRange(export)
+		text      = map[string]string{
+			"depth_near": formatDepth(near),
+			"depth_far":  formatDepth(far),
+		}
+	)
+	if min, max, ok := buffer.Range(); ok {
+		text["depth_min"] = formatDepth(min)
+		text["depth_max"] = formatDepth(max)
+	}
+	return buffer.Image(export).SaveWithText(filename, text)
+}
+
+// Returns the depths mapped to white and black.
+func (buffer *DepthBuffer) exportRange(export DepthExport) (near, far float64) {
+	if export.Near != export.Far {
+		return export.Near, export.Far
+	}
+	if min, max, ok := buffer.Range(); ok {
+		return min, max
+	}
+	return 0, 0
+}
+
+// Converts the depth to the shortest string that can be parsed back to the same value.
+func formatDepth(depth float64) string {
+	return strconv.FormatFloat(depth, 'g', -1, 64)
+}
@@ -0,0 +1,83 @@
+package render
+
+import (
+	"computer_graphics/model"
+	"computer_graphics/pngimage"
+	"math"
+)
+
+// The state of the lighting for which the brightness of the corners was calculated by the GouraudMaterial.
+type gouraudKey struct {
+	light   Vec3    // The direction to the light.
+	ambient float64 // The ambient brightness.
+	faces   int     // The number of the faces of the model.
+}
+
+// Material that paints the model with a single color lit by a directional light using the Gouraud shading:
+// the light is calculated once per corner of each face with the normals generated by model.Model.GenerateNormals,
+// and only the brightness of the corners is interpolated over the faces for each pixel,
+// which is much faster than lighting each pixel and still shows the smooth surfaces smooth.
+// The faces of the model without the generated normals are lit by their own normals, like by the ClayMaterial.
+//
+// The brightness of the corners is cached and calculated again when the Light, the Ambient
+// or the number of the faces of the model is changed. The cache must be updated by Update
+// after the vertices of the model are transformed or its normals are generated again.
+// The material must be used only for the model for which it was created.
+type GouraudMaterial struct {
+	Color   pngimage.RGB // The color of the fully lit surface.
+	Light   Vec3         // The direction to the light, does not have to be a unit vector.
+	Ambient float64      // The brightness of the surface not lit by the light from 0 to 1.
+
+	model      *model.Model // The model whose corners are lit.
+	brightness [][3]float64 // The brightness of the corners of the faces.
+	key        gouraudKey   // The state of the lighting for which the brightness was calculated.
+}
+
+// Creates a new GouraudMaterial for the model with the specified color lit from the upper left side of the viewer,
+// like the ClayMaterial.
+func NewGouraudMaterial(m *model.Model, color pngimage.RGB) *GouraudMaterial {
+	return &GouraudMaterial{Color: color, Light: Vec3{X: -0.5, Y: -0.5, Z: -1}, Ambient: 0.2, model: m}
+}
+
+// Implementation of the Shade method in the Material interface.
+func (m *GouraudMaterial) Shade(fragment *Fragment) pngimage.RGB {
+	if m.key != m.currentKey() {
+		m.Update()
+	}
+	var (
+		corners = &m.brightness[fragment.FaceIndex]
+		bary    = fragment.Barycentric
+	)
+	return m.Color.Scale(bary.X*corners[0] + bary.Y*corners[1] + bary.Z*corners[2])
+}
+
+// Calculates the brightness of the corners of all faces of the model for the current lighting.
+// Both sides of the faces are lit in the same way, so the orientation of the faces does not matter.
+func (m *GouraudMaterial) Update() {
+	m.key = m.currentKey()
+	var (
+		count = m.model.FacesCount()
+		light = m.Light.Normalize()
+	)
+	if cap(m.brightness) < count {
+		m.brightness = make([][3]float64, count)
+	}
+	m.brightness = m.brightness[:count]
+	for i := 0; i < count; i++ {
+		var normals, ok = m.model.FaceNormals(i)
+		if !ok {
+			var x, y, z = m.model.GetFace(i).Normal()
+			var n = Vec3{x, y, z}.Normalize()
+			normals = [3]model.Vertex{{X: n.X, Y: n.Y, Z: n.Z}, {X: n.X, Y: n.Y, Z: n.Z}, {X: n.X, Y: n.Y, Z: n.Z}}
+		}
+		for j, n := range normals {
+			var lit = math.Abs(n.X*light.X + n.Y*light.Y + n.Z*light.Z)
+			m.brightness[i][j] = m.Ambient + (1-m.Ambient)*lit
+		}
+	}
+}
+
+// Returns the state of the lighting that determines the brightness of the corners.
+func (m *GouraudMaterial) currentKey() gouraudKey {
+	return gouraudKey{light: m.Light, ambient: m.Ambient, faces: m.model.FacesCount()}
+}
@@ -0,0 +1,155 @@
+package render
+
+import (
+	"computer_graphics/model"
+	"computer_graphics/pngimage"
+	"math"
+)
+
+// A square ground plane under a model, so that the presentation renders do not show the model floating in a void.
+// The plane is horizontal in the space of the model, where the Y axis points up as in .obj files,
+// it touches the lowest point of the model and is centered under the center of its bounding box.
+//
+// The Model of the plane consists of the vertices of the corners and two faces in the order known to GroundMaterial,
+// so it must be transformed to the coordinates of the Renderer in the same way as the model standing on it,
+// but its vertices and faces must not be changed otherwise.
+type GroundPlane struct {
+	Model    *model.Model // The quad of the plane with the normal pointing up.
+	HalfSize float64      // The half of the side of the quad in the units of the model.
+}
+
+// Creates a new GroundPlane under the model with the side equal to the largest size of the model
+// multiplied by the extent, so that the extent of 10 gives the plane spreading far beyond the model.
+// The extent less than 1 is replaced by 1, the model without vertices gets the plane with the side of 2 around the origin.
+func NewGroundPlane(m *model.Model, extent float64) *GroundPlane {
+	var (
+		min, max = m.Bounds()
+		size     = math.Max(max.X-min.X, math.Max(max.Y-min.Y, max.Z-min.Z))
+		x, z     = (min.X + max.X) / 2, (min.Z + max.Z) / 2
+		half     = math.Max(extent, 1) * size / 2
+		ground   = model.NewModel()
+	)
+	if half == 0 {
+		half = 1
+	}
+	// The corners go counterclockwise when seen from above, so the faces look up.
+	ground.AppendVertex(x-half, min.Y, z-half)
+	ground.AppendVertex(x-half, min.Y, z+half)
+	ground.AppendVertex(x+half, min.Y, z+half)
+	ground.AppendVertex(x+half, min.Y, z-half)
+	if err := ground.AppendFace(1, 2, 3); err != nil {
+		panic(err)
+	}
+	if err := ground.AppendFace(1, 3, 4); err != nil {
+		panic(err)
+	}
+	return &GroundPlane{Model: ground, HalfSize: half}
+}
+
+// The corners of the faces of the GroundPlane in the coordinates of the plane relative to its center,
+// measured in the halves of the side of the quad.
+var groundCorners = [2][3][2]float64{
+	{{-1, -1}, {-1, 1}, {1, 1}},
+	{{-1, -1}, {1, 1}, {1, -1}},
+}
+
+// Material of the GroundPlane that paints it with a procedural checkerboard or grid
+// fading to the Horizon color towards the edges of the quad, so that the plane looks infinite
+// when the Horizon is the color of the background.
+//
+// The pattern is placed in the coordinates of the plane calculated from the barycentric coordinates of the fragments,
+// so it stays the same whatever transform was applied to the plane to draw it.
+// If Shadow is set, the plane receives the shadows of the models rendered into the map,
+// with soft edges if the map uses the percentage-closer filtering.
+type GroundMaterial struct {
+	HalfSize  float64      // The half of the side of the GroundPlane in the units of the model.
+	CellSize  float64      // The side of a cell of the checkerboard or the grid in the units of the model.
+	LineWidth float64      // The width of the grid lines in the units of the model, 0 gives the checkerboard.
+	Color1    pngimage.RGB // The color of the even cells of the checkerboard or the color of the grid cells.
+	Color2    pngimage.RGB // The color of the odd cells of the checkerboard or the color of the grid lines.
+	Horizon   pngimage.RGB // The color the pattern fades to towards the edges of the plane.
+	FadeStart float64      // The distance from the center as a fraction of HalfSize from which the fading starts.
+	Shadow    *ShadowMap   // The map that determines the shadowed points, nil for the plane without shadows.
+	Darkness  float64      // The fraction by which the color of the completely shadowed points is reduced.
+}
+
+// Implementation of the Shade method in the Material interface.
+func (m *GroundMaterial) Shade(fragment *Fragment) pngimage.RGB {
+	var (
+		u, v  = m.planeCoordinates(fragment)
+		color = m.pattern(u, v)
+	)
+	if m.Shadow != nil {
+		var amount = m.Darkness * (1 - m.Shadow.Visibility(fragment))
+		color = pngimage.RGB{
+			R: blendChannel(color.R, 0, amount),
+			G: blendChannel(color.G, 0, amount),
+			B: blendChannel(color.B, 0, amount),
+		}
+	}
+	var (
+		distance = math.Hypot(u, v) / m.HalfSize
+		fade     = smoothStep(m.FadeStart, 1, distance)
+	)
+	return pngimage.RGB{
+		R: blendChannel(color.R, m.Horizon.R, fade),
+		G: blendChannel(color.G, m.Horizon.G, fade),
+		B: blendChannel(color.B, m.Horizon.B, fade),
+	}
+}
+
+// Creates a new GroundMaterial for the plane with the light gray checkerboard with the specified size of the cells
+// fading to black from the half of the distance to the edges, without shadows.
+// The Darkness is set to 0.6, so that only the Shadow has to be set to get the shadows.
+func NewGroundMaterial(plane *GroundPlane, cellSize float64) *GroundMaterial {
+	return &GroundMaterial{
+		HalfSize:  plane.HalfSize,
+		CellSize:  cellSize,
+		Color1:    pngimage.RGB{R: 200, G: 200, B: 200},
+		Color2:    pngimage.RGB{R: 150, G: 150, B: 150},
+		Horizon:   pngimage.BlackColor(),
+		FadeStart: 0.5,
+		Darkness:  0.6,
+	}
+}
+
+// Returns the coordinates of the fragment in the plane relative to its center in the units of the model.
+func (m *GroundMaterial) planeCoordinates(fragment *Fragment) (float64, float64) {
+	var (
+		corners = groundCorners[fragment.FaceIndex&1]
+		bary    = fragment.Barycentric
+		u       = bary.X*corners[0][0] + bary.Y*corners[1][0] + bary.Z*corners[2][0]
+		v       = bary.X*corners[0][1] + bary.Y*corners[1][1] + bary.Z*corners[2][1]
+	)
+	return u * m.HalfSize, v * m.HalfSize
+}
+
+// Returns the color of the checkerboard or the grid at the point of the plane.
+func (m *GroundMaterial) pattern(u, v float64) pngimage.RGB {
+	if m.LineWidth > 0 {
+		var (
+			du = math.Abs(u - math.Round(u/m.CellSize)*m.CellSize)
+			dv = math.Abs(v - math.Round(v/m.CellSize)*m.CellSize)
+		)
+		if du < m.LineWidth/2 || dv < m.LineWidth/2 {
+			return m.Color2
+		}
+		return m.Color1
+	}
+	if math.Mod(math.Floor(u/m.CellSize)+math.Floor(v/m.CellSize), 2) == 0 {
+		return m.Color1
+	}
+	return m.Color2
+}
+
+// Returns 0 for x not greater than edge0, 1 for x not less than edge1 and the smooth Hermite interpolation between them.
+func smoothStep(edge0, edge1, x float64) float64 {
+	if x <= edge0 {
+		return 0
+	}
+	if x >= edge1 {
+		return 1
+	}
+	var t = (x - edge0) / (edge1 - edge0)
+	return t * t * (3 - 2*t)
+}
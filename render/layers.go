@@ -0,0 +1,134 @@
+package render
+
+import (
+	"computer_graphics/model"
+	"computer_graphics/pngimage"
+	"math"
+)
+
+const (
+	comparisonPadding   = 4 // The space between the panels of the comparison strip and around them in pixels.
+	comparisonTextScale = 2 // The scale of the labels of the comparison strip, see pngimage.Image.DrawText.
+)
+
+// The model of the scene with its own material.
+type SceneObject struct {
+	Model    *model.Model // The model in the coordinates of the pixels, see Renderer.
+	Material Material     // The material of the model, used unless the Layer overrides it.
+}
+
+// The variant of rendering the scene with the overridden materials and shading,
+// for example, a clay render or a wireframe, see RenderLayer.
+type Layer struct {
+	Label          string       // The caption of the layer in the comparison strip.
+	Background     pngimage.RGB // The color of the pixels not covered by the models.
+	Material       Material     // Overrides the materials of all objects, nil keeps the materials of the objects.
+	Wireframe      bool         // Draws the edges of the faces over the surfaces.
+	WireframeColor pngimage.RGB // The color of the edges of the faces.
+}
+
+// Returns the Layer rendering the objects with their own materials.
+func ShadedLayer(label string, background pngimage.RGB) Layer {
+	return Layer{Label: label, Background: background}
+}
+
+// Returns the Layer rendering all objects with the ClayMaterial of the specified color.
+func ClayLayer(label string, background, color pngimage.RGB) Layer {
+	return Layer{Label: label, Background: background, Material: NewClayMaterial(color)}
+}
+
+// Returns the Layer rendering only the edges of the visible faces:
+// the surfaces are painted with the color of the background, so that they still hide the edges behind them.
+func WireframeLayer(label string, background, color pngimage.RGB) Layer {
+	return Layer{
+		Label:          label,
+		Background:     background,
+		Material:       NewUnlitMaterial(background),
+		Wireframe:      true,
+		WireframeColor: color,
+	}
+}
+
+// Material that paints the model with a single color lit by a directional light,
+// which shows the shape of the surface regardless of the textures and colors of the model.
+// Both sides of the faces are lit in the same way, so the orientation of the faces does not matter.
+type ClayMaterial struct {
+	Color   pngimage.RGB // The color of the fully lit surface.
+	Light   Vec3         // The direction to the light, does not have to be a unit vector.
+	Ambient float64      // The brightness of the surface not lit by the light from 0 to 1.
+}
+
+// Implementation of the Shade method in the Material interface.
+func (m *ClayMaterial) Shade(fragment *Fragment) pngimage.RGB {
+	var (
+		light = m.Light.Normalize()
+		n     = fragment.Normal
+		lit   = math.Abs(n.X*light.X + n.Y*light.Y + n.Z*light.Z)
+	)
+	return m.Color.Scale(m.Ambient + (1-m.Ambient)*lit)
+}
+
+// Creates a new ClayMaterial with the specified color lit from the upper left side of the viewer.
+func NewClayMaterial(color pngimage.RGB) *ClayMaterial {
+	return &ClayMaterial{Color: color, Light: Vec3{X: -0.5, Y: -0.5, Z: -1}, Ambient: 0.2}
+}
+
+// Fills the target with the background of the layer and renders the objects of the scene on it
+// with the overrides of the layer.
+func RenderLayer(target Target, scene []SceneObject, layer Layer) {
+	for y := 0; y < target.Height(); y++ {
+		for x := 0; x < target.Width(); x++ {
+			target.SetRGB(x, y, layer.Background)
+		}
+	}
+	var renderer = NewRenderer(target)
+	for _, object := range scene {
+		var material = object.Material
+		if layer.Material != nil {
+			material = layer.Material
+		}
+		renderer.Render(object.Model, material)
+	}
+	if !layer.Wireframe {
+		return
+	}
+	for _, object := range scene {
+		var indices = make([]int, object.Model.FacesCount())
+		for i := range indices {
+			indices[i] = i
+		}
+		renderer.OutlineFaces(object.Model, layer.WireframeColor, indices...)
+	}
+}
+
+// Renders the scene once for each layer and composes the renders into the comparison strip:
+// the renders of the specified size are placed from left to right with the labels of the layers under them.
+// The labels that do not fit into the width of the render are cut off.
+func RenderComparison(width, height int, scene []SceneObject, layers []Layer, labelColor, background pngimage.RGB) *pngimage.Image {
+	var (
+		labelHeight = pngimage.GlyphHeight*comparisonTextScale + comparisonPadding
+		strip       = pngimage.FilledImage(
+			uint(len(layers)*(width+comparisonPadding)+comparisonPadding),
+			uint(height+labelHeight+2*comparisonPadding),
+			background,
+		)
+		panel = pngimage.NewImage(uint(width), uint(height))
+	)
+	for i, layer := range layers {
+		var x = comparisonPadding + i*(width+comparisonPadding)
+		RenderLayer(panel, scene, layer)
+		strip.Blit(panel, x, comparisonPadding)
+		var label = []rune(layer.Label)
+		for len(label) > 0 && pngimage.TextWidth(string(label), comparisonTextScale) > width {
+			label = label[:len(label)-1]
+		}
+		strip.DrawText(
+			x+(width-pngimage.TextWidth(string(label), comparisonTextScale))/2,
+			2*comparisonPadding+height,
+			string(label),
+			comparisonTextScale,
+			labelColor,
+		)
+	}
+	return strip
+}
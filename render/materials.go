@@ -0,0 +1,216 @@
+package render
+
+import (
+	"computer_graphics/mathutils"
+	"computer_graphics/model"
+	"computer_graphics/pngimage"
+	"math"
+)
+
+// Debug material that paints all points of the model with a single color without any lighting.
+// Useful for checking the silhouette and the coverage of the model.
+type UnlitMaterial struct {
+	Color pngimage.RGB // The color of all points.
+}
+
+// Implementation of the Shade method in the Material interface.
+func (m *UnlitMaterial) Shade(*Fragment) pngimage.RGB {
+	return m.Color
+}
+
+// Creates a new UnlitMaterial with the specified color.
+func NewUnlitMaterial(color pngimage.RGB) *UnlitMaterial {
+	return &UnlitMaterial{Color: color}
+}
+
+// Material that paints all points of the model with a single color multiplied by the intensity without any lighting,
+// for example, the light sources. The intensity above 1 makes the color brighter than white in the high dynamic range,
+// so that the anti-aliased edges of the bright surfaces stay bright, see Accumulator.
+type EmissiveMaterial struct {
+	Color     pngimage.RGB // The color of all points at the intensity 1.
+	Intensity float64      // The multiplier of the color.
+}
+
+// Implementation of the Shade method in the Material interface.
+// The color is clamped to white.
+func (m *EmissiveMaterial) Shade(*Fragment) pngimage.RGB {
+	return m.Color.Scale(m.Intensity)
+}
+
+// Implementation of the ShadeHDR method in the HDRMaterial interface.
+func (m *EmissiveMaterial) ShadeHDR(*Fragment) Vec3 {
+	var color = rgbToVec3(m.Color)
+	return Vec3{color.X * m.Intensity, color.Y * m.Intensity, color.Z * m.Intensity}
+}
+
+// Creates a new EmissiveMaterial with the specified color and intensity.
+func NewEmissiveMaterial(color pngimage.RGB, intensity float64) *EmissiveMaterial {
+	return &EmissiveMaterial{Color: color, Intensity: intensity}
+}
+
+// Debug material that paints each point with the color encoding the normal to the surface:
+// the X, Y and Z components of the unit normal are mapped from [-1, 1] to the R, G and B channels.
+// Useful for finding flipped faces and incorrect normals.
+type NormalMaterial struct{}
+
+// Implementation of the Shade method in the Material interface.
+func (m *NormalMaterial) Shade(fragment *Fragment) pngimage.RGB {
+	return pngimage.RGB{
+		R: normalChannel(fragment.Normal.X),
+		G: normalChannel(fragment.Normal.Y),
+		B: normalChannel(fragment.Normal.Z),
+	}
+}
+
+// Creates a new NormalMaterial.
+func NewNormalMaterial() *NormalMaterial {
+	return &NormalMaterial{}
+}
+
+// Converts a component of the unit normal to a color channel value.
+func normalChannel(component float64) uint8 {
+	return uint8(math.Round((math.Max(-1, math.Min(1, component)) + 1) / 2 * 255))
+}
+
+// Debug material that paints the model with a three-dimensional checkerboard
+// made of cubes with the specified size in the space of the model.
+// Useful for seeing the shape of the surface and the scale of the model without lighting
+// and for the models without texture coordinates, see UVCheckerMaterial for the checkerboard in the texture space.
+type CheckerMaterial struct {
+	Size   float64      // The length of the edge of a single cube.
+	Color1 pngimage.RGB // The color of the even cubes.
+	Color2 pngimage.RGB // The color of the odd cubes.
+}
+
+// Implementation of the Shade method in the Material interface.
+func (m *CheckerMaterial) Shade(fragment *Fragment) pngimage.RGB {
+	var (
+		p   = fragment.Position
+		sum = math.Floor(p.X/m.Size) + math.Floor(p.Y/m.Size) + math.Floor(p.Z/m.Size)
+	)
+	if math.Mod(sum, 2) == 0 {
+		return m.Color1
+	}
+	return m.Color2
+}
+
+// Creates a new black and white CheckerMaterial with the specified size of the cubes.
+func NewCheckerMaterial(size float64) *CheckerMaterial {
+	return &CheckerMaterial{Size: size, Color1: pngimage.WhiteColor(), Color2: pngimage.BlackColor()}
+}
+
+// Debug material that paints the model with a checkerboard in the texture space,
+// interpolating the texture vertices of the corners of the face, see model.Model.FaceTextureVertices.
+// Useful for finding the stretched and flipped texture coordinates and the seams of the texture mapping.
+// The faces without texture vertices are painted with the CheckerMaterial in the space of the model.
+type UVCheckerMaterial struct {
+	CheckerMaterial              // Paints the faces without texture vertices, its colors are used for all faces.
+	Model           *model.Model // The model being drawn, whose texture vertices are used.
+	Squares         float64      // The number of squares along each side of the unit square of the texture space.
+}
+
+// Implementation of the Shade method in the Material interface.
+func (m *UVCheckerMaterial) Shade(fragment *Fragment) pngimage.RGB {
+	var vertices, ok = m.Model.FaceTextureVertices(fragment.FaceIndex)
+	if !ok {
+		return m.CheckerMaterial.Shade(fragment)
+	}
+	var (
+		bary = fragment.Barycentric
+		u    = bary.X*vertices[0].U + bary.Y*vertices[1].U + bary.Z*vertices[2].U
+		v    = bary.X*vertices[0].V + bary.Y*vertices[1].V + bary.Z*vertices[2].V
+		sum  = math.Floor(u*m.Squares) + math.Floor(v*m.Squares)
+	)
+	if math.Mod(sum, 2) == 0 {
+		return m.Color1
+	}
+	return m.Color2
+}
+
+// Creates a new black and white UVCheckerMaterial of the model with the specified number of squares
+// along each side of the texture and the size of the cubes of the faces without texture vertices.
+func NewUVCheckerMaterial(m *model.Model, squares, size float64) *UVCheckerMaterial {
+	return &UVCheckerMaterial{CheckerMaterial: *NewCheckerMaterial(size), Model: m, Squares: squares}
+}
+
+// Material that shades each face with the material of its index, see model.Face.MaterialIndex,
+// so that the parts of the model made of different materials are painted differently,
+// for example, with the colors of the materials loaded from the .mtl files.
+type MaterialSet struct {
+	Materials []Material // The materials by the indices of the materials of the faces.
+	Default   Material   // Shades the faces without a material or with the index without a non-nil material.
+}
+
+// Implementation of the Shade method in the Material interface.
+func (m *MaterialSet) Shade(fragment *Fragment) pngimage.RGB {
+	if index := fragment.Face.MaterialIndex(); index >= 0 && index < len(m.Materials) && m.Materials[index] != nil {
+		return m.Materials[index].Shade(fragment)
+	}
+	return m.Default.Shade(fragment)
+}
+
+// Creates a new MaterialSet with the materials by the indices of the materials of the faces and the default material.
+func NewMaterialSet(materials []Material, defaultMaterial Material) *MaterialSet {
+	return &MaterialSet{Materials: materials, Default: defaultMaterial}
+}
+
+// Debug material that paints the model with a color map of the scalar values given for each vertex,
+// for example, the curvature stored in a vertex attribute of the model.
+// The values are linearly interpolated over the faces and mapped to the colors
+// from blue for Min through cyan, green and yellow to red for Max, the values outside the range are clamped.
+type HeatmapMaterial struct {
+	Values   []float64 // The value for each vertex of the model in the order of the vertices.
+	Min, Max float64   // The values mapped to the ends of the color map.
+}
+
+// Implementation of the Shade method in the Material interface.
+func (m *HeatmapMaterial) Shade(fragment *Fragment) pngimage.RGB {
+	var i1, i2, i3 = fragment.Face.Indices()
+	if i1 >= len(m.Values) || i2 >= len(m.Values) || i3 >= len(m.Values) {
+		return pngimage.BlackColor()
+	}
+	var (
+		bary  = fragment.Barycentric
+		value = bary.X*m.Values[i1] + bary.Y*m.Values[i2] + bary.Z*m.Values[i3]
+		t     = 0.5
+	)
+	if m.Max != m.Min {
+		t = (value - m.Min) / (m.Max - m.Min)
+	}
+	return heatmapColor(t)
+}
+
+// Creates a new HeatmapMaterial for the values.
+// The range of the color map is set from the 2nd to the 98th percentile of the values,
+// so that a few outliers do not make the rest of the model monochrome.
+func NewHeatmapMaterial(values []float64) *HeatmapMaterial {
+	var bounds = mathutils.Percentiles(values, 2, 98)
+	return &HeatmapMaterial{Values: values, Min: bounds[0], Max: bounds[1]}
+}
+
+// The colors of the heatmap evenly distributed over the range [0, 1].
+var heatmapColors = [...]pngimage.RGB{
+	{R: 0, G: 0, B: 255},
+	{R: 0, G: 255, B: 255},
+	{R: 0, G: 255, B: 0},
+	{R: 255, G: 255, B: 0},
+	{R: 255, G: 0, B: 0},
+}
+
+// Returns the color of the heatmap for the value in the range [0, 1], the values outside the range are clamped.
+func heatmapColor(t float64) pngimage.RGB {
+	if math.IsNaN(t) {
+		return pngimage.BlackColor()
+	}
+	var (
+		position = math.Max(0, math.Min(1, t)) * float64(len(heatmapColors)-1)
+		i        = int(math.Min(position, float64(len(heatmapColors)-2)))
+		amount   = position - float64(i)
+		from, to = heatmapColors[i], heatmapColors[i+1]
+	)
+	return pngimage.RGB{
+		R: blendChannel(from.R, to.R, amount),
+		G: blendChannel(from.G, to.G, amount),
+		B: blendChannel(from.B, to.B, amount),
+	}
+}
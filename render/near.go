@@ -0,0 +1,223 @@
+package render
+
+import (
+	"fmt"
+	"math"
+)
+
+// The way the Renderer and the PainterRenderer draw the faces and the lines with the vertices closer than their near plane,
+// see Renderer.SetNearPlane.
+// The vertices of the models projected with the perspective division, like x/z, land close to the viewer
+// or behind it when their depth is close to zero or negative, and their huge coordinates corrupt the z-buffer.
+type NearPlanePolicy uint8
+
+const (
+	// The near plane is not checked, the faces are drawn whatever the depth of their vertices is.
+	IgnoreNearPlane NearPlanePolicy = iota
+	// The faces and the segments of the lines with at least one vertex closer than the near plane are not drawn.
+	CullNearPlane
+	// The depth of the vertices closer than the near plane is clamped to the near plane, their X and Y are kept.
+	ClampNearPlane
+	// The parts of the faces and the segments closer than the near plane are cut off, the rest of the faces is drawn
+	// with the barycentric coordinates of the fragments relative to the whole faces.
+	ClipNearPlane
+)
+
+// The numbers of the vertices and faces of the models whose drawing was changed by the near plane guard
+// since the Renderer was created or cleared, see Renderer.NearPlaneStats.
+// The vertices are counted for each face and each segment of the lines using them, the segments are not counted as faces.
+type NearPlaneStats struct {
+	// The vertices with NaN or infinite coordinates, the faces with them are never drawn.
+	NonFinite int
+	// The vertices closer than the near plane, not counted for the IgnoreNearPlane policy.
+	Near int
+	// The faces that are not drawn because of the non-finite vertices, the CullNearPlane policy
+	// or because they are entirely closer than the near plane with the ClipNearPlane policy.
+	Culled int
+	// The faces drawn with the clamped depth or clipped by the near plane.
+	Adjusted int
+}
+
+// Returns a summary of the statistics for logging.
+func (stats NearPlaneStats) String() string {
+	return fmt.Sprintf("non-finite vertices: %d, near vertices: %d, culled faces: %d, adjusted faces: %d",
+		stats.NonFinite, stats.Near, stats.Culled, stats.Adjusted)
+}
+
+// A part of the face drawn by the Renderer: the triangle and the barycentric coordinates of its vertices
+// relative to the vertices of the face.
+type facePart struct {
+	triangle Triangle // The vertices of the part in the coordinates of the Target.
+	corners  [3]Vec3  // The barycentric coordinates of the vertices of the part relative to the face.
+}
+
+// The barycentric coordinates of the vertices of the face relative to itself.
+var wholeFace = [3]Vec3{{X: 1}, {Y: 1}, {Z: 1}}
+
+// The near plane of the Renderer and the PainterRenderer, which embed it, so that both of them guard the faces
+// against the vertices closer than the near plane in the same way.
+type nearPlaneGuard struct {
+	near       float64         // The depth of the near plane, see Renderer.SetNearPlane.
+	nearPolicy NearPlanePolicy // The way the faces closer than the near plane are drawn.
+	nearStats  NearPlaneStats  // The problems found by the near plane guard since the last Clear.
+}
+
+// Sets the depth of the near plane in the coordinates of the Target and the way the faces closer than it are drawn.
+// By default, the depth is zero and the policy is IgnoreNearPlane. The faces with the vertices
+// with NaN or infinite coordinates are never drawn, regardless of the policy, see Renderer.NearPlaneStats.
+func (r *nearPlaneGuard) SetNearPlane(depth float64, policy NearPlanePolicy) {
+	r.near = depth
+	r.nearPolicy = policy
+}
+
+// Returns the depth of the near plane and the way the faces closer than it are drawn.
+func (r *nearPlaneGuard) NearPlane() (float64, NearPlanePolicy) {
+	return r.near, r.nearPolicy
+}
+
+// Returns the numbers of the vertices and faces whose drawing was changed by the near plane
+// since the renderer was created or cleared, so that the blank or broken renders can be explained.
+func (r *nearPlaneGuard) NearPlaneStats() NearPlaneStats {
+	return r.nearStats
+}
+
+// Checks the vertices of the triangle against the near plane according to the policy, updates the statistics
+// and fills the parts of the triangle to be drawn. Returns the number of the parts, from 0 to 2.
+func (r *nearPlaneGuard) guardNearPlane(tri Triangle, parts *[2]facePart) int {
+	var nonFinite, near int
+	for _, v := range tri {
+		switch {
+		case !isFinite(v):
+			nonFinite++
+		case v.Z < r.near:
+			near++
+		}
+	}
+	if nonFinite > 0 {
+		r.nearStats.NonFinite += nonFinite
+		r.nearStats.Culled++
+		return 0
+	}
+	if near == 0 || r.nearPolicy == IgnoreNearPlane {
+		parts[0] = facePart{triangle: tri, corners: wholeFace}
+		return 1
+	}
+	r.nearStats.Near += near
+	switch {
+	case r.nearPolicy == CullNearPlane, r.nearPolicy == ClipNearPlane && near == 3:
+		r.nearStats.Culled++
+		return 0
+	case r.nearPolicy == ClampNearPlane:
+		for i := range tri {
+			tri[i].Z = math.Max(tri[i].Z, r.near)
+		}
+		parts[0] = facePart{triangle: tri, corners: wholeFace}
+		r.nearStats.Adjusted++
+		return 1
+	default:
+		r.nearStats.Adjusted++
+		return r.clipNearPlane(tri, parts)
+	}
+}
+
+// Cuts off the part of the triangle closer than the near plane and divides the rest of it into triangles,
+// one if a single vertex is behind the near plane and two otherwise. Returns the number of the triangles.
+func (r *nearPlaneGuard) clipNearPlane(tri Triangle, parts *[2]facePart) int {
+	var (
+		vertices [4]Vec3 // The vertices of the clipped polygon.
+		corners  [4]Vec3 // Their barycentric coordinates relative to the triangle.
+		count    int
+	)
+	for i := range tri {
+		var (
+			a, b         = tri[i], tri[(i+1)%3]
+			aBary, bBary = wholeFace[i], wholeFace[(i+1)%3]
+		)
+		if a.Z >= r.near {
+			vertices[count], corners[count] = a, aBary
+			count++
+		}
+		// The edge crossing the near plane adds the point of the intersection.
+		if (a.Z >= r.near) != (b.Z >= r.near) {
+			var t = (r.near - a.Z) / (b.Z - a.Z)
+			vertices[count], corners[count] = lerpVec3(a, b, t), lerpVec3(aBary, bBary, t)
+			vertices[count].Z = r.near
+			count++
+		}
+	}
+	parts[0] = facePart{
+		triangle: Triangle{vertices[0], vertices[1], vertices[2]},
+		corners:  [3]Vec3{corners[0], corners[1], corners[2]},
+	}
+	if count == 3 {
+		return 1
+	}
+	parts[1] = facePart{
+		triangle: Triangle{vertices[0], vertices[2], vertices[3]},
+		corners:  [3]Vec3{corners[0], corners[2], corners[3]},
+	}
+	return 2
+}
+
+// Checks the ends of the segment of a line against the near plane according to the policy like the guardNearPlane
+// and updates the statistics. Returns the ends of the segment to be drawn and false if the segment is not drawn.
+func (r *nearPlaneGuard) guardSegment(a, b Vec3) (Vec3, Vec3, bool) {
+	var nonFinite, near int
+	for _, v := range [...]Vec3{a, b} {
+		switch {
+		case !isFinite(v):
+			nonFinite++
+		case v.Z < r.near:
+			near++
+		}
+	}
+	if nonFinite > 0 {
+		r.nearStats.NonFinite += nonFinite
+		return a, b, false
+	}
+	if near == 0 || r.nearPolicy == IgnoreNearPlane {
+		return a, b, true
+	}
+	r.nearStats.Near += near
+	switch {
+	case r.nearPolicy == CullNearPlane, r.nearPolicy == ClipNearPlane && near == 2:
+		return a, b, false
+	case r.nearPolicy == ClampNearPlane:
+		a.Z, b.Z = math.Max(a.Z, r.near), math.Max(b.Z, r.near)
+		return a, b, true
+	default:
+		// The end closer than the near plane is moved to the point of the intersection.
+		if a.Z < r.near {
+			a, b = b, a
+		}
+		b = lerpVec3(a, b, (r.near-a.Z)/(b.Z-a.Z))
+		b.Z = r.near
+		return a, b, true
+	}
+}
+
+// Draws a single pixel of the clipped part of the face being drawn,
+// converting the barycentric coordinates relative to the part to the ones relative to the face.
+func (d *faceDrawer) plotPartFragment(x, y int, bary Vec3, depth float64) {
+	var c = &d.part.corners
+	d.plotFragment(x, y, Vec3{
+		X: bary.X*c[0].X + bary.Y*c[1].X + bary.Z*c[2].X,
+		Y: bary.X*c[0].Y + bary.Y*c[1].Y + bary.Z*c[2].Y,
+		Z: bary.X*c[0].Z + bary.Y*c[1].Z + bary.Z*c[2].Z,
+	}, depth)
+}
+
+// Reports whether all coordinates of the vector are neither NaN nor infinite.
+func isFinite(v Vec3) bool {
+	for _, c := range [...]float64{v.X, v.Y, v.Z} {
+		if math.IsNaN(c) || math.IsInf(c, 0) {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns the point between the points a and b, t from 0 to 1 specifies the position between them.
+func lerpVec3(a, b Vec3, t float64) Vec3 {
+	return Vec3{X: a.X + (b.X-a.X)*t, Y: a.Y + (b.Y-a.Y)*t, Z: a.Z + (b.Z-a.Z)*t}
+}
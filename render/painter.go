@@ -0,0 +1,68 @@
+package render
+
+import (
+	"computer_graphics/model"
+	"sort"
+)
+
+// A face queued for drawing by the PainterRenderer.
+type paintedFace struct {
+	face     *model.Face // The face to be drawn.
+	index    int         // The index of the face in the model.
+	material Material    // The material of the model of the face.
+	depth    float64     // The average depth of the vertices of the face.
+}
+
+// Draws the models on the Target using the painter's algorithm instead of the z-buffer:
+// the faces are sorted by the average depth of their vertices and drawn from the farthest to the closest,
+// so that the closer faces are painted over the farther ones.
+// Unlike the Renderer, does not need memory for the depth of each pixel,
+// but draws the intersecting faces and the faces overlapping each other cyclically incorrectly.
+// The faces with the same depth are drawn in the order in which they were passed to Render.
+// The faces are set up, guarded against the near plane and shaded like the faces of the Renderer, see Renderer.SetNearPlane.
+type PainterRenderer struct {
+	faceDrawer               // Draws the faces on the Target.
+	faces      []paintedFace // The faces passed to Render and not yet drawn.
+}
+
+// Creates a new PainterRenderer that draws on the target.
+func NewPainterRenderer(target Target) *PainterRenderer {
+	var r = &PainterRenderer{}
+	r.init(target)
+	return r
+}
+
+// Returns the Target on which the PainterRenderer draws.
+func (r *PainterRenderer) Target() Target {
+	return r.target
+}
+
+// Queues all faces of the model for drawing with the material.
+// The faces are drawn by Flush, so the model must not be changed until then.
+// Several models with different materials can be queued one after another,
+// their faces are sorted together.
+func (r *PainterRenderer) Render(m *model.Model, material Material) {
+	for i := 0; i < m.FacesCount(); i++ {
+		var face = m.GetFace(i)
+		r.faces = append(r.faces, paintedFace{
+			face:     face,
+			index:    i,
+			material: material,
+			depth:    (face.Vertex1().Z + face.Vertex2().Z + face.Vertex3().Z) / 3,
+		})
+	}
+}
+
+// Draws the queued faces from the farthest to the closest and clears the queue.
+func (r *PainterRenderer) Flush() {
+	// The stable sort keeps the order of the faces with the same depth.
+	sort.SliceStable(r.faces, func(i, j int) bool {
+		return r.faces[i].depth > r.faces[j].depth
+	})
+	for i := range r.faces {
+		var painted = &r.faces[i]
+		r.drawFace(painted.face, painted.index, painted.material, Vec3{}, nil)
+		r.faces[i] = paintedFace{}
+	}
+	r.faces = r.faces[:0]
+}
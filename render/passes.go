@@ -0,0 +1,336 @@
+package render
+
+import (
+	"computer_graphics/pngimage"
+	"fmt"
+	"sort"
+)
+
+// The names of the buffers of the Frame that the passes read and write.
+const (
+	ColorBufferName  = "color"  // The colors of the pixels, see Frame.Color.
+	DepthBufferName  = "depth"  // The depth of the closest surfaces, see Frame.Depth.
+	IDBufferName     = "id"     // The identifiers of the objects drawn in the pixels, see Frame.IDs.
+	ShadowBufferName = "shadow" // The ShadowMap rendered by the ShadowPass.
+)
+
+// The stage of the pipeline to which the pass belongs.
+// The passes of the earlier stages run before the passes of the later ones.
+type Stage uint8
+
+const (
+	ShadowStage      Stage = iota // Rendering the data from the point of view of the lights, for example, shadow maps.
+	OpaqueStage                   // Rendering the opaque surfaces.
+	TransparentStage              // Rendering the transparent surfaces over the opaque ones.
+	PostStage                     // Processing the rendered image, for example, outlines, ambient occlusion or bloom.
+)
+
+// The buffers shared by the passes rendering a single image of the scene.
+type Frame struct {
+	Scene []SceneObject   // The objects of the scene.
+	Color *pngimage.Image // The colors of the pixels, filled with the background.
+	Depth *DepthBuffer    // The depth of the closest surfaces.
+	// The identifiers of the objects drawn in the pixels row by row: the index of the object in the Scene plus one,
+	// 0 for the pixels of the background.
+	IDs []int
+
+	buffers map[string]interface{} // The custom buffers of the passes by their names.
+}
+
+// Creates a new Frame with the buffers of the specified size for rendering the scene,
+// the Color is filled with the background.
+func NewFrame(width, height int, scene []SceneObject, background pngimage.RGB) *Frame {
+	return &Frame{
+		Scene: scene,
+		Color: pngimage.FilledImage(uint(width), uint(height), background),
+		Depth: NewDepthBuffer(width, height),
+		IDs:   make([]int, width*height),
+	}
+}
+
+// Returns the width of the buffers in pixels.
+func (frame *Frame) Width() int {
+	return frame.Depth.Width()
+}
+
+// Returns the height of the buffers in pixels.
+func (frame *Frame) Height() int {
+	return frame.Depth.Height()
+}
+
+// Returns the custom buffer with the specified name written by one of the passes, nil if there is none.
+func (frame *Frame) Buffer(name string) interface{} {
+	return frame.buffers[name]
+}
+
+// Stores the custom buffer with the specified name, so that the following passes can read it.
+func (frame *Frame) SetBuffer(name string, buffer interface{}) {
+	if frame.buffers == nil {
+		frame.buffers = make(map[string]interface{})
+	}
+	frame.buffers[name] = buffer
+}
+
+// A step of the rendering pipeline, see PassGraph.
+// The passes declare the names of the buffers they read and write, so that the PassGraph can order them.
+type Pass struct {
+	Name    string                   // The unique name of the pass.
+	Stage   Stage                    // The stage of the pipeline to which the pass belongs.
+	Inputs  []string                 // The names of the buffers read by the pass.
+	Outputs []string                 // The names of the buffers written by the pass.
+	Run     func(frame *Frame) error // Renders the pass, the error stops the rendering of the frame.
+}
+
+// The rendering pipeline made of the passes, which can be inserted by the users,
+// so that the effects like outlines, ambient occlusion or bloom can be composed without changing the other passes.
+//
+// The passes are run in the order of their stages, a pass runs after all passes of the same or earlier stages
+// that write the buffers it reads, the passes that do not depend on each other run in the order of adding.
+// A pass that reads and writes the same buffer modifies it in place: it runs after the writers of the buffer
+// of the earlier stages and the writers of the same stage added before it, the other writers modify it after the pass.
+// The Color, Depth and IDs of the Frame exist before the first pass, the custom buffers must be written
+// by a pass before they are read.
+type PassGraph struct {
+	passes []Pass // The passes in the order of adding.
+}
+
+// Creates a new PassGraph with the specified passes.
+// Panics if the names of the passes are not unique.
+func NewPassGraph(passes ...Pass) *PassGraph {
+	var graph = &PassGraph{}
+	for _, pass := range passes {
+		if err := graph.Add(pass); err != nil {
+			panic(err)
+		}
+	}
+	return graph
+}
+
+// Creates a new PassGraph rendering the scene with the materials of the objects by the renderer: the OpaquePass.
+func NewDefaultPassGraph(renderer *Renderer) *PassGraph {
+	return NewPassGraph(OpaquePass(renderer))
+}
+
+// Adds the pass to the graph, returns an error if there is already a pass with the same name.
+func (graph *PassGraph) Add(pass Pass) error {
+	if graph.index(pass.Name) >= 0 {
+		return fmt.Errorf("the pass %q is already added", pass.Name)
+	}
+	graph.passes = append(graph.passes, pass)
+	return nil
+}
+
+// Removes the pass with the specified name from the graph, returns false if there is no such pass.
+func (graph *PassGraph) Remove(name string) bool {
+	var i = graph.index(name)
+	if i < 0 {
+		return false
+	}
+	graph.passes = append(graph.passes[:i], graph.passes[i+1:]...)
+	return true
+}
+
+// Returns the index of the pass with the specified name, -1 if there is no such pass.
+func (graph *PassGraph) index(name string) int {
+	for i, pass := range graph.passes {
+		if pass.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// Returns the passes in the order in which they are run.
+// Returns an error if a pass reads a custom buffer that is not written by a pass of the same or an earlier stage
+// or the passes of the same stage depend on each other in a cycle.
+func (graph *PassGraph) Order() ([]Pass, error) {
+	var (
+		count      = len(graph.passes)
+		dependents = make([][]int, count) // The passes reading the buffers written by each pass.
+		waiting    = make([]int, count)   // The number of the passes that each pass waits for.
+	)
+	for i, reader := range graph.passes {
+		for _, input := range reader.Inputs {
+			var (
+				written  = false
+				modifies = contains(reader.Outputs, input)
+			)
+			for j, writer := range graph.passes {
+				if i == j || !contains(writer.Outputs, input) {
+					continue
+				}
+				// The writers running after the pass modifying the buffer are not the dependencies of the pass.
+				if modifies && (writer.Stage > reader.Stage || writer.Stage == reader.Stage && j > i) {
+					continue
+				}
+				if writer.Stage > reader.Stage {
+					return nil, fmt.Errorf("the pass %q reads the %s buffer written by the pass %q of a later stage",
+						reader.Name, input, writer.Name)
+				}
+				written = true
+				dependents[j] = append(dependents[j], i)
+				waiting[i]++
+			}
+			if !written && !builtinBuffer(input) {
+				return nil, fmt.Errorf("the %s buffer read by the pass %q is not written by any pass", input, reader.Name)
+			}
+		}
+	}
+	// The ready passes are taken by their stages and the order of adding.
+	var (
+		order = make([]Pass, 0, count)
+		ready []int
+	)
+	for i := range graph.passes {
+		if waiting[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+	for len(ready) > 0 {
+		sort.Slice(ready, func(a, b int) bool {
+			var passA, passB = graph.passes[ready[a]], graph.passes[ready[b]]
+			if passA.Stage != passB.Stage {
+				return passA.Stage < passB.Stage
+			}
+			return ready[a] < ready[b]
+		})
+		var next = ready[0]
+		ready = ready[1:]
+		order = append(order, graph.passes[next])
+		for _, dependent := range dependents[next] {
+			waiting[dependent]--
+			if waiting[dependent] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+	if len(order) != count {
+		return nil, fmt.Errorf("the passes depend on each other in a cycle")
+	}
+	return order, nil
+}
+
+// Runs the passes on the frame in their order, see PassGraph.Order.
+// Stops and returns the error if the passes cannot be ordered or one of them fails.
+func (graph *PassGraph) Render(frame *Frame) error {
+	var order, err = graph.Order()
+	if err != nil {
+		return err
+	}
+	for _, pass := range order {
+		if err := pass.Run(frame); err != nil {
+			return fmt.Errorf("the pass %q failed: %w", pass.Name, err)
+		}
+	}
+	return nil
+}
+
+// Returns true if the buffer is one of the buffers that the Frame has before the first pass.
+func builtinBuffer(name string) bool {
+	return name == ColorBufferName || name == DepthBufferName || name == IDBufferName
+}
+
+// Returns true if the names contain the name.
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the pass of the OpaqueStage named 'opaque' that draws the objects of the scene with their materials
+// by the renderer and writes the Color, Depth and IDs of the Frame.
+// The renderer draws on the Color and the Depth of the Frame, its settings, like the sample position
+// and the near plane, are kept, so its NearPlaneStats can be read after the frame is rendered.
+func OpaquePass(renderer *Renderer) Pass {
+	return Pass{
+		Name:    "opaque",
+		Stage:   OpaqueStage,
+		Outputs: []string{ColorBufferName, DepthBufferName, IDBufferName},
+		Run: func(frame *Frame) error {
+			var material = &idMaterial{ids: frame.IDs, width: frame.Width()}
+			renderer.target, renderer.depth = frame.Color, frame.Depth
+			renderer.nearStats = NearPlaneStats{}
+			for i, object := range frame.Scene {
+				material.base = object.Material
+				material.id = i + 1
+				renderer.Render(object.Model, material)
+			}
+			return nil
+		},
+	}
+}
+
+// Returns the pass of the ShadowStage named 'shadow' that renders the objects of the scene to the shadow map
+// and stores it in the shadow buffer of the Frame.
+// The pass runs before the passes of the later stages, so the map is ready when the ShadowedMaterials
+// of the objects are drawn by the OpaquePass.
+func ShadowPass(shadow *ShadowMap) Pass {
+	return Pass{
+		Name:    "shadow",
+		Stage:   ShadowStage,
+		Outputs: []string{ShadowBufferName},
+		Run: func(frame *Frame) error {
+			shadow.depth.Clear()
+			for _, object := range frame.Scene {
+				shadow.Render(object.Model)
+			}
+			frame.SetBuffer(ShadowBufferName, shadow)
+			return nil
+		},
+	}
+}
+
+// Returns the pass of the PostStage named 'outline' that draws the silhouettes of the objects with the color:
+// the pixels of an object next to the pixels of the background or of another object, found by the IDs of the Frame.
+func OutlinePass(rgb pngimage.RGB) Pass {
+	return Pass{
+		Name:    "outline",
+		Stage:   PostStage,
+		Inputs:  []string{IDBufferName},
+		Outputs: []string{ColorBufferName},
+		Run: func(frame *Frame) error {
+			var width, height = frame.Width(), frame.Height()
+			for y := 0; y < height; y++ {
+				for x := 0; x < width; x++ {
+					var id = frame.IDs[y*width+x]
+					if id == 0 {
+						continue
+					}
+					// The edge is drawn on the pixels of the closer object, so that it is not covered by the farther one.
+					for _, p := range [...][2]int{{x - 1, y}, {x + 1, y}, {x, y - 1}, {x, y + 1}} {
+						var outside = p[0] < 0 || p[1] < 0 || p[0] >= width || p[1] >= height
+						if outside || frame.IDs[p[1]*width+p[0]] != id && frame.Depth.At(x, y) <= frame.Depth.At(p[0], p[1]) {
+							frame.Color.SetRGB(x, y, rgb)
+							break
+						}
+					}
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// Returns a copy of the pass that also reads the specified buffers, for example, to make it run after a custom pass.
+func (pass Pass) WithInputs(names ...string) Pass {
+	pass.Inputs = append(append([]string(nil), pass.Inputs...), names...)
+	return pass
+}
+
+// Material that writes the identifier of the object to the ID buffer for each drawn pixel
+// and calculates its color by the material of the object.
+type idMaterial struct {
+	base  Material // The material of the object.
+	id    int      // The identifier of the object.
+	ids   []int    // The ID buffer.
+	width int      // The width of the ID buffer.
+}
+
+// Implementation of the Shade method in the Material interface.
+func (m *idMaterial) Shade(fragment *Fragment) pngimage.RGB {
+	m.ids[fragment.Y*m.width+fragment.X] = m.id
+	return m.base.Shade(fragment)
+}
@@ -0,0 +1,449 @@
+package render
+
+import (
+	"computer_graphics/mathutils"
+	"computer_graphics/model"
+	"computer_graphics/pngimage"
+	"math"
+)
+
+// A vector in three-dimensional space.
+type Vec3 struct {
+	X, Y, Z float64
+}
+
+// Returns the length of the vector.
+func (v Vec3) Length() float64 {
+	return math.Sqrt(v.X*v.X + v.Y*v.Y + v.Z*v.Z)
+}
+
+// Returns the vector of unit length with the same direction.
+// The zero vector is returned unchanged.
+func (v Vec3) Normalize() Vec3 {
+	var length = v.Length()
+	if length == 0 {
+		return v
+	}
+	return Vec3{v.X / length, v.Y / length, v.Z / length}
+}
+
+// Returns the difference of the vectors.
+func (v Vec3) Sub(u Vec3) Vec3 {
+	return Vec3{v.X - u.X, v.Y - u.Y, v.Z - u.Z}
+}
+
+// Returns the cross product of the vectors.
+func (v Vec3) Cross(u Vec3) Vec3 {
+	return Vec3{v.Y*u.Z - v.Z*u.Y, v.Z*u.X - v.X*u.Z, v.X*u.Y - v.Y*u.X}
+}
+
+// A triangle defined by its three vertices.
+type Triangle [3]Vec3
+
+// The surface on which the Renderer draws pixels.
+// pngimage.Image implements this interface.
+type Target interface {
+	// Returns the width of the target in pixels.
+	Width() int
+	// Returns the height of the target in pixels.
+	Height() int
+	// Sets the color of the pixel at (x, y).
+	SetRGB(x, y int, rgb pngimage.RGB)
+}
+
+// Target that keeps the colors of the pixels in the high dynamic range, like the Accumulator.
+// The Renderer passes the colors of the HDRMaterial to it without clamping.
+type HDRTarget interface {
+	Target
+	// Sets the color of the pixel at (x, y), the channels are from 0 to 255 and can exceed 255.
+	SetHDR(x, y int, color Vec3)
+}
+
+// Stores the depth of the closest drawn surface for each pixel of the Target.
+type DepthBuffer struct {
+	width, height int
+	depth         []float64
+	background    float64 // The depth of the empty pixels, see DepthBuffer.SetBackground.
+}
+
+// Creates a new DepthBuffer with the specified width and height, filled with the positive infinity.
+func NewDepthBuffer(width, height int) *DepthBuffer {
+	var buffer = &DepthBuffer{width: width, height: height, depth: make([]float64, width*height), background: math.Inf(+1)}
+	buffer.Clear()
+	return buffer
+}
+
+// Fills the DepthBuffer with the background depth, by default the positive infinity, so that any surface is closer.
+func (buffer *DepthBuffer) Clear() {
+	for i := range buffer.depth {
+		buffer.depth[i] = buffer.background
+	}
+}
+
+// Sets the depth with which the DepthBuffer is filled by Clear, for example, the depth of the far plane of the scene,
+// so that the surfaces farther than it are not drawn. Takes effect on the next Clear.
+func (buffer *DepthBuffer) SetBackground(depth float64) {
+	buffer.background = depth
+}
+
+// Returns the depth with which the DepthBuffer is filled by Clear.
+func (buffer *DepthBuffer) Background() float64 {
+	return buffer.background
+}
+
+// Returns the width of the DepthBuffer in pixels.
+func (buffer *DepthBuffer) Width() int {
+	return buffer.width
+}
+
+// Returns the height of the DepthBuffer in pixels.
+func (buffer *DepthBuffer) Height() int {
+	return buffer.height
+}
+
+// Returns the depth stored for the pixel at (x, y).
+func (buffer *DepthBuffer) At(x, y int) float64 {
+	return buffer.depth[y*buffer.width+x]
+}
+
+// Sets the depth for the pixel at (x, y).
+func (buffer *DepthBuffer) Set(x, y int, depth float64) {
+	buffer.depth[y*buffer.width+x] = depth
+}
+
+// Contains information about the point of the face that is drawn in a single pixel.
+type Fragment struct {
+	X, Y        int         // Coordinates of the pixel.
+	Depth       float64     // Z coordinate of the point.
+	Position    Vec3        // Coordinates of the point in the space of the model.
+	Barycentric Vec3        // Barycentric coordinates of the point relative to the vertices of the face.
+	Normal      Vec3        // Unit normal to the surface of the face.
+	Face        *model.Face // The face being drawn.
+	FaceIndex   int         // The index of the face in the model.
+}
+
+// Calculates the color of the drawn points of the model.
+type Material interface {
+	// Returns the color of the pixel in which the fragment is drawn.
+	Shade(fragment *Fragment) pngimage.RGB
+}
+
+// Material that can calculate the colors brighter than white, for example, of the light sources.
+// The Renderer uses ShadeHDR for the HDRTarget and Shade, which clamps the color, for the other targets.
+type HDRMaterial interface {
+	Material
+	// Returns the color of the pixel in which the fragment is drawn with the channels from 0 to 255,
+	// which can exceed 255.
+	ShadeHDR(fragment *Fragment) Vec3
+}
+
+// Draws the color of the fragment calculated by the material in the pixel (x, y) of the target,
+// in the high dynamic range if the target is the HDRTarget.
+func shadePixel(target Target, material Material, fragment *Fragment, x, y int) {
+	var hdr, ok = target.(HDRTarget)
+	if !ok {
+		target.SetRGB(x, y, material.Shade(fragment))
+		return
+	}
+	if m, ok := material.(HDRMaterial); ok {
+		hdr.SetHDR(x, y, m.ShadeHDR(fragment))
+		return
+	}
+	hdr.SetHDR(x, y, rgbToVec3(material.Shade(fragment)))
+}
+
+// Converts the color to a vector of its channels.
+func rgbToVec3(rgb pngimage.RGB) Vec3 {
+	return Vec3{float64(rgb.R), float64(rgb.G), float64(rgb.B)}
+}
+
+// Converts the coordinates of the model vertices to the coordinates of the pixels of the square Target
+// of the specified size, as the Renderer expects: scales the model to fit the Target and moves it to the center.
+// The Y axis of the Target is directed downwards, and the points with the smaller Z are drawn on top.
+// The normals of the faces must be generated again after fitting the model, see model.Model.GenerateNormals.
+func FitToImage(m *model.Model, size uint) {
+	var (
+		min, max = m.Bounds()
+		extent   = math.Max(max.X-min.X, max.Y-min.Y)
+		scale    = 0.9 * float64(size) / extent
+		center   = model.Vertex{X: (min.X + max.X) / 2, Y: (min.Y + max.Y) / 2, Z: (min.Z + max.Z) / 2}
+		half     = float64(size) / 2
+	)
+	if extent == 0 {
+		scale = 1
+	}
+	m.Transform(func(x, y, z float64) (float64, float64, float64) {
+		return half + (x-center.X)*scale, half - (y-center.Y)*scale, (center.Z - z) * scale
+	})
+}
+
+// Draws the models on the Target using the z-buffer to cut off overlapping faces.
+// The coordinates of the model vertices must already be converted to the coordinates of the Target pixels,
+// the Z coordinate is used as the depth: points with a smaller Z overlap the points with a larger one.
+type Renderer struct {
+	faceDrawer              // Draws the faces on the Target.
+	depth      *DepthBuffer // The z-buffer.
+	sample     Vec3         // The position of the sample point within the pixel, see Renderer.SetSamplePosition.
+}
+
+// Creates a new Renderer that draws on the target.
+func NewRenderer(target Target) *Renderer {
+	var r = &Renderer{depth: NewDepthBuffer(target.Width(), target.Height())}
+	r.init(target)
+	return r
+}
+
+// Draws the faces of the models on the Target for the Renderer and the PainterRenderer, which embed it:
+// sets up the fragments of the faces, guards the faces against the near plane and shades their pixels by the materials.
+type faceDrawer struct {
+	target Target // The surface on which the pixels are drawn.
+
+	nearPlaneGuard // The near plane, see Renderer.SetNearPlane.
+
+	// The state of the face being drawn, reused between the faces and the frames,
+	// so that drawing a model does not allocate memory.
+	fragment Fragment                                 // The fragment passed to the material.
+	material Material                                 // The material of the model being drawn.
+	plot     func(x, y int, bary Vec3, depth float64) // The plotFragment method value created once.
+	parts    [2]facePart                              // The parts of the face being drawn left by the near plane guard.
+	part     *facePart                                // The part being drawn.
+	plotPart func(x, y int, bary Vec3, depth float64) // The plotPartFragment method value created once.
+}
+
+// Initializes the faceDrawer drawing on the target, it must not be copied after that.
+func (d *faceDrawer) init(target Target) {
+	d.target = target
+	d.plot = d.plotFragment
+	d.plotPart = d.plotPartFragment
+}
+
+// Draws a single face of the model with the index in the model by the material.
+// The face is sampled at the sample point within the pixels, see Renderer.SetSamplePosition,
+// and tested against the buffer, if it is not nil.
+func (d *faceDrawer) drawFace(face *model.Face, index int, material Material, sample Vec3, buffer *DepthBuffer) {
+	var x, y, z = face.Normal()
+	d.fragment = Fragment{
+		Normal:    Vec3{x, y, z}.Normalize(),
+		Face:      face,
+		FaceIndex: index,
+	}
+	d.material = material
+	var count = d.guardNearPlane(Triangle{vertexToVec3(face.Vertex1()), vertexToVec3(face.Vertex2()), vertexToVec3(face.Vertex3())}, &d.parts)
+	for i := 0; i < count; i++ {
+		d.part = &d.parts[i]
+		var plot = d.plot
+		if d.part.corners != wholeFace {
+			plot = d.plotPart
+		}
+		// Sampling the point (x + dx, y + dy) is the same as sampling the point (x, y) of the shifted face.
+		rasterizeTriangle(
+			d.part.triangle[0].Sub(sample),
+			d.part.triangle[1].Sub(sample),
+			d.part.triangle[2].Sub(sample),
+			d.target.Width(),
+			d.target.Height(),
+			buffer,
+			plot,
+		)
+	}
+	d.material = nil
+	d.part = nil
+}
+
+// Draws a single pixel of the face being drawn, calculating its color by the material.
+func (d *faceDrawer) plotFragment(x, y int, bary Vec3, depth float64) {
+	d.fragment.X = x
+	d.fragment.Y = y
+	d.fragment.Depth = depth
+	d.fragment.Position = Vec3{float64(x), float64(y), depth}
+	d.fragment.Barycentric = bary
+	shadePixel(d.target, d.material, &d.fragment, x, y)
+}
+
+// Returns the Target on which the Renderer draws.
+func (r *Renderer) Target() Target {
+	return r.target
+}
+
+// Returns the z-buffer of the Renderer.
+func (r *Renderer) DepthBuffer() *DepthBuffer {
+	return r.depth
+}
+
+// Sets the position of the point within the pixel at which the faces are sampled:
+// the pixel is covered by the face if the point (x + dx, y + dy) lies inside it.
+// By default, the faces are sampled at the corner of the pixel, (0.5, 0.5) samples them at the center.
+// Changing the position between the frames with random offsets and averaging the frames by the Accumulator
+// gives the anti-aliased image, see JitteredSamples.
+func (r *Renderer) SetSamplePosition(dx, dy float64) {
+	r.sample = Vec3{X: dx, Y: dy}
+}
+
+// Returns the position of the point within the pixel at which the faces are sampled.
+func (r *Renderer) SamplePosition() (dx, dy float64) {
+	return r.sample.X, r.sample.Y
+}
+
+// Clears the z-buffer, so that the next models are drawn over the previous ones,
+// and resets the NearPlaneStats of the frame.
+func (r *Renderer) Clear() {
+	r.depth.Clear()
+	r.nearStats = NearPlaneStats{}
+}
+
+// Draws all faces of the model, calculating the color of each pixel by the material.
+// Several models with different materials can be drawn one after another,
+// they will overlap each other correctly because the z-buffer is shared.
+func (r *Renderer) Render(m *model.Model, material Material) {
+	for i := 0; i < m.FacesCount(); i++ {
+		r.drawFace(m.GetFace(i), i, material, r.sample, r.depth)
+	}
+}
+
+// Draws all lines of the model with the specified color.
+// The lines are tested against the z-buffer, so the faces drawn before hide the lines behind them,
+// this allows to draw the wireframe over the rendered model.
+func (r *Renderer) RenderLines(m *model.Model, rgb pngimage.RGB) {
+	for i := 0; i < m.LinesCount(); i++ {
+		var line = m.GetLine(i)
+		for j := 1; j < line.VerticesCount(); j++ {
+			r.renderSegment(vertexToVec3(line.Vertex(j-1)), vertexToVec3(line.Vertex(j)), rgb)
+		}
+	}
+}
+
+// Draws all points of the model with the specified color as single pixels.
+// The points are tested against the z-buffer in the same way as the lines, see RenderLines.
+func (r *Renderer) RenderPoints(m *model.Model, rgb pngimage.RGB) {
+	for i := 0; i < m.PointsCount(); i++ {
+		var p = vertexToVec3(m.GetPoint(i))
+		r.renderSegment(p, p, rgb)
+	}
+}
+
+// Finds the pixels of the Target covered by the triangle and not overlapped by the surfaces already drawn,
+// updates the z-buffer and calls plot for each of them.
+// The vertices of the triangle must be in the coordinates of the Target pixels, Z is used as the depth.
+// The pixels outside the Target are clipped, the degenerate triangles are not drawn.
+// Allows to implement custom interpolation and effects: plot receives the coordinates of the pixel,
+// the barycentric coordinates of its sample point (see SetSamplePosition) relative to the vertices of the triangle and its depth,
+// and can set the color of the pixel itself.
+func (r *Renderer) RasterizeTriangle(tri Triangle, plot func(x, y int, bary Vec3, depth float64)) {
+	rasterizeTriangle(
+		tri[0].Sub(r.sample),
+		tri[1].Sub(r.sample),
+		tri[2].Sub(r.sample),
+		r.depth.Width(),
+		r.depth.Height(),
+		r.depth,
+		plot,
+	)
+}
+
+// Draws a single segment of the line, interpolating the depth between its ends.
+// The pixels at the same depth as the surface already drawn are not hidden, so the edges of the faces remain visible.
+// The segment is guarded against the near plane like the faces.
+func (r *Renderer) renderSegment(a, b Vec3, rgb pngimage.RGB) {
+	var ok bool
+	if a, b, ok = r.guardSegment(a, b); !ok {
+		return
+	}
+	var steps = int(math.Ceil(math.Max(math.Abs(b.X-a.X), math.Abs(b.Y-a.Y))))
+	for i := 0; i <= steps; i++ {
+		var t = 0.0
+		if steps > 0 {
+			t = float64(i) / float64(steps)
+		}
+		var (
+			x     = int(math.Floor(a.X + (b.X-a.X)*t))
+			y     = int(math.Floor(a.Y + (b.Y-a.Y)*t))
+			depth = a.Z + (b.Z-a.Z)*t
+		)
+		if x < 0 || y < 0 || x >= r.depth.Width() || y >= r.depth.Height() || depth > r.depth.At(x, y) {
+			continue
+		}
+		r.depth.Set(x, y, depth)
+		r.target.SetRGB(x, y, rgb)
+	}
+}
+
+// Converts the vertex of the model to a vector.
+func vertexToVec3(v model.Vertex) Vec3 {
+	return Vec3{v.X, v.Y, v.Z}
+}
+
+// Finds the pixels of the depth buffer covered by the triangle and closer than the surfaces already drawn,
+// updates their depth and calls plot for each of them.
+// If the buffer is nil, the depth test is not performed and plot is called for all the covered pixels
+// of the area with the specified width and height.
+// The pixel (x, y) is covered if its sample point, the point (x, y) itself, that is, the corner of the pixel,
+// lies inside the triangle. The Renderer samples the other points within the pixel, see SetSamplePosition,
+// by shifting the triangle before rasterizing it.
+// Sample points lying exactly on an edge are assigned to only one of the two triangles sharing it,
+// so that there are neither gaps nor overlaps between the adjacent faces.
+func rasterizeTriangle(
+	v1, v2, v3 Vec3,
+	width, height int,
+	buffer *DepthBuffer,
+	plot func(x, y int, bary Vec3, depth float64),
+) {
+	var area = edgeFunction(v1, v2, v3)
+	if area == 0 || math.IsNaN(area) {
+		return
+	}
+	var swapped = area < 0
+	if swapped {
+		v2, v3 = v3, v2
+		area = -area
+	}
+	var (
+		xMin       = int(math.Max(0, math.Ceil(mathutils.Min(v1.X, v2.X, v3.X))))
+		xMax       = int(math.Min(float64(width-1), math.Floor(mathutils.Max(v1.X, v2.X, v3.X))))
+		yMin       = int(math.Max(0, math.Ceil(mathutils.Min(v1.Y, v2.Y, v3.Y))))
+		yMax       = int(math.Min(float64(height-1), math.Floor(mathutils.Max(v1.Y, v2.Y, v3.Y))))
+		w1, w2, w3 float64
+		p          Vec3
+		bary       Vec3
+	)
+	for i := xMin; i <= xMax; i++ {
+		for j := yMin; j <= yMax; j++ {
+			p = Vec3{X: float64(i), Y: float64(j)}
+			w1 = edgeFunction(v2, v3, p)
+			w2 = edgeFunction(v3, v1, p)
+			w3 = edgeFunction(v1, v2, p)
+			if !covers(w1, v2, v3) || !covers(w2, v3, v1) || !covers(w3, v1, v2) {
+				continue
+			}
+			bary = Vec3{w1 / area, w2 / area, w3 / area}
+			p.Z = bary.X*v1.Z + bary.Y*v2.Z + bary.Z*v3.Z
+			if buffer == nil || p.Z < buffer.At(i, j) {
+				if buffer != nil {
+					buffer.Set(i, j, p.Z)
+				}
+				if swapped {
+					bary.Y, bary.Z = bary.Z, bary.Y
+				}
+				plot(i, j, bary, p.Z)
+			}
+		}
+	}
+}
+
+// Returns the doubled signed area of the triangle abp,
+// which is positive if p lies to the left of the directed edge ab in the coordinates of the image.
+func edgeFunction(a, b, p Vec3) float64 {
+	return (b.X-a.X)*(p.Y-a.Y) - (b.Y-a.Y)*(p.X-a.X)
+}
+
+// Reports whether the point with the specified value of the edge function of the directed edge ab
+// belongs to the triangle: the points to the left of the edge belong to it,
+// and the points on the edge belong to it only for the edges directed down or, if horizontal, to the left.
+// The edge shared by two triangles of the same orientation has opposite directions in them,
+// so exactly one of the triangles gets the points lying on it.
+func covers(w float64, a, b Vec3) bool {
+	if w != 0 {
+		return w > 0
+	}
+	var dx, dy = b.X - a.X, b.Y - a.Y
+	return dy > 0 || dy == 0 && dx < 0
+}
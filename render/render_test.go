@@ -0,0 +1,902 @@
+package render
+
+import (
+	"bytes"
+	"computer_graphics/model"
+	"computer_graphics/pngimage"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"testing"
+)
+
+// Creates directories for output, if there are none.
+func TestMain(m *testing.M) {
+	if _, err := os.Stat("testdata"); os.IsNotExist(err) {
+		err = os.Mkdir("testdata", os.ModePerm)
+		if err != nil {
+			panic(err)
+		}
+	}
+	if _, err := os.Stat("testdata/pictures"); os.IsNotExist(err) {
+		err = os.Mkdir("testdata/pictures", os.ModePerm)
+		if err != nil {
+			panic(err)
+		}
+	}
+	m.Run()
+}
+
+// Creates a model of a single triangle with the specified depth covering the upper left half of the square.
+func triangle(size, depth float64) *model.Model {
+	var m = model.NewModel()
+	m.AppendVertex(0, 0, depth)
+	m.AppendVertex(size, 0, depth)
+	m.AppendVertex(0, size, depth)
+	if err := m.AppendFace(1, 2, 3); err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// Example of drawing two overlapping models with different debug materials.
+func ExampleRenderer_Render() {
+	var (
+		img      = pngimage.BlackImage(10, 10)
+		renderer = NewRenderer(img)
+	)
+	renderer.Render(triangle(10, 2), NewUnlitMaterial(pngimage.RedColor()))
+	renderer.Render(triangle(5, 1), NewUnlitMaterial(pngimage.GreenColor()))
+	renderer.Render(triangle(10, 3), NewUnlitMaterial(pngimage.BlueColor()))
+	fmt.Println(img.Get(1, 1))
+	fmt.Println(img.Get(6, 1))
+	fmt.Println(img.Get(9, 9))
+	fmt.Println(renderer.DepthBuffer().At(1, 1))
+	//Output:
+	//{0 255 0}
+	//{255 0 0}
+	//{0 0 0}
+	//1
+}
+
+// Example of the colors produced by the NormalMaterial.
+func ExampleNormalMaterial() {
+	var material = NewNormalMaterial()
+	fmt.Println(material.Shade(&Fragment{Normal: Vec3{0, 0, 1}}))
+	fmt.Println(material.Shade(&Fragment{Normal: Vec3{-1, 0, 0}}))
+	//Output:
+	//{128 128 255}
+	//{0 128 128}
+}
+
+// Example of the colors produced by the CheckerMaterial.
+func ExampleCheckerMaterial() {
+	var material = NewCheckerMaterial(2)
+	fmt.Println(material.Shade(&Fragment{Position: Vec3{1, 1, 1}}))
+	fmt.Println(material.Shade(&Fragment{Position: Vec3{3, 1, 1}}))
+	fmt.Println(material.Shade(&Fragment{Position: Vec3{-1, 1, 1}}))
+	//Output:
+	//{255 255 255}
+	//{0 0 0}
+	//{0 0 0}
+}
+
+// Example of the colors produced by the UVCheckerMaterial for a textured and an untextured face.
+func ExampleUVCheckerMaterial() {
+	var m = model.NewModel()
+	m.AppendVertex(0, 0, 0)
+	m.AppendVertex(1, 0, 0)
+	m.AppendVertex(0, 1, 0)
+	m.AppendTextureVertex(0, 0, 0)
+	m.AppendTextureVertex(1, 0, 0)
+	m.AppendTextureVertex(0, 1, 0)
+	_ = m.AppendTexturedFace(1, 2, 3, 1, 2, 3)
+	_ = m.AppendFace(1, 2, 3)
+	var material = NewUVCheckerMaterial(m, 4, 2)
+	fmt.Println(material.Shade(&Fragment{FaceIndex: 0, Barycentric: Vec3{0.9, 0.05, 0.05}}))
+	fmt.Println(material.Shade(&Fragment{FaceIndex: 0, Barycentric: Vec3{0.6, 0.3, 0.1}}))
+	fmt.Println(material.Shade(&Fragment{FaceIndex: 1, Position: Vec3{3, 1, 1}}))
+	//Output:
+	//{255 255 255}
+	//{0 0 0}
+	//{0 0 0}
+}
+
+// Example of drawing a grid of triangles with each of the debug materials.
+func ExampleRenderer_debugMaterials() {
+	var materials = map[string]Material{
+		"unlit":   NewUnlitMaterial(pngimage.RGB{R: 200, G: 120, B: 40}),
+		"normal":  NewNormalMaterial(),
+		"checker": NewCheckerMaterial(25),
+	}
+	for name, material := range materials {
+		var (
+			img      = pngimage.BlackImage(400, 400)
+			renderer = NewRenderer(img)
+			m        = model.NewModel()
+		)
+		for i := 0; i < 4; i++ {
+			for j := 0; j < 4; j++ {
+				var x, y = float64(i * 100), float64(j * 100)
+				m.AppendVertex(x, y, float64(i))
+				m.AppendVertex(x+100, y, float64(j))
+				m.AppendVertex(x, y+100, float64(i+j))
+				var last = m.VerticesCount()
+				if err := m.AppendFace(last-2, last-1, last); err != nil {
+					fmt.Println(err)
+				}
+			}
+		}
+		renderer.Render(m, material)
+		if err := img.Save("testdata/pictures/debug_material_" + name + ".png"); err != nil {
+			fmt.Println(err)
+		}
+	}
+	fmt.Println("Ok")
+	//Output:
+	//Ok
+}
+
+// Creates a model of a square with the specified depth.
+func square(x, y, size, depth float64) *model.Model {
+	var m = model.NewModel()
+	m.AppendVertex(x, y, depth)
+	m.AppendVertex(x+size, y, depth)
+	m.AppendVertex(x+size, y+size, depth)
+	m.AppendVertex(x, y+size, depth)
+	if err := m.AppendFace(1, 2, 3); err != nil {
+		panic(err)
+	}
+	if err := m.AppendFace(1, 3, 4); err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// Example of hard and soft shadows cast by a small square on a large one when the light is shining along the Z axis.
+func ExampleShadowMap_Visibility() {
+	var (
+		occluder = square(10, 10, 10, 5)
+		shadow   = NewShadowMap(40, 40, func(p Vec3) Vec3 { return p })
+	)
+	shadow.Render(occluder)
+	shadow.Render(square(0, 0, 40, 10))
+	fmt.Println(shadow.Visibility(&Fragment{Position: Vec3{15, 15, 10}}))
+	fmt.Println(shadow.Visibility(&Fragment{Position: Vec3{30, 30, 10}}))
+	fmt.Println(shadow.Visibility(&Fragment{Position: Vec3{21, 15, 10}}))
+	shadow.Radius = 2
+	shadow.Samples = 5
+	fmt.Println(shadow.Visibility(&Fragment{Position: Vec3{15, 15, 10}}))
+	fmt.Println(shadow.Visibility(&Fragment{Position: Vec3{21, 15, 10}}))
+	//Output:
+	//0
+	//1
+	//1
+	//0
+	//0.6
+}
+
+// Example of drawing a shadow with soft edges on the plane under a floating square.
+func ExampleShadowedMaterial() {
+	var (
+		img       = pngimage.BlackImage(200, 200)
+		renderer  = NewRenderer(img)
+		ground    = square(0, 0, 200, 100)
+		occluder  = square(60, 60, 60, 50)
+		toLight   = func(p Vec3) Vec3 { return Vec3{p.X + 0.5*p.Z, p.Y + 0.25*p.Z, p.Z} }
+		shadowMap = NewShadowMap(300, 300, toLight)
+	)
+	shadowMap.Radius = 3
+	shadowMap.Samples = 4
+	shadowMap.Render(ground)
+	shadowMap.Render(occluder)
+	var material = NewShadowedMaterial(NewUnlitMaterial(pngimage.WhiteColor()), shadowMap, 0.6)
+	renderer.Render(ground, material)
+	renderer.Render(occluder, NewUnlitMaterial(pngimage.RedColor()))
+	if err := img.Save("testdata/pictures/soft_shadow.png"); err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println(img.Get(10, 10))
+	fmt.Println(img.Get(45, 70))
+	fmt.Println(img.Get(90, 90))
+	//Output:
+	//{255 255 255}
+	//{102 102 102}
+	//{255 0 0}
+}
+
+// Example of drawing a triangle with the colors of the vertices interpolated over its surface.
+func ExampleRenderer_RasterizeTriangle() {
+	var (
+		img      = pngimage.BlackImage(200, 200)
+		renderer = NewRenderer(img)
+		tri      = Triangle{{10, 10, 1}, {190, 40, 1}, {60, 190, 1}}
+	)
+	renderer.RasterizeTriangle(tri, func(x, y int, bary Vec3, depth float64) {
+		img.SetRGB(x, y, pngimage.RGB{R: uint8(255 * bary.X), G: uint8(255 * bary.Y), B: uint8(255 * bary.Z)})
+	})
+	var covered = 0
+	renderer.RasterizeTriangle(tri, func(int, int, Vec3, float64) {
+		covered++
+	})
+	if err := img.Save("testdata/pictures/interpolated_colors.png"); err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println(img.Get(11, 11))
+	fmt.Println(covered)
+	//Output:
+	//{252 1 1}
+	//0
+}
+
+// Example of the colors produced by the HeatmapMaterial.
+func ExampleHeatmapMaterial() {
+	var (
+		m        = triangle(10, 1)
+		material = &HeatmapMaterial{Values: []float64{0, 1, 2}, Min: 0, Max: 2}
+		fragment = Fragment{Face: m.GetFace(0)}
+	)
+	for _, bary := range []Vec3{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}, {0.5, 0.5, 0}} {
+		fragment.Barycentric = bary
+		fmt.Println(material.Shade(&fragment))
+	}
+	//Output:
+	//{0 0 255}
+	//{0 255 0}
+	//{255 0 0}
+	//{0 255 255}
+}
+
+// Example of drawing the lines of the model over its faces.
+func ExampleRenderer_RenderLines() {
+	var (
+		img      = pngimage.BlackImage(10, 10)
+		renderer = NewRenderer(img)
+		m        = model.NewModel()
+	)
+	m.AppendVertex(0, 0, 5)
+	m.AppendVertex(9, 0, 5)
+	m.AppendVertex(0, 9, 5)
+	m.AppendVertex(0, 5, 10)
+	m.AppendVertex(9, 5, 0)
+	_ = m.AppendFace(1, 2, 3)
+	_ = m.AppendLine(4, 5)
+	renderer.Render(m, NewUnlitMaterial(pngimage.WhiteColor()))
+	renderer.RenderLines(m, pngimage.RedColor())
+	for y := 0; y < img.Height(); y++ {
+		for x := 0; x < img.Width(); x++ {
+			switch img.Get(x, y) {
+			case pngimage.RedColor():
+				fmt.Print("#")
+			case pngimage.WhiteColor():
+				fmt.Print("+")
+			default:
+				fmt.Print(".")
+			}
+		}
+		fmt.Println()
+	}
+	// Output:
+	//..........
+	//.++++++++.
+	//.+++++++..
+	//.++++++...
+	//.+++++....
+	//#++++#####
+	//.+++......
+	//.++.......
+	//.+........
+	//..........
+}
+
+// Example of highlighting the faces and the vertices of the model and outlining its faces.
+func ExampleHighlightMaterial() {
+	var (
+		img      = pngimage.BlackImage(12, 12)
+		renderer = NewRenderer(img)
+		m        = square(1, 1, 10, 1)
+		material = NewHighlightMaterial(NewUnlitMaterial(pngimage.WhiteColor()), pngimage.RedColor())
+	)
+	material.Amount = 1
+	material.HighlightFaces(0).HighlightVertices(3)
+	renderer.Render(m, material)
+	renderer.OutlineFaces(m, pngimage.GreenColor(), 1)
+	for y := 0; y < img.Height(); y++ {
+		for x := 0; x < img.Width(); x++ {
+			switch img.Get(x, y) {
+			case pngimage.RedColor():
+				fmt.Print("#")
+			case pngimage.GreenColor():
+				fmt.Print("o")
+			case pngimage.WhiteColor():
+				fmt.Print("+")
+			default:
+				fmt.Print(".")
+			}
+		}
+		fmt.Println()
+	}
+	// Output:
+	//............
+	//.o..........
+	//.oo#########
+	//.o+o########
+	//.o++o#######
+	//.o+++o######
+	//.o++++o#####
+	//.o+++++o####
+	//.o++++++o###
+	//.o+++++++o##
+	//.o#+++++++o#
+	//.ooooooooooo
+}
+
+// Renders the frames of a turntable animation: the model rotates around the vertical axis between the frames.
+// Each frame clears the z-buffer and draws the model over the previous one.
+func renderTurntable(renderer *Renderer, m *model.Model, material Material, frames int) {
+	var rotation = model.AxisRotation(model.Vertex{Y: 1}, 2*math.Pi/float64(frames))
+	// The model is rotated around the center of the target.
+	var center = model.Vertex{X: float64(renderer.Target().Width()) / 2, Z: 50}
+	var step = func(x, y, z float64) (float64, float64, float64) {
+		x, y, z = rotation.Apply(x-center.X, y, z-center.Z)
+		return x + center.X, y, z + center.Z
+	}
+	for i := 0; i < frames; i++ {
+		renderer.Clear()
+		renderer.Render(m, material)
+		m.Transform(step)
+	}
+}
+
+// Example of printing the preview of two overlapping models as text.
+func ExampleTextTarget() {
+	var (
+		target   = NewTextTarget(8, 8)
+		renderer = NewRenderer(target)
+		ascii    bytes.Buffer
+		ansi     bytes.Buffer
+	)
+	renderer.Render(triangle(8, 2), NewUnlitMaterial(pngimage.WhiteColor()))
+	renderer.Render(triangle(4, 1), NewUnlitMaterial(pngimage.BlueColor()))
+	if err := target.WriteASCII(&ascii); err != nil {
+		fmt.Println(err)
+	}
+	if err := target.WriteANSI(&ansi); err != nil {
+		fmt.Println(err)
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(ascii.String(), "\n"), "\n") {
+		fmt.Printf("|%s|\n", line)
+	}
+	fmt.Println(strings.Count(ansi.String(), "\n"))
+	fmt.Printf("%q\n", strings.SplitAfter(ansi.String(), upperHalfBlock)[0])
+	//Output:
+	//|        |
+	//| ...@@@@|
+	//| ..@@@@ |
+	//| .@@@@  |
+	//| @@@@   |
+	//| @@@    |
+	//| @@     |
+	//| @      |
+	//4
+	//"\x1b[38;5;16m\x1b[48;5;16m▀"
+}
+
+// Example of drawing overlapping models with the painter's algorithm in comparison with the z-buffer.
+func ExamplePainterRenderer() {
+	var (
+		img      = pngimage.BlackImage(10, 10)
+		painter  = NewPainterRenderer(img)
+		buffered = pngimage.BlackImage(10, 10)
+		renderer = NewRenderer(buffered)
+	)
+	for _, r := range []interface {
+		Render(m *model.Model, material Material)
+	}{painter, renderer} {
+		r.Render(triangle(5, 1), NewUnlitMaterial(pngimage.GreenColor()))
+		r.Render(triangle(10, 2), NewUnlitMaterial(pngimage.RedColor()))
+		r.Render(triangle(10, 2), NewUnlitMaterial(pngimage.BlueColor()))
+	}
+	painter.Flush()
+	fmt.Println(img.Get(1, 1), buffered.Get(1, 1))
+	fmt.Println(img.Get(6, 1), buffered.Get(6, 1))
+	fmt.Println(img.Get(9, 9), buffered.Get(9, 9))
+	//Output:
+	//{0 255 0} {0 255 0}
+	//{0 0 255} {255 0 0}
+	//{0 0 0} {0 0 0}
+}
+
+// Example of the anti-aliased drawing of a triangle by averaging the frames with jittered sample positions.
+func ExampleAccumulator() {
+	var (
+		accumulator = NewAccumulator(10, 10, pngimage.BlackColor())
+		renderer    = NewRenderer(accumulator)
+		img         = pngimage.BlackImage(10, 10)
+	)
+	renderer.SetSamplePosition(0.5, 0.5)
+	renderer.Render(triangle(10, 1), NewUnlitMaterial(pngimage.WhiteColor()))
+	accumulator.EndFrame()
+	accumulator.Resolve(img)
+	fmt.Println(img.Get(4, 4), img.Get(4, 5), img.Get(5, 5))
+	accumulator.Reset()
+	for _, sample := range JitteredSamples(4, 1) {
+		renderer.Clear()
+		renderer.SetSamplePosition(sample[0], sample[1])
+		renderer.Render(triangle(10, 1), NewUnlitMaterial(pngimage.WhiteColor()))
+		accumulator.EndFrame()
+	}
+	accumulator.Resolve(img)
+	fmt.Println(accumulator.Frames())
+	fmt.Println(img.Get(4, 4), img.Get(4, 5), img.Get(5, 5))
+	//Output:
+	//{255 255 255} {255 255 255} {0 0 0}
+	//16
+	//{255 255 255} {159 159 159} {0 0 0}
+}
+
+// The samples of the emissive material brighter than white are averaged before the tone mapping,
+// so the edge pixels of the bright triangle stay brighter than the edge pixels of the white one.
+func ExampleAccumulator_hdr() {
+	var (
+		accumulator = NewAccumulator(10, 10, pngimage.BlackColor())
+		renderer    = NewRenderer(accumulator)
+		img         = pngimage.BlackImage(10, 10)
+	)
+	for _, material := range []Material{
+		NewUnlitMaterial(pngimage.WhiteColor()),
+		NewEmissiveMaterial(pngimage.WhiteColor(), 4),
+	} {
+		accumulator.Reset()
+		for _, sample := range JitteredSamples(4, 1) {
+			renderer.Clear()
+			renderer.SetSamplePosition(sample[0], sample[1])
+			renderer.Render(triangle(10, 1), material)
+			accumulator.EndFrame()
+		}
+		accumulator.Resolve(img)
+		fmt.Println(img.Get(4, 4), img.Get(4, 5))
+	}
+	accumulator.SetToneMapping(ReinhardToneMapping)
+	accumulator.Resolve(img)
+	fmt.Println(img.Get(4, 4), img.Get(4, 5))
+	//Output:
+	//{255 255 255} {159 159 159}
+	//{255 255 255} {255 255 255}
+	//{204 204 204} {182 182 182}
+}
+
+// Poses an arm made of two segments: the forearm follows the rotation of the upper arm.
+func ExampleRig() {
+	var m = model.NewModel()
+	m.AppendVertex(0, 0, 0)
+	m.AppendVertex(1, 0, 0)
+	m.AppendVertex(2, 0, 0)
+	m.AppendVertex(3, 0, 0)
+	m.StartSubMesh("arm", "upper")
+	m.AppendFace(1, 2, 2)
+	m.StartSubMesh("arm", "forearm")
+	m.AppendFace(3, 4, 4)
+	var (
+		rig      = NewRig(m)
+		upper    = rig.AddHandle("upper", nil, Vec3{0, 0, 0})
+		forearm  = rig.AddHandle("forearm", upper, Vec3{2, 0, 0})
+		printArm = func() {
+			var vertices []string
+			for i := 1; i <= m.VerticesCount(); i++ {
+				var v, _ = m.GetVertex(i)
+				vertices = append(vertices, fmt.Sprintf("(%.2f %.2f %.2f)", v.X, v.Y, v.Z))
+			}
+			fmt.Println(strings.Join(vertices, " "))
+		}
+	)
+	fmt.Println(rig.AssignSubMesh(upper, "upper"), rig.AssignSubMesh(forearm, "forearm"))
+	upper.SetRotation(0, 0, -math.Pi/2)
+	rig.Pose()
+	printArm()
+	forearm.SetRotation(0, 0, math.Pi/2)
+	rig.Pose()
+	printArm()
+	rig.Reset()
+	printArm()
+	//Output:
+	//2 2
+	//(0.00 0.00 0.00) (0.00 1.00 0.00) (0.00 2.00 0.00) (0.00 3.00 0.00)
+	//(0.00 0.00 0.00) (0.00 1.00 0.00) (0.00 2.00 0.00) (1.00 2.00 0.00)
+	//(0.00 0.00 0.00) (1.00 0.00 0.00) (2.00 0.00 0.00) (3.00 0.00 0.00)
+}
+
+// Exports the depth of a tilted square as a grayscale image with the range of the depths in the metadata.
+func ExampleDepthBuffer_SavePNG() {
+	var (
+		m        = model.NewModel()
+		renderer = NewRenderer(pngimage.BlackImage(10, 10))
+		buffer   = renderer.DepthBuffer()
+	)
+	m.AppendVertex(0, 0, 10)
+	m.AppendVertex(10, 0, 20)
+	m.AppendVertex(10, 10, 20)
+	m.AppendVertex(0, 10, 10)
+	m.AppendFace(1, 2, 3)
+	m.AppendFace(1, 3, 4)
+	buffer.SetBackground(19)
+	renderer.Clear()
+	renderer.Render(m, NewUnlitMaterial(pngimage.WhiteColor()))
+	fmt.Println(buffer.Range())
+	var img = buffer.Image(DepthExport{})
+	fmt.Println(img.Get(0, 5), img.Get(4, 5), img.Get(9, 5))
+	img = buffer.Image(DepthExport{Near: 0, Far: 20, Invert: true})
+	fmt.Println(img.Get(0, 5), img.Get(4, 5), img.Get(9, 5))
+	if err := buffer.SavePNG("testdata/depth.png", DepthExport{}); err != nil {
+		fmt.Println(err)
+		return
+	}
+	var data, _ = os.ReadFile("testdata/depth.png")
+	// The tEXt chunk: the length of the data, the type, the key, the zero byte, the value and the CRC.
+	for _, key := range []string{"depth_min", "depth_max", "depth_near", "depth_far"} {
+		var (
+			start  = bytes.Index(data, []byte("tEXt"+key+"\x00"))
+			length = int(binary.BigEndian.Uint32(data[start-4:]))
+		)
+		fmt.Printf("%s = %s\n", key, data[start+4+len(key)+1:start+4+length])
+	}
+	//Output:
+	//11 18 true
+	//{0 0 0} {146 146 146} {0 0 0}
+	//{255 255 255} {179 179 179} {255 255 255}
+	//depth_min = 11
+	//depth_max = 18
+	//depth_near = 11
+	//depth_far = 18
+}
+
+// Example of the near plane guard: a triangle crossing the near plane is drawn with each policy,
+// a triangle with a vertex at the infinity produced by the division by zero is never drawn.
+func ExampleRenderer_SetNearPlane() {
+	var m = model.NewModel()
+	m.AppendVertex(10, 10, -10)
+	m.AppendVertex(90, 10, 10)
+	m.AppendVertex(10, 90, 10)
+	m.AppendVertex(math.Inf(1), 50, 1)
+	if err := m.AppendFace(1, 2, 3); err != nil {
+		panic(err)
+	}
+	if err := m.AppendFace(2, 3, 4); err != nil {
+		panic(err)
+	}
+	for _, policy := range []struct {
+		name   string
+		policy NearPlanePolicy
+	}{
+		{"ignore", IgnoreNearPlane},
+		{"cull", CullNearPlane},
+		{"clamp", ClampNearPlane},
+		{"clip", ClipNearPlane},
+	} {
+		var (
+			img      = pngimage.BlackImage(100, 100)
+			renderer = NewRenderer(img)
+			drawn    = 0
+		)
+		renderer.SetNearPlane(0, policy.policy)
+		renderer.Render(m, NewUnlitMaterial(pngimage.WhiteColor()))
+		for y := 0; y < img.Height(); y++ {
+			for x := 0; x < img.Width(); x++ {
+				if img.Get(x, y) != pngimage.BlackColor() {
+					drawn++
+				}
+			}
+		}
+		fmt.Printf("%s: %d pixels, %s\n", policy.name, drawn, renderer.NearPlaneStats())
+	}
+	// Output:
+	//ignore: 3160 pixels, non-finite vertices: 1, near vertices: 0, culled faces: 1, adjusted faces: 0
+	//cull: 0 pixels, non-finite vertices: 1, near vertices: 1, culled faces: 2, adjusted faces: 0
+	//clamp: 3160 pixels, non-finite vertices: 1, near vertices: 1, culled faces: 1, adjusted faces: 1
+	//clip: 2380 pixels, non-finite vertices: 1, near vertices: 1, culled faces: 1, adjusted faces: 1
+}
+
+// Checks that the PainterRenderer and the lines of the Renderer are guarded against the near plane like the faces.
+func TestNearPlane_painterAndLines(t *testing.T) {
+	var m = model.NewModel()
+	m.AppendVertex(10, 10, -10)
+	m.AppendVertex(90, 10, 10)
+	m.AppendVertex(10, 90, 10)
+	m.AppendVertex(math.Inf(1), 50, 1)
+	if err := m.AppendFace(1, 2, 3); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.AppendFace(2, 3, 4); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.AppendLine(1, 2); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.AppendLine(2, 4); err != nil {
+		t.Fatal(err)
+	}
+	var count = func(img *pngimage.Image) int {
+		var drawn = 0
+		for y := 0; y < img.Height(); y++ {
+			for x := 0; x < img.Width(); x++ {
+				if img.Get(x, y) != pngimage.BlackColor() {
+					drawn++
+				}
+			}
+		}
+		return drawn
+	}
+	for _, policy := range []NearPlanePolicy{IgnoreNearPlane, CullNearPlane, ClampNearPlane, ClipNearPlane} {
+		var (
+			img      = pngimage.BlackImage(100, 100)
+			renderer = NewRenderer(img)
+			painted  = pngimage.BlackImage(100, 100)
+			painter  = NewPainterRenderer(painted)
+		)
+		renderer.SetNearPlane(0, policy)
+		renderer.Render(m, NewUnlitMaterial(pngimage.WhiteColor()))
+		painter.SetNearPlane(0, policy)
+		painter.Render(m, NewUnlitMaterial(pngimage.WhiteColor()))
+		painter.Flush()
+		if count(img) != count(painted) || renderer.NearPlaneStats() != painter.NearPlaneStats() {
+			t.Errorf("policy %d: the Renderer drew %d pixels with %s, the PainterRenderer drew %d pixels with %s",
+				policy, count(img), renderer.NearPlaneStats(), count(painted), painter.NearPlaneStats())
+		}
+	}
+	for _, test := range []struct {
+		policy NearPlanePolicy
+		drawn  int
+		stats  NearPlaneStats
+	}{
+		{IgnoreNearPlane, 81, NearPlaneStats{NonFinite: 1}},
+		{CullNearPlane, 0, NearPlaneStats{NonFinite: 1, Near: 1}},
+		{ClampNearPlane, 81, NearPlaneStats{NonFinite: 1, Near: 1}},
+		{ClipNearPlane, 41, NearPlaneStats{NonFinite: 1, Near: 1}},
+	} {
+		var (
+			img      = pngimage.BlackImage(100, 100)
+			renderer = NewRenderer(img)
+		)
+		renderer.SetNearPlane(0, test.policy)
+		renderer.RenderLines(m, pngimage.WhiteColor())
+		if count(img) != test.drawn || renderer.NearPlaneStats() != test.stats {
+			t.Errorf("policy %d: expected %d pixels with %s, received %d pixels with %s",
+				test.policy, test.drawn, test.stats, count(img), renderer.NearPlaneStats())
+		}
+	}
+}
+
+// Checks that drawing the frames does not allocate memory after the first frame.
+func TestRenderer_Render_allocations(t *testing.T) {
+	var (
+		renderer = NewRenderer(pngimage.BlackImage(100, 100))
+		m        = square(25, 25, 50, 50)
+	)
+	for name, material := range map[string]Material{
+		"unlit":   NewUnlitMaterial(pngimage.WhiteColor()),
+		"normal":  NewNormalMaterial(),
+		"checker": NewCheckerMaterial(8),
+		"heatmap": NewHeatmapMaterial([]float64{0, 1, 2, 3}),
+	} {
+		renderTurntable(renderer, m, material, 1)
+		var allocs = testing.AllocsPerRun(10, func() {
+			renderTurntable(renderer, m, material, 4)
+		})
+		if allocs != 0 {
+			t.Errorf("%s material: %v allocations per turntable, expected 0", name, allocs)
+		}
+	}
+}
+
+// Measures the time and the memory of drawing the frames of the turntable animation.
+func BenchmarkRenderer_Render(b *testing.B) {
+	var (
+		renderer = NewRenderer(pngimage.BlackImage(200, 200))
+		m        = square(50, 50, 100, 50)
+		material = NewNormalMaterial()
+	)
+	renderTurntable(renderer, m, material, 1)
+	b.ReportAllocs()
+	b.ResetTimer()
+	renderTurntable(renderer, m, material, b.N)
+}
+
+// Example of rendering the same scene as it is, as a clay render and as a wireframe side by side.
+func ExampleRenderComparison() {
+	var (
+		scene = []SceneObject{
+			{Model: square(10, 10, 80, 5), Material: NewCheckerMaterial(20)},
+		}
+		layers = []Layer{
+			ShadedLayer("checker", pngimage.BlackColor()),
+			ClayLayer("clay", pngimage.BlackColor(), pngimage.RGB{R: 200, G: 180, B: 160}),
+			WireframeLayer("wireframe", pngimage.WhiteColor(), pngimage.BlackColor()),
+		}
+		strip = RenderComparison(100, 100, scene, layers, pngimage.WhiteColor(), pngimage.RGB{R: 32, G: 32, B: 32})
+	)
+	fmt.Println(strip.Width(), strip.Height())
+	// The points of the face in each panel.
+	fmt.Println(strip.Get(40, 70), strip.Get(144, 70), strip.Get(248, 70))
+	// The edge of the face of the wireframe.
+	fmt.Println(strip.Get(222, 20))
+	if err := strip.Save("testdata/pictures/comparison.png"); err != nil {
+		fmt.Println(err)
+	}
+	// Output:
+	//316 126
+	//{255 255 255} {171 154 137} {255 255 255}
+	//{0 0 0}
+}
+
+// Testing that the passes reading and writing the same buffer run in the order of their stages and adding,
+// instead of being reported as a cycle.
+func TestPassGraph_Order_modify(t *testing.T) {
+	var modify = func(name string, stage Stage) Pass {
+		return Pass{Name: name, Stage: stage, Inputs: []string{ColorBufferName}, Outputs: []string{ColorBufferName}}
+	}
+	var graph = NewPassGraph(
+		modify("tone", PostStage),
+		modify("bloom", PostStage),
+		Pass{Name: "histogram", Stage: PostStage, Inputs: []string{ColorBufferName}, Outputs: []string{"histogram"}},
+		modify("glass", TransparentStage),
+		OpaquePass(NewRenderer(pngimage.BlackImage(1, 1))),
+	)
+	var order, err = graph.Order()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, pass := range order {
+		names = append(names, pass.Name)
+	}
+	if got, want := strings.Join(names, " "), "opaque glass tone bloom histogram"; got != want {
+		t.Errorf("Incorrect order of the passes, got: %s, want: %s", got, want)
+	}
+}
+
+// Example of composing the default passes with the outlines and custom passes exchanging a custom buffer.
+func ExamplePassGraph() {
+	var (
+		scene = []SceneObject{
+			{Model: square(1, 1, 7, 2), Material: NewUnlitMaterial(pngimage.RedColor())},
+			{Model: square(4, 4, 7, 1), Material: NewUnlitMaterial(pngimage.BlueColor())},
+		}
+		frame = NewFrame(12, 12, scene, pngimage.BlackColor())
+		graph = NewDefaultPassGraph(NewRenderer(frame.Color))
+	)
+	// The report is added before the coverage, but runs after it, because it reads the buffer written by the coverage.
+	_ = graph.Add(Pass{
+		Name:   "report",
+		Stage:  PostStage,
+		Inputs: []string{"coverage"},
+		Run: func(frame *Frame) error {
+			fmt.Printf("coverage: %.2f\n", frame.Buffer("coverage"))
+			return nil
+		},
+	})
+	_ = graph.Add(Pass{
+		Name:    "coverage",
+		Stage:   PostStage,
+		Inputs:  []string{IDBufferName},
+		Outputs: []string{"coverage"},
+		Run: func(frame *Frame) error {
+			var covered = 0
+			for _, id := range frame.IDs {
+				if id != 0 {
+					covered++
+				}
+			}
+			frame.SetBuffer("coverage", float64(covered)/float64(len(frame.IDs)))
+			return nil
+		},
+	})
+	_ = graph.Add(OutlinePass(pngimage.WhiteColor()))
+	var order, _ = graph.Order()
+	var names []string
+	for _, pass := range order {
+		names = append(names, pass.Name)
+	}
+	fmt.Println(strings.Join(names, " -> "))
+	if err := graph.Render(frame); err != nil {
+		fmt.Println(err)
+	}
+	for y := 0; y < frame.Height(); y++ {
+		for x := 0; x < frame.Width(); x++ {
+			switch frame.Color.Get(x, y) {
+			case pngimage.WhiteColor():
+				fmt.Print("o")
+			case pngimage.RedColor():
+				fmt.Print("r")
+			case pngimage.BlueColor():
+				fmt.Print("b")
+			default:
+				fmt.Print(".")
+			}
+		}
+		fmt.Println()
+	}
+	graph.Remove("coverage")
+	fmt.Println(graph.Render(frame))
+	// Output:
+	//opaque -> coverage -> report -> outline
+	//coverage: 0.57
+	//............
+	//............
+	//..ooooooo...
+	//..orrrrro...
+	//..orrrrro...
+	//..orrooooooo
+	//..orrobbbbbo
+	//..orrobbbbbo
+	//..oooobbbbbo
+	//.....obbbbbo
+	//.....obbbbbo
+	//.....ooooooo
+	//the coverage buffer read by the pass "report" is not written by any pass
+}
+
+// Example of drawing an upside-down pyramid on the checkered ground plane that fades to the background
+// and receives the soft shadow of the pyramid from the light shining straight down.
+func ExampleGroundMaterial() {
+	var (
+		img     = pngimage.BlackImage(200, 200)
+		pyramid = model.NewModel()
+	)
+	pyramid.AppendVertex(0, 0, 0)
+	pyramid.AppendVertex(-1, 2, -1)
+	pyramid.AppendVertex(1, 2, -1)
+	pyramid.AppendVertex(1, 2, 1)
+	pyramid.AppendVertex(-1, 2, 1)
+	for _, face := range [][3]int{{2, 3, 4}, {2, 4, 5}, {1, 2, 3}, {1, 3, 4}, {1, 4, 5}, {1, 5, 2}} {
+		if err := pyramid.AppendFace(face[0], face[1], face[2]); err != nil {
+			fmt.Println(err)
+		}
+	}
+	var (
+		ground   = NewGroundPlane(pyramid, 8)
+		material = NewGroundMaterial(ground, 1)
+		// Looks at the scene from the front and above: x to the right, y up and z away from the viewer.
+		toScreen = func(x, y, z float64) (float64, float64, float64) {
+			return 100 + 20*x, 140 - 16*y - 12*z, 100 + 16*z - 12*y
+		}
+		// The light shines down, the map is the view from above.
+		toLight = func(p Vec3) Vec3 {
+			var (
+				a = (140 - p.Y) / 20
+				b = (p.Z - 100) / 20
+				y = 0.8*a - 0.6*b
+				z = 0.6*a + 0.8*b
+			)
+			return Vec3{X: p.X, Y: 100 + 20*z, Z: 100 - 20*y}
+		}
+		shadowMap = NewShadowMap(200, 200, toLight)
+	)
+	pyramid.Transform(toScreen)
+	ground.Model.Transform(toScreen)
+	shadowMap.Radius = 3
+	shadowMap.Samples = 4
+	shadowMap.Render(pyramid)
+	shadowMap.Render(ground.Model)
+	material.Shadow = shadowMap
+	var renderer = NewRenderer(img)
+	renderer.Render(ground.Model, material)
+	renderer.Render(pyramid, NewUnlitMaterial(pngimage.RedColor()))
+	if err := img.Save("testdata/pictures/ground_plane.png"); err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println(ground.HalfSize)
+	fmt.Println(img.Get(100, 100))
+	fmt.Println(img.Get(60, 140))
+	fmt.Println(img.Get(80, 140))
+	fmt.Println(img.Get(100, 150))
+	fmt.Println(img.Get(5, 195))
+	// Output:
+	//8
+	//{255 0 0}
+	//{200 200 200}
+	//{105 105 105}
+	//{83 83 83}
+	//{56 56 56}
+}
@@ -0,0 +1,201 @@
+package render
+
+import (
+	"computer_graphics/model"
+	"math"
+)
+
+// A rotation around a pivot followed by a translation: p -> rotation * (p - pivot) + pivot + translation.
+// Stored as a 3x3 matrix and a translation vector, so that the transforms can be composed.
+type rigidTransform struct {
+	m [3][3]float64
+	t Vec3
+}
+
+// Applies the transform to the point.
+func (transform *rigidTransform) apply(p Vec3) Vec3 {
+	var m = &transform.m
+	return Vec3{
+		m[0][0]*p.X + m[0][1]*p.Y + m[0][2]*p.Z + transform.t.X,
+		m[1][0]*p.X + m[1][1]*p.Y + m[1][2]*p.Z + transform.t.Y,
+		m[2][0]*p.X + m[2][1]*p.Y + m[2][2]*p.Z + transform.t.Z,
+	}
+}
+
+// Returns the transform that applies the other transform first and then this one.
+func (transform *rigidTransform) then(other *rigidTransform) rigidTransform {
+	var res rigidTransform
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			for k := 0; k < 3; k++ {
+				res.m[i][j] += transform.m[i][k] * other.m[k][j]
+			}
+		}
+	}
+	res.t = transform.apply(other.t)
+	return res
+}
+
+// Returns the rotation around the pivot by the angles around each axis,
+// the same rotation as the one performed by model.Model.Rotate.
+func rotationAround(pivot Vec3, xAngle, yAngle, zAngle float64) rigidTransform {
+	var (
+		sinX, cosX = math.Sincos(xAngle)
+		sinY, cosY = math.Sincos(yAngle)
+		sinZ, cosZ = math.Sincos(zAngle)
+		res        = rigidTransform{m: [3][3]float64{
+			{cosY * cosZ, cosY * sinZ, sinY},
+			{-(sinX*sinY*cosZ + cosY*sinZ), -sinX*sinY*sinZ + cosX*cosZ, sinX * cosY},
+			{-cosX*sinY*cosZ + sinX*sinZ, -(cosX*sinY*sinZ + sinX*cosY), cosX * cosY},
+		}}
+	)
+	var rotated = res.apply(pivot)
+	res.t = pivot.Sub(rotated)
+	return res
+}
+
+// A named transform handle of the Rig, like a bone of a skeleton:
+// the vertices assigned to the handle are rotated around its pivot together with the vertices of its children.
+type Handle struct {
+	name      string
+	parent    *Handle
+	pivot     Vec3           // The point around which the vertices are rotated in the rest pose.
+	rotation  Vec3           // The angles of the rotation around each axis.
+	transform rigidTransform // The transform of the rest pose to the current pose, calculated by Rig.Pose.
+}
+
+// Returns the name of the handle.
+func (h *Handle) Name() string {
+	return h.name
+}
+
+// Returns the parent of the handle, nil for the root handles.
+func (h *Handle) Parent() *Handle {
+	return h.parent
+}
+
+// Sets the angles of the rotation of the handle around its pivot relative to its parent,
+// the same angles as the ones passed to model.Model.Rotate.
+// The rotation is applied by Rig.Pose.
+func (h *Handle) SetRotation(xAngle, yAngle, zAngle float64) {
+	h.rotation = Vec3{xAngle, yAngle, zAngle}
+}
+
+// Poses the parts of a model for articulated renders, a lightweight precursor to skinning.
+// The vertices are assigned to the named handles organized in a hierarchy,
+// each handle is rotated around its pivot relative to its parent,
+// for example, the head of an animal follows the rotation of its neck.
+// Each vertex follows a single handle, the vertices that are not assigned to any handle do not move.
+//
+// The Rig remembers the rest pose of the model when it is created,
+// Pose changes the vertices of the model starting from the rest pose, so the poses do not accumulate.
+type Rig struct {
+	model   *model.Model
+	rest    []Vec3    // The coordinates of the vertices in the rest pose.
+	handles []*Handle // The handles in the order in which they were added, the parents precede their children.
+	owners  []*Handle // The handles of the vertices, nil for the vertices that are not assigned.
+}
+
+// Creates a new Rig without handles that poses the model, the current vertices of the model are the rest pose.
+func NewRig(m *model.Model) *Rig {
+	var rig = &Rig{
+		model:  m,
+		rest:   make([]Vec3, m.VerticesCount()),
+		owners: make([]*Handle, m.VerticesCount()),
+	}
+	for i := range rig.rest {
+		var v, _ = m.GetVertex(i + 1)
+		rig.rest[i] = vertexToVec3(v)
+	}
+	return rig
+}
+
+// Adds a new handle with the pivot in the rest pose.
+// The parent must be a handle of the same Rig or nil for a root handle.
+func (rig *Rig) AddHandle(name string, parent *Handle, pivot Vec3) *Handle {
+	var h = &Handle{name: name, parent: parent, pivot: pivot}
+	rig.handles = append(rig.handles, h)
+	return h
+}
+
+// Returns the handle by its name, nil if there is no such handle.
+func (rig *Rig) Handle(name string) *Handle {
+	for _, h := range rig.handles {
+		if h.name == name {
+			return h
+		}
+	}
+	return nil
+}
+
+// Assigns the vertices with the specified indices starting from 0 to the handle,
+// replacing their previous handles.
+func (rig *Rig) Assign(h *Handle, indices ...int) {
+	for _, index := range indices {
+		rig.owners[index] = h
+	}
+}
+
+// Assigns the vertices of the faces of the sub-meshes with the object or one of the groups named name
+// to the handle, replacing their previous handles. See model.Model.SubMeshes.
+// Returns the number of the assigned vertices.
+func (rig *Rig) AssignSubMesh(h *Handle, name string) int {
+	var count = 0
+	for _, subMesh := range rig.model.SubMeshes() {
+		if !subMeshNamed(subMesh, name) {
+			continue
+		}
+		for i := subMesh.FirstFace; i < subMesh.FirstFace+subMesh.FacesCount; i++ {
+			var v1, v2, v3 = rig.model.GetFace(i).Indices()
+			for _, index := range [...]int{v1, v2, v3} {
+				if rig.owners[index] != h {
+					rig.owners[index] = h
+					count++
+				}
+			}
+		}
+	}
+	return count
+}
+
+// Reports whether the object or one of the groups of the sub-mesh has the name.
+func subMeshNamed(subMesh model.SubMesh, name string) bool {
+	if subMesh.Object == name {
+		return true
+	}
+	for _, group := range subMesh.Groups {
+		if group == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Moves the vertices of the model to the current pose:
+// the transform of each handle is its rotation around its pivot followed by the transform of its parent,
+// each vertex is moved from the rest pose by the transform of its handle.
+func (rig *Rig) Pose() {
+	for _, h := range rig.handles {
+		var local = rotationAround(h.pivot, h.rotation.X, h.rotation.Y, h.rotation.Z)
+		if h.parent == nil {
+			h.transform = local
+		} else {
+			h.transform = h.parent.transform.then(&local)
+		}
+	}
+	for i, rest := range rig.rest {
+		var p = rest
+		if h := rig.owners[i]; h != nil {
+			p = h.transform.apply(rest)
+		}
+		rig.model.SetVertex(i+1, p.X, p.Y, p.Z)
+	}
+}
+
+// Moves the vertices of the model back to the rest pose and clears the rotations of all handles.
+func (rig *Rig) Reset() {
+	for _, h := range rig.handles {
+		h.rotation = Vec3{}
+	}
+	rig.Pose()
+}
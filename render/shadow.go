@@ -0,0 +1,150 @@
+package render
+
+import (
+	"computer_graphics/model"
+	"computer_graphics/pngimage"
+	"math"
+)
+
+// The largest slope of the surface relative to the light taken into account by the slope-scaled bias.
+// Surfaces almost parallel to the light direction would otherwise get an infinite bias.
+const maxShadowSlope = 10
+
+// Depth map of the scene drawn from the point of view of the light source.
+// Points that are farther from the light than the surface stored in the map are in the shadow.
+//
+// The map is filtered using percentage-closer filtering (PCF):
+// the depth comparison is done for a grid of Samples x Samples points spread over the square
+// with the half side of Radius pixels around the point, and the fraction of the lit points is returned.
+// The Radius of zero or Samples less than 2 give hard shadows.
+//
+// To avoid the self-shadowing of the surfaces (shadow acne),
+// the depth of the point is reduced by Bias plus SlopeBias multiplied by the slope of the surface relative to the light.
+type ShadowMap struct {
+	Radius    float64 // The half side of the PCF kernel in pixels of the map.
+	Samples   int     // The number of the PCF samples along each axis.
+	Bias      float64 // The constant depth bias.
+	SlopeBias float64 // The depth bias per unit of the surface slope relative to the light.
+
+	depth   *DepthBuffer      // The depth of the surfaces closest to the light.
+	toLight func(p Vec3) Vec3 // Converts the point to the pixel coordinates and the depth of the map.
+}
+
+// Creates a new empty ShadowMap with the specified size.
+// The toLight function converts the coordinates of the points, in which the models are drawn by the Renderer,
+// to the coordinates of the map pixels and the depth relative to the light source.
+// By default, hard shadows with the bias of 0.5 and the slope-scaled bias of 1 are used.
+func NewShadowMap(width, height int, toLight func(p Vec3) Vec3) *ShadowMap {
+	return &ShadowMap{
+		Samples:   1,
+		Bias:      0.5,
+		SlopeBias: 1,
+		depth:     NewDepthBuffer(width, height),
+		toLight:   toLight,
+	}
+}
+
+// Returns the depth buffer of the map.
+func (s *ShadowMap) DepthBuffer() *DepthBuffer {
+	return s.depth
+}
+
+// Adds the faces of the model to the map, so that they cast a shadow.
+// The model must be in the same coordinates as the models drawn by the Renderer.
+func (s *ShadowMap) Render(m *model.Model) {
+	for i := 0; i < m.FacesCount(); i++ {
+		var v1, v2, v3 = s.faceToLight(m.GetFace(i))
+		rasterizeTriangle(v1, v2, v3, s.depth.Width(), s.depth.Height(), s.depth, func(int, int, Vec3, float64) {})
+	}
+}
+
+// Returns the fraction of the PCF samples around the fragment that are lit by the light source:
+// 0 means that the fragment is completely in the shadow, 1 means that it is completely lit.
+// Points outside the map are considered lit.
+func (s *ShadowMap) Visibility(fragment *Fragment) float64 {
+	var (
+		p    = s.toLight(fragment.Position)
+		bias = s.Bias
+	)
+	if fragment.Face != nil {
+		bias += s.SlopeBias * s.slope(fragment.Face)
+	}
+	if s.Radius <= 0 || s.Samples < 2 {
+		return s.lit(p.X, p.Y, p.Z-bias)
+	}
+	var (
+		step = 2 * s.Radius / float64(s.Samples-1)
+		sum  float64
+	)
+	for i := 0; i < s.Samples; i++ {
+		for j := 0; j < s.Samples; j++ {
+			sum += s.lit(p.X-s.Radius+float64(i)*step, p.Y-s.Radius+float64(j)*step, p.Z-bias)
+		}
+	}
+	return sum / float64(s.Samples*s.Samples)
+}
+
+// Returns 1 if the point at the specified depth is not overlapped by the surface stored in the map, 0 otherwise.
+// The point is looked up in the nearest pixel of the map.
+func (s *ShadowMap) lit(x, y, depth float64) float64 {
+	var i, j = int(math.Round(x)), int(math.Round(y))
+	if i < 0 || j < 0 || i >= s.depth.Width() || j >= s.depth.Height() {
+		return 1
+	}
+	if depth > s.depth.At(i, j) {
+		return 0
+	}
+	return 1
+}
+
+// Returns the tangent of the angle between the face normal and the light direction in the space of the map,
+// limited by maxShadowSlope.
+func (s *ShadowMap) slope(face *model.Face) float64 {
+	var (
+		v1, v2, v3 = s.faceToLight(face)
+		normal     = v2.Sub(v1).Cross(v3.Sub(v1)).Normalize()
+		cos        = math.Abs(normal.Z)
+	)
+	if cos*maxShadowSlope < math.Sqrt(1-cos*cos) {
+		return maxShadowSlope
+	}
+	return math.Sqrt(1-cos*cos) / cos
+}
+
+// Converts the vertices of the face to the space of the map.
+func (s *ShadowMap) faceToLight(face *model.Face) (Vec3, Vec3, Vec3) {
+	return s.toLight(vertexToVec3(face.Vertex1())),
+		s.toLight(vertexToVec3(face.Vertex2())),
+		s.toLight(vertexToVec3(face.Vertex3()))
+}
+
+// Material that darkens the color of another material in the areas shadowed according to the ShadowMap.
+type ShadowedMaterial struct {
+	Base     Material     // The material that calculates the color of the lit points.
+	Shadow   *ShadowMap   // The map that determines the shadowed points.
+	Darkness float64      // The fraction by which the color of the completely shadowed points is reduced.
+	Color    pngimage.RGB // The color of the shadow that the shadowed points are blended with.
+}
+
+// Implementation of the Shade method in the Material interface.
+func (m *ShadowedMaterial) Shade(fragment *Fragment) pngimage.RGB {
+	var (
+		color  = m.Base.Shade(fragment)
+		amount = m.Darkness * (1 - m.Shadow.Visibility(fragment))
+	)
+	return pngimage.RGB{
+		R: blendChannel(color.R, m.Color.R, amount),
+		G: blendChannel(color.G, m.Color.G, amount),
+		B: blendChannel(color.B, m.Color.B, amount),
+	}
+}
+
+// Creates a new ShadowedMaterial that darkens the base material with a black shadow.
+func NewShadowedMaterial(base Material, shadow *ShadowMap, darkness float64) *ShadowedMaterial {
+	return &ShadowedMaterial{Base: base, Shadow: shadow, Darkness: darkness, Color: pngimage.BlackColor()}
+}
+
+// Linearly blends the two color channel values, amount is the weight of the second one.
+func blendChannel(a, b uint8, amount float64) uint8 {
+	return uint8(math.Round(float64(a)*(1-amount) + float64(b)*amount))
+}
@@ -0,0 +1,103 @@
+package render
+
+import (
+	"bufio"
+	"computer_graphics/pngimage"
+	"fmt"
+	"io"
+)
+
+// The characters of the ASCII output ordered from the darkest to the brightest.
+const asciiRamp = " .:-=+*#%@"
+
+// The character drawn by the ANSI output: the upper half of the cell has the foreground color,
+// the lower half has the background color.
+const upperHalfBlock = "▀"
+
+// Target that stores the pixels in memory and prints them as text,
+// so that quick previews can be shown in a terminal or a CI log without opening image files.
+// Use WriteASCII for plain text output and WriteANSI for colored output in terminals supporting 256 colors.
+type TextTarget struct {
+	width, height int
+	pixels        []pngimage.RGB
+}
+
+// Creates a new black TextTarget with the specified size in pixels.
+func NewTextTarget(width, height int) *TextTarget {
+	return &TextTarget{
+		width:  width,
+		height: height,
+		pixels: make([]pngimage.RGB, width*height),
+	}
+}
+
+// Implementation of the Width method in the Target interface.
+func (target *TextTarget) Width() int {
+	return target.width
+}
+
+// Implementation of the Height method in the Target interface.
+func (target *TextTarget) Height() int {
+	return target.height
+}
+
+// Implementation of the SetRGB method in the Target interface.
+// The pixels outside the target are ignored.
+func (target *TextTarget) SetRGB(x, y int, rgb pngimage.RGB) {
+	if x < 0 || y < 0 || x >= target.width || y >= target.height {
+		return
+	}
+	target.pixels[y*target.width+x] = rgb
+}
+
+// Returns the color of the pixel at (x, y).
+// Returns black color for the pixels outside the target.
+func (target *TextTarget) Get(x, y int) pngimage.RGB {
+	if x < 0 || y < 0 || x >= target.width || y >= target.height {
+		return pngimage.BlackColor()
+	}
+	return target.pixels[y*target.width+x]
+}
+
+// Writes the pixels as lines of characters of the ASCII luminance ramp, one character per pixel.
+// Dark pixels are written as spaces, bright pixels are written as '@'.
+func (target *TextTarget) WriteASCII(w io.Writer) error {
+	var writer = bufio.NewWriter(w)
+	for y := 0; y < target.height; y++ {
+		for x := 0; x < target.width; x++ {
+			var index = int(luminance(target.Get(x, y))) * len(asciiRamp) / 256
+			writer.WriteByte(asciiRamp[index])
+		}
+		writer.WriteByte('\n')
+	}
+	return writer.Flush()
+}
+
+// Writes the pixels using the ANSI escape codes of the 256-color palette and the upper half block characters,
+// so that each character shows two pixels placed one above the other.
+// The last line of the target with an odd height is written with the black lower half.
+// Each line ends with the color reset code.
+func (target *TextTarget) WriteANSI(w io.Writer) error {
+	var writer = bufio.NewWriter(w)
+	for y := 0; y < target.height; y += 2 {
+		for x := 0; x < target.width; x++ {
+			fmt.Fprintf(writer, "\x1b[38;5;%dm\x1b[48;5;%dm%s",
+				ansiColor(target.Get(x, y)), ansiColor(target.Get(x, y+1)), upperHalfBlock)
+		}
+		writer.WriteString("\x1b[0m\n")
+	}
+	return writer.Flush()
+}
+
+// Returns the perceived brightness of the color from 0 to 255 using the Rec. 601 weights.
+func luminance(rgb pngimage.RGB) uint8 {
+	return uint8(0.299*float64(rgb.R) + 0.587*float64(rgb.G) + 0.114*float64(rgb.B))
+}
+
+// Returns the closest color of the 6x6x6 cube of the ANSI 256-color palette.
+func ansiColor(rgb pngimage.RGB) int {
+	var level = func(channel uint8) int {
+		return (int(channel)*5 + 127) / 255
+	}
+	return 16 + 36*level(rgb.R) + 6*level(rgb.G) + level(rgb.B)
+}